@@ -28,10 +28,12 @@ type Client interface {
 	BlockByNumber(context.Context, *big.Int) (*types.Block, error)
 	HeaderByHash(context.Context, common.Hash) (*types.Header, error)
 	HeaderByNumber(context.Context, *big.Int) (*types.Header, error)
+	HeaderHash(*types.Header) common.Hash
 	TransactionByHash(context.Context, common.Hash) (*types.Transaction, bool, error)
 	TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error)
 	TraceTransaction(context.Context, string) (*Call, []*FlatCall, error)
 	TraceBlockByHash(context.Context, string) ([]*Call, [][]*FlatCall, error)
+	TraceCall(context.Context, interfaces.CallMsg) (*SimulatedCall, error)
 	SendTransaction(context.Context, *types.Transaction) error
 	BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error)
 	NonceAt(context.Context, common.Address, *big.Int) (uint64, error)
@@ -42,6 +44,7 @@ type Client interface {
 	CallContract(context.Context, interfaces.CallMsg, *big.Int) ([]byte, error)
 	IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error)
 	GetAtomicUTXOs(ctx context.Context, addrs []ids.ShortID, sourceChain string, limit uint32, startAddress ids.ShortID, startUTXOID ids.ID, options ...rpc.Option) ([][]byte, ids.ShortID, ids.ID, error)
+	GetAtomicTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (evm.Status, error)
 	EstimateBaseFee(ctx context.Context) (*big.Int, error)
 }
 
@@ -70,3 +73,8 @@ func NewClient(ctx context.Context, endpoint string) (Client, error) {
 		ContractClient: NewContractClient(eth.Client),
 	}, nil
 }
+
+// HeaderHash returns the hash of [header]
+func (*client) HeaderHash(header *types.Header) common.Hash {
+	return header.Hash()
+}
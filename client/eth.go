@@ -2,16 +2,22 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/ava-labs/coreth/eth/tracers"
 	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/interfaces"
 	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ava-labs/avalanche-rosetta/constants"
 )
 
 var (
 	tracer        = "callTracer"
 	tracerTimeout = "180s"
-	prefixEth     = "/ext/bc/C/rpc"
+	prefixEth     = "/ext/bc/%s/rpc"
 )
 
 // EthClient provides access to Coreth API
@@ -21,9 +27,14 @@ type EthClient struct {
 	traceConfig *tracers.TraceConfig
 }
 
-// NewEthClient returns a new EVM client
+// NewEthClient returns a new EVM client for the C-chain
 func NewEthClient(ctx context.Context, endpoint string) (*EthClient, error) {
-	endpointURL := endpoint + prefixEth
+	return NewEthChainClient(ctx, endpoint, constants.CChain.String())
+}
+
+// NewEthChainClient returns a new EVM client for [chain], a blockchain ID or alias
+func NewEthChainClient(ctx context.Context, endpoint string, chain string) (*EthClient, error) {
+	endpointURL := endpoint + fmt.Sprintf(prefixEth, chain)
 
 	c, err := rpc.DialContext(ctx, endpointURL)
 	if err != nil {
@@ -83,3 +94,41 @@ func (c *EthClient) TraceBlockByHash(ctx context.Context, hash string) ([]*Call,
 
 	return result, flattened, nil
 }
+
+// TraceCall executes [msg] against the latest state and returns its trace,
+// including the logs emitted by each call
+func (c *EthClient) TraceCall(ctx context.Context, msg interfaces.CallMsg) (*SimulatedCall, error) {
+	var result SimulatedCall
+
+	config := &tracers.TraceCallConfig{
+		TraceConfig: *c.traceConfig,
+	}
+	config.TracerConfig = json.RawMessage(`{"withLog":true}`)
+
+	err := c.rpc.CallContext(ctx, &result, "debug_traceCall", toCallArg(msg), "latest", config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func toCallArg(msg interfaces.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
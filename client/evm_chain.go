@@ -0,0 +1,254 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const blockHashCacheSize = 1024
+
+var errMissingBlockNumber = errors.New("block number missing from block")
+
+// Interface compliance
+var _ Client = &evmChainClient{}
+
+// evmChainClient is a client for EVM chains other than the C-chain, such as
+// Subnet-EVM based L1s. Their block headers lack the coreth specific fields,
+// so blocks are decoded as Subnet-EVM headers and their hashes are the ones
+// reported by the node or, past the cache, computed from the Subnet-EVM header.
+type evmChainClient struct {
+	*client
+
+	// block number -> block hash reported by the node
+	hashes *cache.LRU[uint64, common.Hash]
+}
+
+// NewEVMChainClient returns a new client for the EVM chain [chain], a
+// blockchain ID or alias
+func NewEVMChainClient(ctx context.Context, endpoint string, chain string) (Client, error) {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	eth, err := NewEthChainClient(ctx, endpoint, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &evmChainClient{
+		client: &client{
+			Client:         info.NewClient(endpoint),
+			EvmClient:      evm.NewClient(endpoint, chain),
+			EthClient:      eth,
+			ContractClient: NewContractClient(eth.Client),
+		},
+		hashes: &cache.LRU[uint64, common.Hash]{Size: blockHashCacheSize},
+	}, nil
+}
+
+// BlockByHash returns the block with hash [hash]
+func (c *evmChainClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return c.getBlock(ctx, "eth_getBlockByHash", hash, true)
+}
+
+// BlockByNumber returns the block at height [number], or the last accepted block if nil
+func (c *evmChainClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return c.getBlock(ctx, "eth_getBlockByNumber", toBlockNumArg(number), true)
+}
+
+// HeaderByHash returns the header of the block with hash [hash]
+func (c *evmChainClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	block, err := c.getBlock(ctx, "eth_getBlockByHash", hash, false)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// HeaderByNumber returns the header of the block at height [number], or of
+// the last accepted block if nil
+func (c *evmChainClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	block, err := c.getBlock(ctx, "eth_getBlockByNumber", toBlockNumArg(number), false)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// HeaderHash returns the hash of [header] as reported by the node, or as
+// computed from the Subnet-EVM header if it is no longer cached
+func (c *evmChainClient) HeaderHash(header *types.Header) common.Hash {
+	if hash, ok := c.hashes.Get(header.Number.Uint64()); ok {
+		return hash
+	}
+	return newSubnetEVMHeader(header).Hash()
+}
+
+func (c *evmChainClient) getBlock(ctx context.Context, method string, args ...interface{}) (*types.Block, error) {
+	var raw json.RawMessage
+	if err := c.rpc.CallContext(ctx, &raw, method, args...); err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		rpcSubnetEVMHeader
+		Hash         common.Hash       `json:"hash"`
+		Transactions []json.RawMessage `json:"transactions"`
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, interfaces.NotFound
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, err
+	}
+	if body.Number == nil {
+		return nil, errMissingBlockNumber
+	}
+
+	header := body.header()
+	c.hashes.Put(header.Number.Uint64(), body.Hash)
+
+	txs := make([]*types.Transaction, 0, len(body.Transactions))
+	for _, txJSON := range body.Transactions {
+		// transactions are only listed by hash when not requested in full
+		if len(txJSON) > 0 && txJSON[0] != '{' {
+			continue
+		}
+		tx := &types.Transaction{}
+		if err := json.Unmarshal(txJSON, tx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+
+	return types.NewBlockWithHeader(header).WithBody(txs, nil), nil
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
+
+// rpcSubnetEVMHeader is a Subnet-EVM block header, as returned by the RPC
+type rpcSubnetEVMHeader struct {
+	ParentHash       common.Hash      `json:"parentHash"`
+	UncleHash        common.Hash      `json:"sha3Uncles"`
+	Coinbase         common.Address   `json:"miner"`
+	Root             common.Hash      `json:"stateRoot"`
+	TxHash           common.Hash      `json:"transactionsRoot"`
+	ReceiptHash      common.Hash      `json:"receiptsRoot"`
+	Bloom            types.Bloom      `json:"logsBloom"`
+	Difficulty       *hexutil.Big     `json:"difficulty"`
+	Number           *hexutil.Big     `json:"number"`
+	GasLimit         hexutil.Uint64   `json:"gasLimit"`
+	GasUsed          hexutil.Uint64   `json:"gasUsed"`
+	Time             hexutil.Uint64   `json:"timestamp"`
+	Extra            hexutil.Bytes    `json:"extraData"`
+	MixDigest        common.Hash      `json:"mixHash"`
+	Nonce            types.BlockNonce `json:"nonce"`
+	BaseFee          *hexutil.Big     `json:"baseFeePerGas"`
+	BlockGasCost     *hexutil.Big     `json:"blockGasCost"`
+	BlobGasUsed      *hexutil.Uint64  `json:"blobGasUsed"`
+	ExcessBlobGas    *hexutil.Uint64  `json:"excessBlobGas"`
+	ParentBeaconRoot *common.Hash     `json:"parentBeaconBlockRoot"`
+}
+
+// header returns the coreth header holding the fields of [h]. Its coreth
+// specific fields are left empty.
+func (h *rpcSubnetEVMHeader) header() *types.Header {
+	return &types.Header{
+		ParentHash:       h.ParentHash,
+		UncleHash:        h.UncleHash,
+		Coinbase:         h.Coinbase,
+		Root:             h.Root,
+		TxHash:           h.TxHash,
+		ReceiptHash:      h.ReceiptHash,
+		Bloom:            h.Bloom,
+		Difficulty:       (*big.Int)(h.Difficulty),
+		Number:           (*big.Int)(h.Number),
+		GasLimit:         uint64(h.GasLimit),
+		GasUsed:          uint64(h.GasUsed),
+		Time:             uint64(h.Time),
+		Extra:            h.Extra,
+		MixDigest:        h.MixDigest,
+		Nonce:            h.Nonce,
+		BaseFee:          (*big.Int)(h.BaseFee),
+		BlockGasCost:     (*big.Int)(h.BlockGasCost),
+		BlobGasUsed:      (*uint64)(h.BlobGasUsed),
+		ExcessBlobGas:    (*uint64)(h.ExcessBlobGas),
+		ParentBeaconRoot: h.ParentBeaconRoot,
+	}
+}
+
+// subnetEVMHeader is the Subnet-EVM block header, whose RLP encoding is hashed
+// into the block hash. It differs from the coreth header by the lack of the
+// ExtDataHash and ExtDataGasUsed fields.
+type subnetEVMHeader struct {
+	ParentHash       common.Hash
+	UncleHash        common.Hash
+	Coinbase         common.Address
+	Root             common.Hash
+	TxHash           common.Hash
+	ReceiptHash      common.Hash
+	Bloom            types.Bloom
+	Difficulty       *big.Int
+	Number           *big.Int
+	GasLimit         uint64
+	GasUsed          uint64
+	Time             uint64
+	Extra            []byte
+	MixDigest        common.Hash
+	Nonce            types.BlockNonce
+	BaseFee          *big.Int     `rlp:"optional"`
+	BlockGasCost     *big.Int     `rlp:"optional"`
+	BlobGasUsed      *uint64      `rlp:"optional"`
+	ExcessBlobGas    *uint64      `rlp:"optional"`
+	ParentBeaconRoot *common.Hash `rlp:"optional"`
+}
+
+func newSubnetEVMHeader(h *types.Header) *subnetEVMHeader {
+	return &subnetEVMHeader{
+		ParentHash:       h.ParentHash,
+		UncleHash:        h.UncleHash,
+		Coinbase:         h.Coinbase,
+		Root:             h.Root,
+		TxHash:           h.TxHash,
+		ReceiptHash:      h.ReceiptHash,
+		Bloom:            h.Bloom,
+		Difficulty:       h.Difficulty,
+		Number:           h.Number,
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Time:             h.Time,
+		Extra:            h.Extra,
+		MixDigest:        h.MixDigest,
+		Nonce:            h.Nonce,
+		BaseFee:          h.BaseFee,
+		BlockGasCost:     h.BlockGasCost,
+		BlobGasUsed:      h.BlobGasUsed,
+		ExcessBlobGas:    h.ExcessBlobGas,
+		ParentBeaconRoot: h.ParentBeaconRoot,
+	}
+}
+
+// Hash returns the keccak256 hash of the RLP encoding of [h]
+func (h *subnetEVMHeader) Hash() common.Hash {
+	b, err := rlp.EncodeToBytes(h)
+	if err != nil {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(b)
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// newTestEVMChainClient returns a client of a node serving [block] for any
+// eth_getBlockByNumber or eth_getBlockByHash request
+func newTestEVMChainClient(t *testing.T, block map[string]interface{}) *evmChainClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Contains(t, []string{"eth_getBlockByNumber", "eth_getBlockByHash"}, req.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  block,
+		}))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewEVMChainClient(context.Background(), server.URL, "subnet")
+	require.NoError(t, err)
+	return c.(*evmChainClient)
+}
+
+func rpcBlock(header *ethtypes.Header, hash common.Hash, blockGasCost *big.Int) map[string]interface{} {
+	block := map[string]interface{}{
+		"hash":             hash,
+		"parentHash":       header.ParentHash,
+		"sha3Uncles":       header.UncleHash,
+		"miner":            header.Coinbase,
+		"stateRoot":        header.Root,
+		"transactionsRoot": header.TxHash,
+		"receiptsRoot":     header.ReceiptHash,
+		"logsBloom":        header.Bloom,
+		"difficulty":       (*hexutil.Big)(header.Difficulty),
+		"number":           (*hexutil.Big)(header.Number),
+		"gasLimit":         hexutil.Uint64(header.GasLimit),
+		"gasUsed":          hexutil.Uint64(header.GasUsed),
+		"timestamp":        hexutil.Uint64(header.Time),
+		"extraData":        hexutil.Bytes(header.Extra),
+		"mixHash":          header.MixDigest,
+		"nonce":            header.Nonce,
+		"baseFeePerGas":    (*hexutil.Big)(header.BaseFee),
+		"transactions":     []interface{}{},
+	}
+	if blockGasCost != nil {
+		block["blockGasCost"] = (*hexutil.Big)(blockGasCost)
+	}
+	return block
+}
+
+func TestEVMChainGetBlock(t *testing.T) {
+	header := &ethtypes.Header{
+		ParentHash:  common.HexToHash("0x01"),
+		UncleHash:   ethtypes.EmptyUncleHash,
+		Coinbase:    common.HexToAddress("0x0100000000000000000000000000000000000000"),
+		Root:        common.HexToHash("0x02"),
+		TxHash:      ethtypes.EmptyTxsHash,
+		ReceiptHash: ethtypes.EmptyReceiptsHash,
+		Difficulty:  big.NewInt(1),
+		Number:      big.NewInt(42),
+		GasLimit:    8_000_000,
+		GasUsed:     21_000,
+		Time:        1_700_000_000,
+		Extra:       []byte{0x01, 0x02},
+		BaseFee:     big.NewInt(25_000_000_000),
+	}
+
+	t.Run("header without block gas cost", func(t *testing.T) {
+		require := require.New(t)
+
+		// without the coreth fields and the block gas cost, the Subnet-EVM
+		// header is encoded as the go-ethereum London header
+		hash := header.Hash()
+		c := newTestEVMChainClient(t, rpcBlock(header, hash, nil))
+
+		block, err := c.BlockByNumber(context.Background(), big.NewInt(42))
+		require.NoError(err)
+		require.Equal(uint64(42), block.NumberU64())
+		require.Equal(hash, c.HeaderHash(block.Header()))
+
+		// the hash is computed once the block is no longer cached
+		c.hashes = &cache.LRU[uint64, common.Hash]{Size: blockHashCacheSize}
+		require.Equal(hash, c.HeaderHash(block.Header()))
+	})
+
+	t.Run("subnet-evm header", func(t *testing.T) {
+		require := require.New(t)
+
+		blockGasCost := big.NewInt(100_000)
+		encoded, err := rlp.EncodeToBytes([]interface{}{
+			header.ParentHash,
+			header.UncleHash,
+			header.Coinbase,
+			header.Root,
+			header.TxHash,
+			header.ReceiptHash,
+			header.Bloom,
+			header.Difficulty,
+			header.Number,
+			header.GasLimit,
+			header.GasUsed,
+			header.Time,
+			header.Extra,
+			header.MixDigest,
+			header.Nonce,
+			header.BaseFee,
+			blockGasCost,
+		})
+		require.NoError(err)
+		hash := crypto.Keccak256Hash(encoded)
+		c := newTestEVMChainClient(t, rpcBlock(header, hash, blockGasCost))
+
+		h, err := c.HeaderByHash(context.Background(), hash)
+		require.NoError(err)
+		require.Equal(blockGasCost, h.BlockGasCost)
+		require.Equal(common.Hash{}, h.ExtDataHash)
+		require.Equal(hash, c.HeaderHash(h))
+
+		c.hashes = &cache.LRU[uint64, common.Hash]{Size: blockHashCacheSize}
+		require.Equal(hash, c.HeaderHash(h))
+	})
+
+	t.Run("block not found", func(t *testing.T) {
+		c := newTestEVMChainClient(t, nil)
+
+		_, err := c.BlockByNumber(context.Background(), big.NewInt(43))
+		require.ErrorIs(t, err, interfaces.NotFound)
+	})
+}
@@ -26,6 +26,7 @@ import (
 	signer "github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	types "github.com/ava-labs/coreth/core/types"
 	interfaces "github.com/ava-labs/coreth/interfaces"
+	evm "github.com/ava-labs/coreth/plugin/evm"
 	common "github.com/ethereum/go-ethereum/common"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -158,6 +159,26 @@ func (mr *MockClientMockRecorder) EstimateGas(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockClient)(nil).EstimateGas), arg0, arg1)
 }
 
+// GetAtomicTxStatus mocks base method.
+func (m *MockClient) GetAtomicTxStatus(arg0 context.Context, arg1 ids.ID, arg2 ...rpc.Option) (evm.Status, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAtomicTxStatus", varargs...)
+	ret0, _ := ret[0].(evm.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAtomicTxStatus indicates an expected call of GetAtomicTxStatus.
+func (mr *MockClientMockRecorder) GetAtomicTxStatus(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAtomicTxStatus", reflect.TypeOf((*MockClient)(nil).GetAtomicTxStatus), varargs...)
+}
+
 // GetAtomicUTXOs mocks base method.
 func (m *MockClient) GetAtomicUTXOs(arg0 context.Context, arg1 []ids.ShortID, arg2 string, arg3 uint32, arg4 ids.ShortID, arg5 ids.ID, arg6 ...rpc.Option) ([][]byte, ids.ShortID, ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -246,6 +267,20 @@ func (mr *MockClientMockRecorder) HeaderByNumber(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderByNumber", reflect.TypeOf((*MockClient)(nil).HeaderByNumber), arg0, arg1)
 }
 
+// HeaderHash mocks base method.
+func (m *MockClient) HeaderHash(arg0 *types.Header) common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeaderHash", arg0)
+	ret0, _ := ret[0].(common.Hash)
+	return ret0
+}
+
+// HeaderHash indicates an expected call of HeaderHash.
+func (mr *MockClientMockRecorder) HeaderHash(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderHash", reflect.TypeOf((*MockClient)(nil).HeaderHash), arg0)
+}
+
 // IsBootstrapped mocks base method.
 func (m *MockClient) IsBootstrapped(arg0 context.Context, arg1 string, arg2 ...rpc.Option) (bool, error) {
 	m.ctrl.T.Helper()
@@ -366,6 +401,21 @@ func (mr *MockClientMockRecorder) TraceBlockByHash(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceBlockByHash", reflect.TypeOf((*MockClient)(nil).TraceBlockByHash), arg0, arg1)
 }
 
+// TraceCall mocks base method.
+func (m *MockClient) TraceCall(arg0 context.Context, arg1 interfaces.CallMsg) (*SimulatedCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceCall", arg0, arg1)
+	ret0, _ := ret[0].(*SimulatedCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceCall indicates an expected call of TraceCall.
+func (mr *MockClientMockRecorder) TraceCall(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceCall", reflect.TypeOf((*MockClient)(nil).TraceCall), arg0, arg1)
+}
+
 // TraceTransaction mocks base method.
 func (m *MockClient) TraceTransaction(arg0 context.Context, arg1 string) (*Call, []*FlatCall, error) {
 	m.ctrl.T.Helper()
@@ -635,6 +685,27 @@ func (mr *MockPChainClientMockRecorder) GetHeight(arg0 any, arg1 ...any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeight", reflect.TypeOf((*MockPChainClient)(nil).GetHeight), varargs...)
 }
 
+// GetL1Validator mocks base method.
+func (m *MockPChainClient) GetL1Validator(arg0 context.Context, arg1 ids.ID, arg2 ...rpc.Option) (platformvm.L1Validator, uint64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetL1Validator", varargs...)
+	ret0, _ := ret[0].(platformvm.L1Validator)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetL1Validator indicates an expected call of GetL1Validator.
+func (mr *MockPChainClientMockRecorder) GetL1Validator(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetL1Validator", reflect.TypeOf((*MockPChainClient)(nil).GetL1Validator), varargs...)
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockPChainClient) GetLastAccepted(arg0 context.Context, arg1 ...rpc.Option) (indexer.Container, uint64, error) {
 	m.ctrl.T.Helper()
@@ -758,6 +829,26 @@ func (mr *MockPChainClientMockRecorder) GetTxFee(arg0 any, arg1 ...any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxFee", reflect.TypeOf((*MockPChainClient)(nil).GetTxFee), varargs...)
 }
 
+// GetTxStatus mocks base method.
+func (m *MockPChainClient) GetTxStatus(arg0 context.Context, arg1 ids.ID, arg2 ...rpc.Option) (*platformvm.GetTxStatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTxStatus", varargs...)
+	ret0, _ := ret[0].(*platformvm.GetTxStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxStatus indicates an expected call of GetTxStatus.
+func (mr *MockPChainClientMockRecorder) GetTxStatus(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxStatus", reflect.TypeOf((*MockPChainClient)(nil).GetTxStatus), varargs...)
+}
+
 // GetUTXOs mocks base method.
 func (m *MockPChainClient) GetUTXOs(arg0 context.Context, arg1 []ids.ShortID, arg2 uint32, arg3 ids.ShortID, arg4 ids.ID, arg5 ...rpc.Option) ([][]byte, ids.ShortID, ids.ID, error) {
 	m.ctrl.T.Helper()
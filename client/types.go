@@ -3,6 +3,7 @@ package client
 import (
 	"math/big"
 
+	"github.com/ava-labs/coreth/core/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -107,3 +108,68 @@ func (c *Call) init() []*FlatCall {
 
 	return results
 }
+
+// CallLog is a log emitted by a traced call
+type CallLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+	// Position is the number of subcalls of the call emitting the log
+	// that precede it
+	Position hexutil.Uint `json:"position"`
+}
+
+// SimulatedCall is the trace of a call executed against the latest state,
+// along with its output and logs
+type SimulatedCall struct {
+	Call
+	Output       hexutil.Bytes    `json:"output,omitempty"`
+	RevertReason string           `json:"revertReason,omitempty"`
+	Logs         []*CallLog       `json:"logs,omitempty"`
+	Calls        []*SimulatedCall `json:"calls,omitempty"`
+}
+
+// Trace returns the call trace of [c], without outputs and logs, along with
+// its flattened form
+func (c *SimulatedCall) Trace() (*Call, []*FlatCall) {
+	call := c.trace()
+	return call, call.init()
+}
+
+func (c *SimulatedCall) trace() *Call {
+	call := c.Call
+	call.Calls = make([]*Call, len(c.Calls))
+	for i, child := range c.Calls {
+		call.Calls[i] = child.trace()
+	}
+	return &call
+}
+
+// EmittedLogs returns the logs emitted by [c] and its subcalls in execution
+// order. Logs emitted by reverted calls are discarded.
+func (c *SimulatedCall) EmittedLogs() []*types.Log {
+	if len(c.Error) > 0 {
+		return nil
+	}
+
+	logs := []*types.Log{}
+	next := 0
+	for i, child := range c.Calls {
+		for ; next < len(c.Logs) && int(c.Logs[next].Position) <= i; next++ {
+			logs = append(logs, c.Logs[next].log())
+		}
+		logs = append(logs, child.EmittedLogs()...)
+	}
+	for ; next < len(c.Logs); next++ {
+		logs = append(logs, c.Logs[next].log())
+	}
+	return logs
+}
+
+func (l *CallLog) log() *types.Log {
+	return &types.Log{
+		Address: l.Address,
+		Topics:  l.Topics,
+		Data:    l.Data,
+	}
+}
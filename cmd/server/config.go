@@ -1,25 +1,37 @@
 package main
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/ava-labs/avalanche-rosetta/client"
-	"github.com/ava-labs/avalanche-rosetta/mapper"
 	"github.com/ava-labs/avalanche-rosetta/service"
+
+	rosConst "github.com/ava-labs/avalanche-rosetta/constants"
 )
 
 var (
-	errInvalidMode             = errors.New("invalid rosetta mode")
-	errGenesisBlockRequired    = errors.New("genesis block hash is not provided")
-	errInvalidTokenAddress     = errors.New("invalid token address provided")
-	errInvalidErc20Address     = errors.New("not all token addresses provided are valid erc20s")
-	errInvalidIngestionMode    = errors.New("invalid rosetta ingestion mode")
-	errInvalidUnknownTokenMode = errors.New("cannot index unknown tokens while in standard ingestion mode")
+	errInvalidMode               = errors.New("invalid rosetta mode")
+	errGenesisBlockRequired      = errors.New("genesis block hash is not provided")
+	errInvalidTokenAddress       = errors.New("invalid token address provided")
+	errInvalidErc20Address       = errors.New("not all token addresses provided are valid erc20s")
+	errInvalidIngestionMode      = errors.New("invalid rosetta ingestion mode")
+	errInvalidUnknownTokenMode   = errors.New("cannot index unknown tokens while in standard ingestion mode")
+	errInvalidEVMChain           = errors.New("invalid evm chain")
+	errDataDirRequired           = errors.New("data dir is not provided")
+	errInvalidMetadataBundleKey  = errors.New("metadata bundle key must be hex encoded")
+	errMetadataBundleKeyRequired = errors.New("metadata bundle dir requires a metadata bundle key")
+	errSubmitWaitWithoutTracking = errors.New("submit wait timeout requires tracking transactions")
+	errSubmitWaitTooLong         = errors.New("submit wait timeout must be shorter than the write timeout")
+	errInvalidFetchConcurrency   = errors.New("p-chain fetch concurrency must not be negative")
 )
 
 type config struct {
@@ -34,9 +46,47 @@ type config struct {
 
 	IngestionMode          string   `json:"ingestion_mode"`
 	TokenWhiteList         []string `json:"token_whitelist"`
+	TokenWhiteListFiles    []string `json:"token_whitelist_files"`
 	BridgeTokenList        []string `json:"bridge_tokens"`
+	BridgeTokenListFiles   []string `json:"bridge_token_files"`
 	IndexUnknownTokens     bool     `json:"index_unknown_tokens"`
 	ValidateERC20Whitelist bool     `json:"validate_erc20_whitelist"`
+
+	IncludeWhitelistedTokenBalances bool `json:"include_whitelisted_token_balances"`
+	IndexErc721Transfers            bool `json:"index_erc721_transfers"`
+
+	StateSyncEnabled        bool     `json:"state_sync_enabled"`
+	SyncReferenceURLs       []string `json:"sync_reference_urls"`
+	LaggingThresholdSeconds int64    `json:"lagging_threshold_seconds"`
+
+	EVMChains []*evmChainConfig `json:"evm_chains"`
+
+	DataDir           string           `json:"data_dir"`
+	IndexTransactions bool             `json:"index_transactions"`
+	LogBlockEvents    bool             `json:"log_block_events"`
+	IndexStartHeights map[string]int64 `json:"index_start_heights"`
+
+	MetadataBundleDir string `json:"metadata_bundle_dir"`
+	MetadataBundleKey string `json:"metadata_bundle_key"`
+
+	TrackTransactions        bool  `json:"track_transactions"`
+	SubmitWaitTimeoutSeconds int64 `json:"submit_wait_timeout_seconds"`
+
+	PChainFetchConcurrency int      `json:"p_chain_fetch_concurrency"`
+	SignerNodeURLs         []string `json:"signer_node_urls"`
+}
+
+// evmChainConfig describes an EVM chain other than the C-chain, such as a
+// Subnet-EVM based L1, served as a sub network of the Avalanche network
+type evmChainConfig struct {
+	Blockchain       string   `json:"blockchain"`
+	ChainID          int64    `json:"chain_id"`
+	Symbol           string   `json:"symbol"`
+	Decimals         int32    `json:"decimals"`
+	GenesisBlockHash string   `json:"genesis_block_hash"`
+	TokenWhiteList   []string `json:"token_whitelist"`
+
+	TokenWhiteListFiles []string `json:"token_whitelist_files"`
 }
 
 func readConfig(path string) (*config, error) {
@@ -72,6 +122,12 @@ func (c *config) applyDefaults() {
 	if c.ListenAddr == "" {
 		c.ListenAddr = "0.0.0.0:8080"
 	}
+
+	for _, chain := range c.EVMChains {
+		if chain.Decimals == 0 {
+			chain.Decimals = 18
+		}
+	}
 }
 
 func (c *config) validate() error {
@@ -108,11 +164,6 @@ func (c *config) validate() error {
 			if !common.IsHexAddress(token) {
 				return errInvalidTokenAddress
 			}
-
-			// include all bridge tokens within list of tokens whitelisted for indexing
-			if !mapper.EqualFoldContains(c.TokenWhiteList, token) {
-				c.TokenWhiteList = append(c.TokenWhiteList, c.BridgeTokenList...)
-			}
 		}
 	}
 
@@ -123,11 +174,128 @@ func (c *config) validate() error {
 	if c.IngestionMode == service.StandardIngestion && c.IndexUnknownTokens {
 		return errInvalidUnknownTokenMode
 	}
+
+	if (c.IndexTransactions || c.LogBlockEvents || c.IndexErc721Transfers) && c.DataDir == "" {
+		return errDataDirRequired
+	}
+
+	key, err := c.metadataBundleKey()
+	if err != nil {
+		return err
+	}
+	if c.MetadataBundleDir != "" && len(key) == 0 {
+		return errMetadataBundleKeyRequired
+	}
+
+	if c.SubmitWaitTimeoutSeconds > 0 && !c.TrackTransactions {
+		return errSubmitWaitWithoutTracking
+	}
+
+	if time.Duration(c.SubmitWaitTimeoutSeconds)*time.Second >= defaultWriteTimeout {
+		return errSubmitWaitTooLong
+	}
+
+	if c.PChainFetchConcurrency < 0 {
+		return errInvalidFetchConcurrency
+	}
+
+	blockchains := map[string]bool{}
+	for _, chain := range c.EVMChains {
+		if err := chain.validate(); err != nil {
+			return err
+		}
+		if blockchains[chain.Blockchain] {
+			return fmt.Errorf("%w: %s is configured more than once", errInvalidEVMChain, chain.Blockchain)
+		}
+		blockchains[chain.Blockchain] = true
+	}
 	return nil
 }
 
-func (c *config) validateWhitelistOnlyValidErc20s(cli client.Client) error {
+func (c *evmChainConfig) validate() error {
+	switch c.Blockchain {
+	case "":
+		return fmt.Errorf("%w: blockchain not provided", errInvalidEVMChain)
+	case rosConst.CChain.String(), rosConst.PChain.String(), rosConst.XChain.String():
+		return fmt.Errorf("%w: %s is a primary network chain", errInvalidEVMChain, c.Blockchain)
+	}
+
+	if c.ChainID == 0 {
+		return fmt.Errorf("%w: chain id of %s not provided", errInvalidEVMChain, c.Blockchain)
+	}
+
+	if c.Symbol == "" {
+		return fmt.Errorf("%w: currency symbol of %s not provided", errInvalidEVMChain, c.Blockchain)
+	}
+
+	if c.GenesisBlockHash == "" {
+		return fmt.Errorf("%w: %w for %s", errInvalidEVMChain, errGenesisBlockRequired, c.Blockchain)
+	}
+
 	for _, token := range c.TokenWhiteList {
+		if !common.IsHexAddress(token) {
+			return errInvalidTokenAddress
+		}
+	}
+	return nil
+}
+
+// tokenLists returns the token whitelist and the bridge token list, merging
+// the inline lists with the ones read from [TokenWhiteListFiles] and
+// [BridgeTokenListFiles]. Bridge tokens are always whitelisted for indexing.
+func (c *config) tokenLists(chainID int64) ([]string, []string, error) {
+	whiteList, err := mergeTokenLists(c.TokenWhiteList, c.TokenWhiteListFiles, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	bridgeList, err := mergeTokenLists(c.BridgeTokenList, c.BridgeTokenListFiles, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// include all bridge tokens within list of tokens whitelisted for indexing
+	return uniqueTokens(whiteList, bridgeList), bridgeList, nil
+}
+
+// tokenListFiles returns the paths of all the token list files, including
+// the ones of the other EVM chains
+func (c *config) tokenListFiles() []string {
+	files := append([]string{}, c.TokenWhiteListFiles...)
+	files = append(files, c.BridgeTokenListFiles...)
+	for _, chain := range c.EVMChains {
+		files = append(files, chain.TokenWhiteListFiles...)
+	}
+	return files
+}
+
+// tokenWhiteList returns the token whitelist of the chain, merging the inline
+// list with the ones read from [TokenWhiteListFiles]
+func (c *evmChainConfig) tokenWhiteList() ([]string, error) {
+	return mergeTokenLists(c.TokenWhiteList, c.TokenWhiteListFiles, c.ChainID)
+}
+
+// mergeTokenLists returns the tokens of [inline] and of the token list [files]
+// deployed on [chainID], without duplicates
+func mergeTokenLists(inline []string, files []string, chainID int64) ([]string, error) {
+	tokens := append([]string{}, inline...)
+	for _, path := range files {
+		fileTokens, err := readTokenListFile(path, chainID)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, fileTokens...)
+	}
+
+	for _, token := range tokens {
+		if !common.IsHexAddress(token) {
+			return nil, fmt.Errorf("%w: %s", errInvalidTokenAddress, token)
+		}
+	}
+	return uniqueTokens(nil, tokens), nil
+}
+
+func validateWhitelistOnlyValidErc20s(cli client.Client, tokens []string) error {
+	for _, token := range tokens {
 		ethAddress := common.HexToAddress(token)
 		symbol, decimals, err := cli.GetContractInfo(ethAddress, true)
 		if err != nil {
@@ -140,6 +308,18 @@ func (c *config) validateWhitelistOnlyValidErc20s(cli client.Client) error {
 	return nil
 }
 
+// metadataBundleKey returns the key signing off metadata bundles, nil if unset
+func (c *config) metadataBundleKey() ([]byte, error) {
+	if c.MetadataBundleKey == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(c.MetadataBundleKey, "0x"))
+	if err != nil {
+		return nil, errInvalidMetadataBundleKey
+	}
+	return key, nil
+}
+
 func (c *config) avalancheNetworkID() uint32 {
 	// error checked in config.validate
 	res, _ := constants.NetworkID(c.NetworkName)
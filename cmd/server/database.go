@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanche-rosetta/constants"
+)
+
+// Prefixes of the subsystems sharing the database
+var (
+	txIndexPrefix     = []byte("txindex")
+	eventsPrefix      = []byte("events")
+	erc721IndexPrefix = []byte("erc721index")
+)
+
+// databaseVersion identifies the layout of the database. It must be bumped
+// whenever the layout of a subsystem changes: as the database only holds data
+// derived from the blocks, a database with another layout is cleared and
+// rebuilt by following the chains again.
+var (
+	databaseVersionKey = []byte("version")
+	databaseVersion    = []byte{1}
+)
+
+// deleteBatchSize is the number of keys deleted at once when clearing the
+// database
+const deleteBatchSize = 10_000
+
+// openDatabase opens the database persisting the state of the server, such as
+// the transaction index, the block event log and the ERC721 transfer index, in
+// [DataDir]
+func (c *config) openDatabase() (database.Database, error) {
+	if err := os.MkdirAll(c.DataDir, 0o750); err != nil {
+		return nil, err
+	}
+	db, err := leveldb.New(c.DataDir, nil, logging.NoLog{}, prometheus.NewRegistry())
+	if err != nil {
+		return nil, err
+	}
+	if err := migrateDatabase(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// migrateDatabase clears [db] if its layout is not the current one
+func migrateDatabase(db database.Database) error {
+	version, err := db.Get(databaseVersionKey)
+	switch {
+	case err == nil && bytes.Equal(version, databaseVersion):
+		return nil
+	case err != nil && !errors.Is(err, database.ErrNotFound):
+		return err
+	}
+
+	log.Println("database layout changed, rebuilding the indexes derived from the blocks")
+	for {
+		batch := db.NewBatch()
+		it := db.NewIterator()
+		deleted := 0
+		for deleted < deleteBatchSize && it.Next() {
+			if err := batch.Delete(append([]byte{}, it.Key()...)); err != nil {
+				it.Release()
+				return err
+			}
+			deleted++
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			break
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	return db.Put(databaseVersionKey, databaseVersion)
+}
+
+// indexStartHeight returns the height from which the transactions and block
+// events of [network] are recorded. Heights are configured by chain: "C", "P",
+// or the blockchain of an EVM chain.
+func (c *config) indexStartHeight(network *types.NetworkIdentifier) int64 {
+	chain := constants.CChain.String()
+	if network.SubNetworkIdentifier != nil {
+		chain = network.SubNetworkIdentifier.Network
+	}
+	return c.IndexStartHeights[chain]
+}
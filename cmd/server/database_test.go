@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDatabase(t *testing.T) {
+	t.Run("unversioned database is cleared", func(t *testing.T) {
+		db := memdb.New()
+		for i := 0; i < deleteBatchSize+1; i++ {
+			require.NoError(t, db.Put([]byte{byte(i >> 16), byte(i >> 8), byte(i)}, []byte("value")))
+		}
+
+		require.NoError(t, migrateDatabase(db))
+		it := db.NewIterator()
+		defer it.Release()
+		require.True(t, it.Next())
+		require.Equal(t, databaseVersionKey, it.Key())
+		require.Equal(t, databaseVersion, it.Value())
+		require.False(t, it.Next())
+	})
+
+	t.Run("current database is kept", func(t *testing.T) {
+		db := memdb.New()
+		require.NoError(t, db.Put(databaseVersionKey, databaseVersion))
+		require.NoError(t, db.Put([]byte("key"), []byte("value")))
+
+		require.NoError(t, migrateDatabase(db))
+		value, err := db.Get([]byte("key"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+}
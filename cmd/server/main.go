@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/coinbase/rosetta-sdk-go/asserter"
 	"github.com/coinbase/rosetta-sdk-go/server"
@@ -22,6 +24,10 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/service/backend/cchainatomictx"
 	"github.com/ava-labs/avalanche-rosetta/service/backend/pchain"
 	"github.com/ava-labs/avalanche-rosetta/service/backend/pchain/indexer"
+	"github.com/ava-labs/avalanche-rosetta/service/erc721index"
+	"github.com/ava-labs/avalanche-rosetta/service/events"
+	"github.com/ava-labs/avalanche-rosetta/service/follower"
+	"github.com/ava-labs/avalanche-rosetta/service/txindex"
 
 	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 )
@@ -43,10 +49,10 @@ var opts struct {
 func init() {
 	flag.StringVar(&opts.configPath, "config", "", "Path to configuration file")
 	flag.BoolVar(&opts.version, "version", false, "Print version")
-	flag.Parse()
 }
 
 func main() {
+	flag.Parse()
 	if opts.version {
 		log.Printf("%s %s\n", cmdName, cmdVersion)
 		return
@@ -73,17 +79,6 @@ func main() {
 		log.Fatal("client init error:", err)
 	}
 
-	// [ValidateERC20Whitelist] is disabled by default because it requires
-	// a fully synced node to work correctly. If the underlying node is still
-	// bootstrapping, it will fail.
-	//
-	// TODO: Only perform this check after the underlying node is bootstrapped
-	if cfg.Mode == service.ModeOnline && cfg.ValidateERC20Whitelist {
-		if err := cfg.validateWhitelistOnlyValidErc20s(cChainClient); err != nil {
-			log.Fatal("token whitelist validation error:", err)
-		}
-	}
-
 	log.Println("starting server in", cfg.Mode, "mode")
 
 	if cfg.ChainID == 0 {
@@ -108,6 +103,30 @@ func main() {
 		log.Fatal("invalid ChainID:", cfg.ChainID)
 	}
 
+	tokenWhiteList, bridgeTokenList, err := cfg.tokenLists(cfg.ChainID)
+	if err != nil {
+		log.Fatal("token list error:", err)
+	}
+
+	// [ValidateERC20Whitelist] is disabled by default because it requires
+	// a fully synced node to work correctly. If the underlying node is still
+	// bootstrapping, it will fail.
+	//
+	// TODO: Only perform this check after the underlying node is bootstrapped
+	var erc20ValidationClient client.Client
+	if cfg.Mode == service.ModeOnline && cfg.ValidateERC20Whitelist {
+		erc20ValidationClient = cChainClient
+		if err := validateWhitelistOnlyValidErc20s(cChainClient, tokenWhiteList); err != nil {
+			log.Fatal("token whitelist validation error:", err)
+		}
+	}
+
+	// token lists of every chain are reloaded on SIGHUP or whenever the
+	// config file or any of the token list files is modified
+	tokenLists := service.NewTokenLists(tokenWhiteList, bridgeTokenList)
+	evmTokenLists := map[string]*service.TokenLists{}
+	evmValidationClients := map[string]client.Client{}
+
 	// Note: Rosetta is currently configure with capitalized NetworkNames
 	// and service network requests are carried our with capital case.
 	// while avalanchego requires lower-case network names.
@@ -146,6 +165,14 @@ func main() {
 		log.Fatal("unable to initialize p-chain backend:", err)
 	}
 
+	cSyncStatusConfig, pSyncStatusConfig, err := cfg.syncStatusConfigs(context.Background())
+	if err != nil {
+		log.Fatal("unable to initialize sync reference clients:", err)
+	}
+	pChainBackend.SetSyncStatusConfig(pSyncStatusConfig)
+	pChainBackend.SetFetchConcurrency(cfg.PChainFetchConcurrency)
+	pChainBackend.SetSignerNodeURLs(cfg.SignerNodeURLs)
+
 	cChainAtomicTxBackend := cchainatomictx.NewBackend(cChainClient, avaxAssetID, cfg.avalancheNetworkID())
 
 	serviceConfig := &service.Config{
@@ -157,29 +184,149 @@ func main() {
 		AP5Activation:      ap5Activation,
 		IndexUnknownTokens: cfg.IndexUnknownTokens,
 		IngestionMode:      cfg.IngestionMode,
-		TokenWhiteList:     cfg.TokenWhiteList,
-		BridgeTokenList:    cfg.BridgeTokenList,
+		TokenWhiteList:     tokenWhiteList,
+		BridgeTokenList:    bridgeTokenList,
+		TokenLists:         tokenLists,
+
+		IncludeWhitelistedTokenBalances: cfg.IncludeWhitelistedTokenBalances,
+		SyncStatus:                      cSyncStatusConfig,
 	}
 
-	var operationTypes []string
-	operationTypes = append(operationTypes, mapper.OperationTypes...)
-	operationTypes = append(operationTypes, pmapper.OperationTypes...)
+	// Metadata bundles are exported through /call by online instances, and
+	// serve /construction/metadata on offline instances
+	metadataBundleKey, _ := cfg.metadataBundleKey() // error checked in config.validate
+	metadataBundles := service.NewMetadataBundles(cfg.MetadataBundleDir, metadataBundleKey)
+	if cfg.Mode == service.ModeOffline && cfg.MetadataBundleDir != "" {
+		serviceConfig.MetadataBundles = metadataBundles
+	}
 
-	asserter, err := asserter.NewServer(
-		operationTypes, // supported operation types
-		true,           // historical balance lookup
-		[]*types.NetworkIdentifier{ // supported networks
-			networkP,
-			networkC,
-		}, // supported networks
-		[]string{}, // call methods
-		false,      // mempool coins
+	// The database persists the state derived from the blocks of every network
+	var db database.Database
+	if cfg.Mode == service.ModeOnline && (cfg.IndexTransactions || cfg.LogBlockEvents || cfg.IndexErc721Transfers) {
+		db, err = cfg.openDatabase()
+		if err != nil {
+			log.Fatal("unable to open database:", err)
+		}
+		defer db.Close()
+	}
+
+	var erc721Index *erc721index.Index
+	if db != nil && cfg.IndexErc721Transfers {
+		erc721Index = erc721index.New(prefixdb.New(erc721IndexPrefix, db), networkC)
+		serviceConfig.Erc721Index = erc721Index
+	}
+
+	services := newServices(serviceConfig, cChainClient, pChainBackend, cChainAtomicTxBackend)
+	serviceRouter := service.NewRouter(services)
+	// Bundles are only exported when they can be signed off with a key
+	if cfg.Mode == service.ModeOnline && len(metadataBundleKey) != 0 {
+		serviceRouter.SetMetadataBundles(metadataBundles)
+	}
+	networks := []*types.NetworkIdentifier{networkP, networkC}
+
+	// Submitted transactions are polled in the background until they are
+	// accepted, dropped or reverted, and their status served through /call
+	if cfg.Mode == service.ModeOnline && cfg.TrackTransactions {
+		txTracker := service.NewTxTracker(serviceRouter, service.TxTrackerConfig{
+			WaitTimeout: time.Duration(cfg.SubmitWaitTimeoutSeconds) * time.Second,
+		})
+		serviceRouter.SetTxTracker(txTracker)
+		go txTracker.Run(context.Background())
+	}
+
+	// Other EVM chains are served as sub networks, reusing the C-chain
+	// services with their own configuration and client
+	for _, chain := range cfg.EVMChains {
+		networkEVM := &types.NetworkIdentifier{
+			Blockchain: service.BlockchainName,
+			Network:    cfg.NetworkName,
+			SubNetworkIdentifier: &types.SubNetworkIdentifier{
+				Network: chain.Blockchain,
+			},
+		}
+
+		evmClient, err := client.NewEVMChainClient(context.Background(), cfg.RPCBaseURL, chain.Blockchain)
+		if err != nil {
+			log.Fatal("client init error:", err)
+		}
+
+		evmWhiteList, err := chain.tokenWhiteList()
+		if err != nil {
+			log.Fatal("token list error:", err)
+		}
+		if cfg.Mode == service.ModeOnline && cfg.ValidateERC20Whitelist {
+			evmValidationClients[chain.Blockchain] = evmClient
+			if err := validateWhitelistOnlyValidErc20s(evmClient, evmWhiteList); err != nil {
+				log.Fatalf("token whitelist validation error of %s: %v", chain.Blockchain, err)
+			}
+		}
+		evmTokenLists[chain.Blockchain] = service.NewTokenLists(evmWhiteList, nil)
+
+		evmConfig := &service.Config{
+			Mode:               cfg.Mode,
+			ChainID:            big.NewInt(chain.ChainID),
+			NetworkID:          networkEVM,
+			GenesisBlockHash:   chain.GenesisBlockHash,
+			IndexUnknownTokens: cfg.IndexUnknownTokens,
+			IngestionMode:      cfg.IngestionMode,
+			TokenLists:         evmTokenLists[chain.Blockchain],
+			EVMChain:           chain.Blockchain,
+			NativeCurrency: &types.Currency{
+				Symbol:   chain.Symbol,
+				Decimals: chain.Decimals,
+			},
+			MetadataBundles: serviceConfig.MetadataBundles,
+		}
+
+		serviceRouter.AddNetwork(networkEVM, newServices(evmConfig, evmClient, service.SkippedBackend{}, service.SkippedBackend{}))
+		networks = append(networks, networkEVM)
+	}
+	go newTokenListReloader(opts.configPath, cfg, tokenLists, evmTokenLists, erc20ValidationClient, evmValidationClients).run(context.Background())
+
+	// The transactions and the block events of every network, and the ERC721
+	// transfers of the C-chain, are recorded in the background, by following
+	// the blocks served by the router, to serve /search/transactions,
+	// /events/blocks and the tokens owned by C-chain accounts
+	var (
+		searchService *txindex.Service
+		eventsService *events.Service
 	)
+	if db != nil {
+		if cfg.IndexTransactions {
+			searchService = txindex.NewService()
+		}
+		if cfg.LogBlockEvents {
+			eventsService = events.NewService()
+		}
+
+		for _, network := range networks {
+			handlers := []follower.Handler{}
+			if searchService != nil {
+				index := txindex.New(prefixdb.New(txIndexPrefix, db), network)
+				searchService.AddNetwork(network, index)
+				handlers = append(handlers, index)
+			}
+			if eventsService != nil {
+				eventLog := events.New(prefixdb.New(eventsPrefix, db), network)
+				eventsService.AddNetwork(network, eventLog)
+				handlers = append(handlers, eventLog)
+			}
+			if erc721Index != nil && network == networkC {
+				handlers = append(handlers, erc721Index)
+			}
+			if len(handlers) == 0 {
+				continue
+			}
+			go follower.New(network, serviceRouter, serviceRouter, cfg.indexStartHeight(network), handlers...).Run(context.Background())
+		}
+	}
+
+	asserter, err := newServerAsserter(networks)
 	if err != nil {
 		log.Fatal("server asserter init error:", err)
 	}
 
-	handler := configureRouter(serviceConfig, asserter, cChainClient, pChainBackend, cChainAtomicTxBackend)
+	handler := configureRouter(serviceRouter, searchService, eventsService, asserter)
 	if cfg.LogRequests {
 		handler = inspectMiddleware(handler)
 	}
@@ -206,28 +353,93 @@ func main() {
 	log.Fatal(server.ListenAndServe())
 }
 
-func configureRouter(
+// platformBackend serves the P-chain requests of a network
+type platformBackend interface {
+	service.NetworkBackend
+	service.BlockBackend
+	service.AccountBackend
+	service.ConstructionBackend
+}
+
+// atomicBackend serves the atomic transaction requests of a network
+type atomicBackend interface {
+	service.AccountBackend
+	service.ConstructionBackend
+}
+
+func newServices(
 	serviceConfig *service.Config,
-	asserter *asserter.Asserter,
 	apiClient client.Client,
-	pChainBackend *pchain.Backend,
-	cChainAtomicTxBackend *cchainatomictx.Backend,
-) http.Handler {
-	networkService := service.NewNetworkService(serviceConfig, apiClient, pChainBackend)
-	blockService := service.NewBlockService(serviceConfig, apiClient, pChainBackend)
-	accountService := service.NewAccountService(serviceConfig, apiClient, pChainBackend, cChainAtomicTxBackend)
-	mempoolService := service.NewMempoolService(serviceConfig, apiClient)
-	constructionService := service.NewConstructionService(serviceConfig, apiClient, pChainBackend, cChainAtomicTxBackend)
-	callService := service.NewCallService(serviceConfig, apiClient)
-
-	return server.NewRouter(
-		server.NewNetworkAPIController(networkService, asserter),
-		server.NewBlockAPIController(blockService, asserter),
-		server.NewAccountAPIController(accountService, asserter),
-		server.NewMempoolAPIController(mempoolService, asserter),
-		server.NewConstructionAPIController(constructionService, asserter),
-		server.NewCallAPIController(callService, asserter),
+	pChainBackend platformBackend,
+	cChainAtomicTxBackend atomicBackend,
+) *service.Services {
+	return &service.Services{
+		Network:      service.NewNetworkService(serviceConfig, apiClient, pChainBackend),
+		Block:        service.NewBlockService(serviceConfig, apiClient, pChainBackend),
+		Account:      service.NewAccountService(serviceConfig, apiClient, pChainBackend, cChainAtomicTxBackend),
+		Mempool:      service.NewMempoolService(serviceConfig, apiClient),
+		Construction: service.NewConstructionService(serviceConfig, apiClient, pChainBackend, cChainAtomicTxBackend),
+		Call:         service.NewCallService(serviceConfig, apiClient),
+	}
+}
+
+// newServerAsserter returns the asserter validating the requests of [networks].
+// The operation types and call methods of the C-chain and the P-chain are
+// allowed once each, as the asserter rejects duplicates.
+func newServerAsserter(networks []*types.NetworkIdentifier) (*asserter.Asserter, error) {
+	operationTypes := uniqueStrings(mapper.OperationTypes, pmapper.OperationTypes)
+	callMethods := uniqueStrings(mapper.CallMethods, pmapper.CallMethods)
+
+	return asserter.NewServer(
+		operationTypes, // supported operation types
+		true,           // historical balance lookup
+		networks,       // supported networks
+		callMethods,    // call methods
+		false,          // mempool coins
+	)
+}
+
+// uniqueStrings concatenates [lists], skipping the strings already added
+func uniqueStrings(lists ...[]string) []string {
+	var (
+		result []string
+		seen   = map[string]struct{}{}
 	)
+	for _, list := range lists {
+		for _, s := range list {
+			if _, ok := seen[s]; ok {
+				continue
+			}
+			seen[s] = struct{}{}
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func configureRouter(
+	router *service.Router,
+	searchService *txindex.Service,
+	eventsService *events.Service,
+	asserter *asserter.Asserter,
+) http.Handler {
+	routers := []server.Router{
+		server.NewNetworkAPIController(router, asserter),
+		server.NewBlockAPIController(router, asserter),
+		server.NewAccountAPIController(router, asserter),
+		server.NewMempoolAPIController(router, asserter),
+		server.NewConstructionAPIController(router, asserter),
+		server.NewCallAPIController(router, asserter),
+	}
+	// /search/transactions is only served when transactions are indexed
+	if searchService != nil {
+		routers = append(routers, server.NewSearchAPIController(searchService, asserter))
+	}
+	// /events/blocks is only served when block events are logged
+	if eventsService != nil {
+		routers = append(routers, server.NewEventsAPIController(eventsService, asserter))
+	}
+	return server.NewRouter(routers...)
 }
 
 // Inspect middlware used to inspect the body of requets
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+func TestNewServerAsserter(t *testing.T) {
+	networks := []*types.NetworkIdentifier{
+		{
+			Blockchain:           service.BlockchainName,
+			Network:              constants.FujiNetwork,
+			SubNetworkIdentifier: &types.SubNetworkIdentifier{Network: constants.PChain.String()},
+		},
+		{
+			Blockchain: service.BlockchainName,
+			Network:    constants.FujiNetwork,
+		},
+	}
+
+	asserter, err := newServerAsserter(networks)
+	require.NoError(t, err)
+	require.NotNil(t, asserter)
+}
+
+func TestUniqueStrings(t *testing.T) {
+	require.Equal(
+		t,
+		[]string{"a", "b", "c"},
+		uniqueStrings([]string{"a", "b"}, []string{"b", "c", "a"}),
+	)
+}
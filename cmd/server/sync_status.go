@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+// syncStatusConfigs returns the sync status configurations of the C-chain and of
+// the P-chain. Avalanchego does not expose the heights reported by peers, so the
+// sync target is the highest height among the configured reference nodes.
+func (c *config) syncStatusConfigs(ctx context.Context) (service.SyncStatusConfig, service.SyncStatusConfig, error) {
+	laggingThreshold := time.Duration(c.LaggingThresholdSeconds) * time.Second
+	cConfig := service.SyncStatusConfig{
+		LaggingThreshold: laggingThreshold,
+		StateSync:        c.StateSyncEnabled,
+	}
+	pConfig := service.SyncStatusConfig{
+		LaggingThreshold: laggingThreshold,
+	}
+
+	if len(c.SyncReferenceURLs) == 0 {
+		return cConfig, pConfig, nil
+	}
+
+	cHeights := make([]service.HeightFunc, 0, len(c.SyncReferenceURLs))
+	pHeights := make([]service.HeightFunc, 0, len(c.SyncReferenceURLs))
+	for _, url := range c.SyncReferenceURLs {
+		cClient, err := client.NewClient(ctx, url)
+		if err != nil {
+			return service.SyncStatusConfig{}, service.SyncStatusConfig{}, err
+		}
+		cHeights = append(cHeights, func(ctx context.Context) (uint64, error) {
+			header, err := cClient.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return 0, err
+			}
+			return header.Number.Uint64(), nil
+		})
+
+		pClient := client.NewPChainClient(ctx, url, url)
+		pHeights = append(pHeights, func(ctx context.Context) (uint64, error) {
+			return pClient.GetHeight(ctx)
+		})
+	}
+	cConfig.TargetHeight = service.MaxHeight(cHeights...)
+	pConfig.TargetHeight = service.MaxHeight(pHeights...)
+	return cConfig, pConfig, nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+// tokenListPollInterval is how often the config file and the token list
+// files are checked for modifications
+const tokenListPollInterval = 15 * time.Second
+
+var errInvalidTokenList = errors.New("invalid token list file")
+
+// tokenListFile is the subset of the tokenlists.org schema needed to build
+// a token whitelist
+type tokenListFile struct {
+	Tokens []struct {
+		ChainID int64  `json:"chainId"`
+		Address string `json:"address"`
+	} `json:"tokens"`
+}
+
+// readTokenListFile reads token addresses from [path]. The file is either a
+// JSON array of addresses or a tokenlists.org token list, in which case only
+// the tokens deployed on [chainID] are returned.
+func readTokenListFile(path string, chainID int64) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	if err := json.Unmarshal(data, &addresses); err == nil {
+		return addresses, nil
+	}
+
+	var list tokenListFile
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%w %s: %v", errInvalidTokenList, path, err)
+	}
+	for _, token := range list.Tokens {
+		if token.ChainID == chainID {
+			addresses = append(addresses, token.Address)
+		}
+	}
+	return addresses, nil
+}
+
+// uniqueTokens appends to [dst] the tokens of [src] it does not already contain
+func uniqueTokens(dst []string, src []string) []string {
+	for _, token := range src {
+		if !mapper.EqualFoldContains(dst, token) {
+			dst = append(dst, token)
+		}
+	}
+	return dst
+}
+
+// tokenListReloader refreshes the service token lists when the process
+// receives SIGHUP or when the config file or one of the token list files
+// it references is modified.
+type tokenListReloader struct {
+	configPath string
+	chainID    int64
+	lists      *service.TokenLists
+	// evmLists are the token lists of the other EVM chains, by blockchain
+	evmLists map[string]*service.TokenLists
+
+	// validateClient, if set, is used to check that all whitelisted tokens
+	// are valid ERC20 contracts before applying a new list
+	validateClient client.Client
+	// evmValidateClients are the clients validating the whitelisted tokens
+	// of the other EVM chains, by blockchain
+	evmValidateClients map[string]client.Client
+
+	modTimes map[string]time.Time
+}
+
+func newTokenListReloader(
+	configPath string,
+	cfg *config,
+	lists *service.TokenLists,
+	evmLists map[string]*service.TokenLists,
+	validateClient client.Client,
+	evmValidateClients map[string]client.Client,
+) *tokenListReloader {
+	r := &tokenListReloader{
+		configPath:         configPath,
+		chainID:            cfg.ChainID,
+		lists:              lists,
+		evmLists:           evmLists,
+		validateClient:     validateClient,
+		evmValidateClients: evmValidateClients,
+	}
+	r.watch(cfg.tokenListFiles())
+	r.changed()
+	return r
+}
+
+func (r *tokenListReloader) run(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	ticker := time.NewTicker(tokenListPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			r.reload()
+		case <-ticker.C:
+			if r.changed() {
+				r.reload()
+			}
+		}
+	}
+}
+
+func (r *tokenListReloader) reload() {
+	cfg, err := readConfig(r.configPath)
+	if err != nil {
+		log.Println("token list reload: config read error:", err)
+		return
+	}
+	r.watch(cfg.tokenListFiles())
+	r.changed()
+
+	whiteList, bridgeList, err := cfg.tokenLists(r.chainID)
+	if err != nil {
+		log.Println("token list reload:", err)
+		return
+	}
+
+	if r.validateClient != nil {
+		if err := validateWhitelistOnlyValidErc20s(r.validateClient, whiteList); err != nil {
+			log.Println("token list reload: token whitelist validation error:", err)
+			return
+		}
+	}
+
+	r.lists.Update(whiteList, bridgeList)
+	log.Printf("token lists reloaded: %d whitelisted tokens, %d bridge tokens\n", len(whiteList), len(bridgeList))
+
+	// EVM chains added to the config are only served after a restart
+	for _, chain := range cfg.EVMChains {
+		lists, ok := r.evmLists[chain.Blockchain]
+		if !ok {
+			continue
+		}
+		chainWhiteList, err := chain.tokenWhiteList()
+		if err != nil {
+			log.Printf("token list reload of %s: %v\n", chain.Blockchain, err)
+			continue
+		}
+		if validateClient, ok := r.evmValidateClients[chain.Blockchain]; ok {
+			if err := validateWhitelistOnlyValidErc20s(validateClient, chainWhiteList); err != nil {
+				log.Printf("token list reload of %s: token whitelist validation error: %v\n", chain.Blockchain, err)
+				continue
+			}
+		}
+		lists.Update(chainWhiteList, nil)
+		log.Printf("token lists of %s reloaded: %d whitelisted tokens\n", chain.Blockchain, len(chainWhiteList))
+	}
+}
+
+// watch sets the files whose modification triggers a reload
+func (r *tokenListReloader) watch(files []string) {
+	modTimes := map[string]time.Time{r.configPath: r.modTimes[r.configPath]}
+	for _, file := range files {
+		modTimes[file] = r.modTimes[file]
+	}
+	r.modTimes = modTimes
+}
+
+// changed records the modification time of the watched files and reports
+// whether any of them changed since the last call
+func (r *tokenListReloader) changed() bool {
+	changed := false
+	for file, modTime := range r.modTimes {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(modTime) {
+			r.modTimes[file] = info.ModTime()
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+const (
+	tokenA = "0xB31f66AA3C1e785363F0875A1B74E27b85FD66c7"
+	tokenB = "0x5947BB275c521040051D82396192181b413227A3"
+	tokenC = "0xd586E7F844cEa2F87f50152665BCbc2C279D8d70"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadTokenListFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "array of addresses",
+			content:  `["` + tokenA + `", "` + tokenB + `"]`,
+			expected: []string{tokenA, tokenB},
+		},
+		{
+			name: "token list filtered by chain id",
+			content: `{"name": "list", "tokens": [
+				{"chainId": 43114, "address": "` + tokenA + `", "symbol": "WAVAX"},
+				{"chainId": 43113, "address": "` + tokenB + `", "symbol": "LINK"},
+				{"chainId": 43114, "address": "` + tokenC + `", "symbol": "DAI"}
+			]}`,
+			expected: []string{tokenA, tokenC},
+		},
+		{
+			name:    "token list without tokens of the chain",
+			content: `{"tokens": [{"chainId": 1, "address": "` + tokenA + `"}]}`,
+		},
+		{
+			name:    "invalid file",
+			content: `not json`,
+			err:     errInvalidTokenList,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "tokens.json", test.content)
+
+			tokens, err := readTokenListFile(path, 43114)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.expected, tokens)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readTokenListFile(filepath.Join(t.TempDir(), "missing.json"), 43114)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestConfigTokenLists(t *testing.T) {
+	dir := t.TempDir()
+	whiteListFile := writeFile(t, dir, "whitelist.json", `["`+tokenB+`", "`+tokenA+`"]`)
+	bridgeFile := writeFile(t, dir, "bridge.json", `{"tokens": [{"chainId": 43114, "address": "`+tokenC+`"}]}`)
+	invalidFile := writeFile(t, dir, "invalid.json", `["0xinvalid"]`)
+
+	tests := []struct {
+		name              string
+		cfg               *config
+		expectedWhiteList []string
+		expectedBridge    []string
+		err               error
+	}{
+		{
+			name:              "inline lists",
+			cfg:               &config{TokenWhiteList: []string{tokenA}, BridgeTokenList: []string{tokenB}},
+			expectedWhiteList: []string{tokenA, tokenB},
+			expectedBridge:    []string{tokenB},
+		},
+		{
+			name: "inline lists merged with files",
+			cfg: &config{
+				TokenWhiteList:       []string{tokenA},
+				TokenWhiteListFiles:  []string{whiteListFile},
+				BridgeTokenListFiles: []string{bridgeFile},
+			},
+			expectedWhiteList: []string{tokenA, tokenB, tokenC},
+			expectedBridge:    []string{tokenC},
+		},
+		{
+			name: "duplicates are removed regardless of case",
+			cfg: &config{
+				TokenWhiteList:  []string{tokenA, tokenB},
+				BridgeTokenList: []string{tokenA, "0xb31f66aa3c1e785363f0875a1b74e27b85fd66c7"},
+			},
+			expectedWhiteList: []string{tokenA, tokenB},
+			expectedBridge:    []string{tokenA},
+		},
+		{
+			name: "invalid address in file",
+			cfg:  &config{TokenWhiteListFiles: []string{invalidFile}},
+			err:  errInvalidTokenAddress,
+		},
+		{
+			name: "missing file",
+			cfg:  &config{BridgeTokenListFiles: []string{filepath.Join(dir, "missing.json")}},
+			err:  os.ErrNotExist,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			whiteList, bridgeList, err := test.cfg.tokenLists(43114)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.expectedWhiteList, whiteList)
+			require.Equal(t, test.expectedBridge, bridgeList)
+		})
+	}
+}
+
+func TestTokenListReloader(t *testing.T) {
+	dir := t.TempDir()
+	evmFile := writeFile(t, dir, "evm.json", `{"tokens": [{"chainId": 1234, "address": "`+tokenC+`"}]}`)
+
+	writeConfig := func(cfg *config) string {
+		b, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		return writeFile(t, dir, "config.json", string(b))
+	}
+	cfg := &config{
+		ChainID:        43114,
+		TokenWhiteList: []string{tokenA},
+		EVMChains: []*evmChainConfig{
+			{Blockchain: "dfk", ChainID: 1234},
+			{Blockchain: "other", ChainID: 5678},
+		},
+	}
+	configPath := writeConfig(cfg)
+
+	lists := service.NewTokenLists([]string{tokenA}, nil)
+	evmLists := map[string]*service.TokenLists{
+		"dfk": service.NewTokenLists(nil, nil),
+	}
+	r := newTokenListReloader(configPath, cfg, lists, evmLists, nil, nil)
+	require.Contains(t, r.modTimes, configPath)
+
+	cfg.BridgeTokenList = []string{tokenB}
+	cfg.EVMChains[0].TokenWhiteList = []string{tokenA}
+	cfg.EVMChains[0].TokenWhiteListFiles = []string{evmFile}
+	writeConfig(cfg)
+	r.reload()
+
+	require.Equal(t, []string{tokenA, tokenB}, lists.WhiteList())
+	require.Equal(t, []string{tokenB}, lists.BridgeList())
+	require.Equal(t, []string{tokenA, tokenC}, evmLists["dfk"].WhiteList())
+	// the token list files of the other EVM chains are watched as well
+	require.Contains(t, r.modTimes, evmFile)
+
+	// invalid lists are not applied
+	cfg.TokenWhiteList = []string{"0xinvalid"}
+	writeConfig(cfg)
+	r.reload()
+	require.Equal(t, []string{tokenA, tokenB}, lists.WhiteList())
+}
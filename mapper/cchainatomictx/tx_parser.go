@@ -28,24 +28,68 @@ type TxParser struct {
 	chainIDs map[ids.ID]string
 	// inputTxAccounts contain utxo id to account identifier mappings
 	inputTxAccounts map[string]*types.AccountIdentifier
+	// avaxAssetID contains asset id for AVAX currency
+	avaxAssetID ids.ID
 }
 
 // NewTxParser returns a new transaction parser
-func NewTxParser(hrp string, chainIDs map[ids.ID]string, inputTxAccounts map[string]*types.AccountIdentifier) *TxParser {
-	return &TxParser{hrp: hrp, chainIDs: chainIDs, inputTxAccounts: inputTxAccounts}
+func NewTxParser(
+	hrp string,
+	chainIDs map[ids.ID]string,
+	inputTxAccounts map[string]*types.AccountIdentifier,
+	avaxAssetID ids.ID,
+) *TxParser {
+	return &TxParser{hrp: hrp, chainIDs: chainIDs, inputTxAccounts: inputTxAccounts, avaxAssetID: avaxAssetID}
 }
 
-// Parse converts the given atomic evm tx to corresponding Rosetta operations
+// Parse converts the given atomic evm tx to corresponding Rosetta operations,
+// and returns the fee it burns as transaction metadata.
 // This method is only used during construction.
-func (t *TxParser) Parse(tx evm.Tx) ([]*types.Operation, error) {
+func (t *TxParser) Parse(tx evm.Tx) ([]*types.Operation, map[string]interface{}, error) {
+	var (
+		operations []*types.Operation
+		err        error
+	)
 	switch unsignedTx := tx.UnsignedAtomicTx.(type) {
 	case *evm.UnsignedExportTx:
-		return t.parseExportTx(unsignedTx)
+		operations, err = t.parseExportTx(unsignedTx)
 	case *evm.UnsignedImportTx:
-		return t.parseImportTx(unsignedTx)
+		operations, err = t.parseImportTx(unsignedTx)
 	default:
-		return nil, errors.New("unsupported tx type")
+		return nil, nil, errors.New("unsupported tx type")
 	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata, err := t.feeMetadata(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return operations, metadata, nil
+}
+
+// feeMetadata returns the fee burned by [tx], in nAVAX, along with the gas it
+// uses and the gas price it pays, in wei
+func (t *TxParser) feeMetadata(tx evm.Tx) (map[string]interface{}, error) {
+	burned, err := tx.Burned(t.avaxAssetID)
+	if err != nil {
+		return nil, err
+	}
+	metadata := map[string]interface{}{
+		mapper.MetadataTxFee: mapper.AtomicAvaxAmount(new(big.Int).SetUint64(burned)),
+	}
+
+	gasUsed, err := tx.GasUsed(true)
+	if err != nil {
+		return nil, err
+	}
+	if gasUsed > 0 {
+		gasPrice := new(big.Int).Mul(new(big.Int).SetUint64(burned), mapper.X2crate)
+		metadata[MetadataAtomicTxGas] = gasUsed
+		metadata[MetadataGasPrice] = gasPrice.Div(gasPrice, new(big.Int).SetUint64(gasUsed))
+	}
+	return metadata, nil
 }
 
 func (t *TxParser) parseExportTx(exportTx *evm.UnsignedExportTx) ([]*types.Operation, error) {
@@ -8,6 +8,7 @@ import (
 
 const (
 	MetadataAtomicTxGas = "atomic_tx_gas"
+	MetadataGasPrice    = "gas_price"
 	MetadataNonce       = "nonce"
 	MetadataSourceChain = "source_chain"
 )
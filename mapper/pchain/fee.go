@@ -0,0 +1,116 @@
+package pchain
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	txfee "github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
+)
+
+var errProducesMoreThanConsumed = errors.New("transaction produces more than it consumes")
+
+// GasComplexity is the complexity of a P-chain transaction in each dimension
+// of the dynamic fees
+type GasComplexity struct {
+	Bandwidth uint64 `json:"bandwidth"`
+	DBRead    uint64 `json:"db_read"`
+	DBWrite   uint64 `json:"db_write"`
+	Compute   uint64 `json:"compute"`
+}
+
+// BurnedFee returns the AVAX burned by [tx], in nAVAX. It is the AVAX consumed by
+// its inputs that is neither produced back by its outputs nor moved to the balance
+// of L1 validators.
+func BurnedFee(tx txs.UnsignedTx, avaxAssetID ids.ID) (uint64, error) {
+	v, err := visitTx(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	consumed := new(big.Int)
+	for _, ins := range [][]*avax.TransferableInput{v.ins, v.importedIns} {
+		for _, in := range ins {
+			if in.AssetID() == avaxAssetID {
+				consumed.Add(consumed, new(big.Int).SetUint64(in.In.Amount()))
+			}
+		}
+	}
+	produced := new(big.Int).SetUint64(v.balance)
+	for _, outs := range [][]*avax.TransferableOutput{v.outs, v.stakeOuts, v.exportedOuts} {
+		for _, out := range outs {
+			if out.AssetID() == avaxAssetID {
+				produced.Add(produced, new(big.Int).SetUint64(out.Out.Amount()))
+			}
+		}
+	}
+
+	burned := consumed.Sub(consumed, produced)
+	if !burned.IsUint64() {
+		return 0, errProducesMoreThanConsumed
+	}
+	return burned.Uint64(), nil
+}
+
+// addFeeMetadata sets the fee burned by [tx] on [txMetadata], along with its gas
+// complexity and, when the gas weights are known, the gas it used and the gas
+// price it paid
+func (t *TxParser) addFeeMetadata(txMetadata map[string]interface{}, tx txs.UnsignedTx, fee uint64) {
+	txMetadata[mapper.MetadataTxFee] = mapper.AtomicAvaxAmount(new(big.Int).SetUint64(fee))
+
+	// Only the txs introduced or still allowed by Etna have a gas complexity
+	complexity, err := txfee.TxComplexity(tx)
+	if err != nil {
+		return
+	}
+	txMetadata[MetadataGasComplexity] = &GasComplexity{
+		Bandwidth: complexity[gas.Bandwidth],
+		DBRead:    complexity[gas.DBRead],
+		DBWrite:   complexity[gas.DBWrite],
+		Compute:   complexity[gas.Compute],
+	}
+
+	if t.cfg.GasWeights == (gas.Dimensions{}) {
+		return
+	}
+	gasUsed, err := complexity.ToGas(t.cfg.GasWeights)
+	if err != nil || gasUsed == 0 {
+		return
+	}
+	txMetadata[MetadataGasUsed] = uint64(gasUsed)
+	txMetadata[MetadataGasPrice] = fee / uint64(gasUsed)
+}
+
+// feeOperation returns the operation of the account paying the fee burned by a
+// tx, which is the account of its first input. It is informational: as inputs
+// spend whole utxos, their amounts already include the fee, so the operation
+// carries no amount and only reports the fee in its metadata, like the fee
+// operation of C-chain atomic txs.
+func (t *TxParser) feeOperation(ops *txOps, fee uint64, index int) *types.Operation {
+	if t.cfg.IsConstruction || fee == 0 {
+		return nil
+	}
+
+	for _, in := range ops.Ins {
+		if in.Account == nil || in.Amount == nil || types.Hash(in.Amount.Currency) != types.Hash(mapper.AtomicAvaxCurrency) {
+			continue
+		}
+		return &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: int64(index)},
+			Type:                mapper.OpFee,
+			Status:              types.String(mapper.StatusSuccess),
+			Account:             in.Account,
+			Metadata: map[string]interface{}{
+				mapper.MetadataTxFee: mapper.AtomicAvaxAmount(new(big.Int).SetUint64(fee)),
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package pchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
+	txfee "github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
+)
+
+func TestBurnedFee(t *testing.T) {
+	_, exportTx, _ := buildExport()
+
+	t.Run("export tx", func(t *testing.T) {
+		fee, err := BurnedFee(exportTx, avaxAssetID)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1_000_000), fee)
+	})
+
+	t.Run("L1 validator balance is not burned", func(t *testing.T) {
+		tx := &txs.IncreaseL1ValidatorBalanceTx{
+			BaseTx:  exportTx.BaseTx,
+			Balance: 10_000_000,
+		}
+		fee, err := BurnedFee(tx, avaxAssetID)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1_000_000), fee)
+	})
+
+	t.Run("outputs exceed inputs", func(t *testing.T) {
+		tx := &txs.ExportTx{
+			BaseTx: exportTx.BaseTx,
+			ExportedOutputs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          20_000_000,
+					OutputOwners: exportTx.ExportedOutputs[0].Out.(*secp256k1fx.TransferOutput).OutputOwners,
+				},
+			}},
+		}
+		_, err := BurnedFee(tx, avaxAssetID)
+		require.ErrorIs(t, err, errProducesMoreThanConsumed)
+	})
+
+	t.Run("gas price", func(t *testing.T) {
+		signedTx, _, inputAccounts := buildExport()
+		weights := gas.Dimensions{1, 1000, 1000, 4}
+		parser, err := NewTxParser(TxParserConfig{
+			IsConstruction: true,
+			Hrp:            avaconstants.FujiHRP,
+			ChainIDs:       chainIDs,
+			AvaxAssetID:    avaxAssetID,
+			GasWeights:     weights,
+		}, inputAccounts, nil)
+		require.NoError(t, err)
+		rosettaTransaction, err := parser.Parse(signedTx)
+		require.NoError(t, err)
+
+		complexity, err := txfee.TxComplexity(exportTx)
+		require.NoError(t, err)
+		gasUsed, err := complexity.ToGas(weights)
+		require.NoError(t, err)
+		require.Equal(t, mapper.AtomicAvaxAmount(big.NewInt(1_000_000)), rosettaTransaction.Metadata[mapper.MetadataTxFee])
+		require.Equal(t, uint64(gasUsed), rosettaTransaction.Metadata[MetadataGasUsed])
+		require.Equal(t, 1_000_000/uint64(gasUsed), rosettaTransaction.Metadata[MetadataGasPrice])
+
+		// the fee operation is only reported by the /block endpoints
+		for _, op := range rosettaTransaction.Operations {
+			require.NotEqual(t, mapper.OpFee, op.Type)
+		}
+	})
+}
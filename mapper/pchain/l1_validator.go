@@ -0,0 +1,120 @@
+package pchain
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+// L1ValidatorBalanceAccount returns the account of the balance of the L1 validator
+// [validationID], which is a sub-account of the owner of its remaining balance.
+// It is only used by /account/balance.
+func L1ValidatorBalanceAccount(owner string, validationID ids.ID) *types.AccountIdentifier {
+	return &types.AccountIdentifier{
+		Address: owner,
+		SubAccount: &types.SubAccountIdentifier{
+			Address: SubAccountTypeL1ValidatorBalance,
+			Metadata: map[string]interface{}{
+				MetadataValidationID: validationID.String(),
+			},
+		},
+	}
+}
+
+// ParseRegisterL1ValidatorMessage returns the RegisterL1Validator message carried
+// by the warp message of a RegisterL1ValidatorTx
+func ParseRegisterL1ValidatorMessage(warpMessage []byte) (*message.RegisterL1Validator, error) {
+	msg, err := warp.ParseMessage(warpMessage)
+	if err != nil {
+		return nil, err
+	}
+	addressedCall, err := payload.ParseAddressedCall(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return message.ParseRegisterL1Validator(addressedCall.Payload)
+}
+
+func (t *TxParser) parseConvertSubnetToL1Tx(txID ids.ID, tx *txs.ConvertSubnetToL1Tx) (*txOps, error) {
+	ops, err := t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpConvertSubnetToL1Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the initial validators of the L1 are funded with their balance
+	for i, validator := range tx.Validators {
+		t.addL1ValidatorBalanceOperation(ops, OpConvertSubnetToL1Tx, tx.Subnet.Append(uint32(i)), validator.Balance)
+	}
+	return ops, nil
+}
+
+func (t *TxParser) parseRegisterL1ValidatorTx(txID ids.ID, tx *txs.RegisterL1ValidatorTx) (*txOps, error) {
+	ops, err := t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpRegisterL1ValidatorTx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ParseRegisterL1ValidatorMessage(tx.Message)
+	if err != nil {
+		return nil, err
+	}
+	t.addL1ValidatorBalanceOperation(ops, OpRegisterL1ValidatorTx, msg.ValidationID(), tx.Balance)
+	return ops, nil
+}
+
+func (t *TxParser) parseIncreaseL1ValidatorBalanceTx(txID ids.ID, tx *txs.IncreaseL1ValidatorBalanceTx) (*txOps, error) {
+	ops, err := t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpIncreaseL1ValidatorBalanceTx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.addL1ValidatorBalanceOperation(ops, OpIncreaseL1ValidatorBalanceTx, tx.ValidationID, tx.Balance)
+	return ops, nil
+}
+
+// parseDisableL1ValidatorTx only reports the AVAX moved by the inputs and outputs
+// of [tx]. The refund of the validator is left out: the platformvm produces it as
+// the utxo following the outputs of the tx, for the balance left once the fees
+// accrued up to the tx are deducted, and to the remaining balance owner of the
+// validator. Both are only known to the state of the node, not to the tx.
+func (t *TxParser) parseDisableL1ValidatorTx(txID ids.ID, tx *txs.DisableL1ValidatorTx) (*txOps, error) {
+	return t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpDisableL1ValidatorTx)
+}
+
+// addL1ValidatorBalanceOperation appends to [ops] the operation moving [balance]
+// to the balance of the L1 validator [validationID]. The balance is continuously
+// deducted by the network, and its remaining part refunded by transactions
+// whose refund is not part of the block. So that the balance is not reconciled
+// against operations, the operation has no account, and it carries the balance
+// in its metadata rather than as its amount.
+func (t *TxParser) addL1ValidatorBalanceOperation(
+	ops *txOps,
+	opType string,
+	validationID ids.ID,
+	balance uint64,
+) {
+	ops.Append(&types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: int64(ops.Len())},
+		Type:                opType,
+		Status:              t.operationStatus(),
+		Metadata: map[string]interface{}{
+			MetadataOpType:       OpTypeL1ValidatorBalance,
+			MetadataValidationID: validationID.String(),
+			MetadataBalance:      mapper.AtomicAvaxAmount(new(big.Int).SetUint64(balance)),
+		},
+	}, OpTypeL1ValidatorBalance)
+}
+
+func (t *TxParser) operationStatus() *string {
+	if t.cfg.IsConstruction {
+		return nil
+	}
+	return types.String(mapper.StatusSuccess)
+}
@@ -0,0 +1,136 @@
+package pchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestMapL1ValidatorBalanceOperations(t *testing.T) {
+	_, exportTx, inputAccounts := buildExport()
+
+	owner := ids.GenerateTestShortID()
+	validationID := ids.GenerateTestID()
+
+	// the operations are built from the tx alone, without querying the node
+	ctrl := gomock.NewController(t)
+	parserCfg := TxParserConfig{
+		IsConstruction: false,
+		Hrp:            avaconstants.FujiHRP,
+		ChainIDs:       chainIDs,
+		AvaxAssetID:    avaxAssetID,
+		PChainClient:   client.NewMockPChainClient(ctrl),
+	}
+
+	parse := func(t *testing.T, utx txs.UnsignedTx) *types.Transaction {
+		signedTx, err := txs.NewSigned(utx, txs.Codec, nil)
+		require.NoError(t, err)
+		parser, err := NewTxParser(parserCfg, inputAccounts, nil)
+		require.NoError(t, err)
+		rosettaTx, err := parser.Parse(signedTx)
+		require.NoError(t, err)
+		return rosettaTx
+	}
+	balanceOperation := func(index int64, opType string, validationID ids.ID, balance int64) *types.Operation {
+		return &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: index},
+			Type:                opType,
+			Status:              types.String(mapper.StatusSuccess),
+			Metadata: map[string]interface{}{
+				MetadataOpType:       OpTypeL1ValidatorBalance,
+				MetadataValidationID: validationID.String(),
+				MetadataBalance:      mapper.AtomicAvaxAmount(big.NewInt(balance)),
+			},
+		}
+	}
+
+	t.Run("deposit at conversion", func(t *testing.T) {
+		subnetID := ids.GenerateTestID()
+		rosettaTx := parse(t, &txs.ConvertSubnetToL1Tx{
+			BaseTx:     exportTx.BaseTx,
+			Subnet:     subnetID,
+			SubnetAuth: &secp256k1fx.Input{},
+			Validators: []*txs.ConvertSubnetToL1Validator{
+				{
+					Balance:               3_000_000,
+					RemainingBalanceOwner: message.PChainOwner{Threshold: 1, Addresses: []ids.ShortID{owner}},
+				},
+				{
+					Balance: 4_000_000,
+					RemainingBalanceOwner: message.PChainOwner{
+						Threshold: 1,
+						Addresses: []ids.ShortID{owner, ids.GenerateTestShortID()},
+					},
+				},
+			},
+		})
+
+		require.Equal(t, balanceOperation(2, OpConvertSubnetToL1Tx, subnetID.Append(0), 3_000_000), rosettaTx.Operations[2])
+		require.Equal(t, balanceOperation(3, OpConvertSubnetToL1Tx, subnetID.Append(1), 4_000_000), rosettaTx.Operations[3])
+		require.Equal(t, mapper.OpFee, rosettaTx.Operations[4].Type)
+	})
+
+	t.Run("deposit at registration", func(t *testing.T) {
+		remainingBalanceOwner := message.PChainOwner{Threshold: 1, Addresses: []ids.ShortID{owner}}
+		msg, err := message.NewRegisterL1Validator(
+			ids.GenerateTestID(),
+			ids.GenerateTestNodeID(),
+			[48]byte{},
+			1,
+			remainingBalanceOwner,
+			remainingBalanceOwner,
+			10,
+		)
+		require.NoError(t, err)
+		addressedCall, err := payload.NewAddressedCall(nil, msg.Bytes())
+		require.NoError(t, err)
+		unsignedMsg, err := warp.NewUnsignedMessage(avaconstants.FujiID, ids.GenerateTestID(), addressedCall.Bytes())
+		require.NoError(t, err)
+		warpMsg, err := warp.NewMessage(unsignedMsg, &warp.BitSetSignature{})
+		require.NoError(t, err)
+
+		rosettaTx := parse(t, &txs.RegisterL1ValidatorTx{
+			BaseTx:  exportTx.BaseTx,
+			Balance: 5_000_000,
+			Message: warpMsg.Bytes(),
+		})
+
+		require.Equal(t, balanceOperation(2, OpRegisterL1ValidatorTx, msg.ValidationID(), 5_000_000), rosettaTx.Operations[2])
+	})
+
+	t.Run("top-up", func(t *testing.T) {
+		rosettaTx := parse(t, &txs.IncreaseL1ValidatorBalanceTx{
+			BaseTx:       exportTx.BaseTx,
+			ValidationID: validationID,
+			Balance:      2_000_000,
+		})
+
+		require.Equal(t, balanceOperation(2, OpIncreaseL1ValidatorBalanceTx, validationID, 2_000_000), rosettaTx.Operations[2])
+	})
+
+	t.Run("no operation on disable", func(t *testing.T) {
+		rosettaTx := parse(t, &txs.DisableL1ValidatorTx{
+			BaseTx:       exportTx.BaseTx,
+			ValidationID: validationID,
+			DisableAuth:  &secp256k1fx.Input{},
+		})
+
+		for _, op := range rosettaTx.Operations {
+			require.NotEqual(t, OpTypeL1ValidatorBalance, op.Metadata[MetadataOpType])
+		}
+	})
+}
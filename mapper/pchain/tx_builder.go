@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/coinbase/rosetta-sdk-go/parser"
@@ -24,6 +25,14 @@ import (
 var (
 	errInvalidMetadata      = errors.New("invalid metadata")
 	errOutputAmountOverflow = errors.New("sum of output amounts caused overflow")
+
+	errInvalidProofOfPossession = errors.New("invalid BLS proof of possession")
+
+	errMemoTooLarge = fmt.Errorf("memo can't be larger than %d bytes", avax.MaxMemoSize)
+
+	errLockedAtomicFunds      = errors.New("stakeable locked funds can't be imported or exported")
+	errLockedFundsNotProduced = errors.New("stakeable locked funds are not produced back under their lock")
+	errInputLocktime          = errors.New("inputs have no locktime, the stakeable locktime of the coin they spend is set as their stakeable_locktime")
 )
 
 // BuildTx constructs a P-chain Tx based on the provided operation type, Rosetta matches and metadata
@@ -35,6 +44,13 @@ func BuildTx(
 	codec codec.Manager,
 	avaxAssetID ids.ID,
 ) (*txs.Tx, []*types.AccountIdentifier, error) {
+	if len(payloadMetadata.Memo) > avax.MaxMemoSize {
+		return nil, nil, errMemoTooLarge
+	}
+	if err := verifyStakeableLocks(matches[0].Operations, matches[1].Operations); err != nil {
+		return nil, nil, err
+	}
+
 	switch opType {
 	case OpImportAvax:
 		return buildImportTx(matches, payloadMetadata, codec, avaxAssetID)
@@ -81,6 +97,7 @@ func buildImportTx(
 			BlockchainID: blockchainID,
 			Outs:         outs,
 			Ins:          ins,
+			Memo:         metadata.Memo,
 		}},
 		ImportedInputs: imported,
 		SourceChain:    sourceChainID,
@@ -118,6 +135,7 @@ func buildExportTx(
 			BlockchainID: blockchainID,
 			Outs:         outs,
 			Ins:          ins,
+			Memo:         metadata.Memo,
 		}},
 		DestinationChain: destinationChainID,
 		ExportedOutputs:  exported,
@@ -175,6 +193,7 @@ func buildAddValidatorTx(
 			BlockchainID: blockchainID,
 			Outs:         outs,
 			Ins:          ins,
+			Memo:         metadata.Memo,
 		}},
 		Validator: txs.Validator{
 			NodeID: nodeID,
@@ -239,6 +258,7 @@ func buildAddDelegatorTx(
 			BlockchainID: blockchainID,
 			Outs:         outs,
 			Ins:          ins,
+			Memo:         metadata.Memo,
 		}},
 		Validator: txs.Validator{
 			NodeID: nodeID,
@@ -279,20 +299,10 @@ func buildAddPermissionlessValidatorTx(
 		}
 	}
 
-	publicKeyBytes, err := formatting.Decode(formatting.HexNC, metadata.BLSPublicKey)
-	if err != nil {
-		return nil, nil, err
-	}
-	popBytes, err := formatting.Decode(formatting.HexNC, metadata.BLSProofOfPossession)
+	pop, err := ParseProofOfPossession(metadata.BLSPublicKey, metadata.BLSProofOfPossession)
 	if err != nil {
 		return nil, nil, err
 	}
-	pop := &signer.ProofOfPossession{}
-	copy(pop.PublicKey[:], publicKeyBytes)
-	copy(pop.ProofOfPossession[:], popBytes)
-	if err = pop.Verify(); err != nil {
-		return nil, nil, err
-	}
 
 	validationRewardsOwner, err := buildOutputOwner(
 		metadata.ValidationRewardsOwners,
@@ -338,6 +348,7 @@ func buildAddPermissionlessValidatorTx(
 			BlockchainID: blockchainID,
 			Outs:         outs,
 			Ins:          ins,
+			Memo:         metadata.Memo,
 		}},
 		Validator: txs.Validator{
 			NodeID: nodeID,
@@ -356,6 +367,37 @@ func buildAddPermissionlessValidatorTx(
 	return tx, signers, tx.Sign(codec, nil)
 }
 
+// ParseProofOfPossession decodes the hex encoded BLS public key and proof of possession
+// of a validator and verifies that the proof is signed by the key
+func ParseProofOfPossession(blsPublicKey, blsProofOfPossession string) (*signer.ProofOfPossession, error) {
+	publicKeyBytes, err := formatting.Decode(formatting.HexNC, blsPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidProofOfPossession, err)
+	}
+	popBytes, err := formatting.Decode(formatting.HexNC, blsProofOfPossession)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidProofOfPossession, err)
+	}
+
+	pop := &signer.ProofOfPossession{}
+	if len(publicKeyBytes) != len(pop.PublicKey) || len(popBytes) != len(pop.ProofOfPossession) {
+		return nil, fmt.Errorf(
+			"%w: expected %d bytes public key and %d bytes proof, got %d and %d",
+			errInvalidProofOfPossession,
+			len(pop.PublicKey),
+			len(pop.ProofOfPossession),
+			len(publicKeyBytes),
+			len(popBytes),
+		)
+	}
+	copy(pop.PublicKey[:], publicKeyBytes)
+	copy(pop.ProofOfPossession[:], popBytes)
+	if err := pop.Verify(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidProofOfPossession, err)
+	}
+	return pop, nil
+}
+
 // [buildAddPermissionlessDelegatorTx] returns a duly initialized tx if it does not err
 func buildAddPermissionlessDelegatorTx(
 	matches []*parser.Match,
@@ -407,6 +449,7 @@ func buildAddPermissionlessDelegatorTx(
 			BlockchainID: blockchainID,
 			Outs:         outs,
 			Ins:          ins,
+			Memo:         metadata.Memo,
 		}},
 		Validator: txs.Validator{
 			NodeID: nodeID,
@@ -469,19 +512,32 @@ func buildInputs(
 			return nil, nil, nil, fmt.Errorf("parse operation amount failed: %w", err)
 		}
 
+		var transferIn avax.TransferableIn = &secp256k1fx.TransferInput{
+			Amt: val.Uint64(),
+			Input: secp256k1fx.Input{
+				SigIndices: opMetadata.SigIndices,
+			},
+		}
+		if opMetadata.Locktime != 0 {
+			return nil, nil, nil, errInputLocktime
+		}
+		if opMetadata.StakeableLocktime != 0 {
+			transferIn = &stakeable.LockIn{
+				Locktime:       opMetadata.StakeableLocktime,
+				TransferableIn: transferIn,
+			}
+		}
 		in := &avax.TransferableInput{
 			UTXOID: *utxoID,
 			Asset:  avax.Asset{ID: avaxAssetID},
-			In: &secp256k1fx.TransferInput{
-				Amt: val.Uint64(),
-				Input: secp256k1fx.Input{
-					SigIndices: opMetadata.SigIndices,
-				},
-			},
+			In:     transferIn,
 		}
 
 		switch opMetadata.Type {
 		case OpTypeImport:
+			if opMetadata.StakeableLocktime != 0 {
+				return nil, nil, nil, errLockedAtomicFunds
+			}
 			imported = append(imported, in)
 		case OpTypeInput:
 			ins = append(ins, in)
@@ -549,12 +605,19 @@ func buildOutputs(
 			return nil, nil, nil, fmt.Errorf("parse operation amount failed: %w", err)
 		}
 
+		var transferOut avax.TransferableOut = &secp256k1fx.TransferOutput{
+			Amt:          val.Uint64(),
+			OutputOwners: *outputOwners,
+		}
+		if opMetadata.StakeableLocktime != 0 {
+			transferOut = &stakeable.LockOut{
+				Locktime:        opMetadata.StakeableLocktime,
+				TransferableOut: transferOut,
+			}
+		}
 		out := &avax.TransferableOutput{
 			Asset: avax.Asset{ID: avaxAssetID},
-			Out: &secp256k1fx.TransferOutput{
-				Amt:          val.Uint64(),
-				OutputOwners: *outputOwners,
-			},
+			Out:   transferOut,
 		}
 
 		switch opMetadata.Type {
@@ -563,6 +626,9 @@ func buildOutputs(
 		case OpTypeStakeOutput:
 			stakeOutputs = append(stakeOutputs, out)
 		case OpTypeExport:
+			if opMetadata.StakeableLocktime != 0 {
+				return nil, nil, nil, errLockedAtomicFunds
+			}
 			exported = append(exported, out)
 		default:
 			return nil, nil, nil, fmt.Errorf("invalid option type: %s", op.Type)
@@ -576,6 +642,64 @@ func buildOutputs(
 	return outs, stakeOutputs, exported, nil
 }
 
+// verifyStakeableLocks checks that the stakeable locked funds spent by [inputOps] are
+// produced back by [outputOps], either staked or as change, with the same owner and
+// stakeable locktime. Locked funds that are not are burned by the P-chain, as the fee
+// can only be paid with unlocked funds.
+func verifyStakeableLocks(inputOps []*types.Operation, outputOps []*types.Operation) error {
+	type lock struct {
+		owner    string
+		locktime uint64
+	}
+	lockedAmounts := func(ops []*types.Operation) (map[lock]uint64, error) {
+		amounts := make(map[lock]uint64)
+		for _, op := range ops {
+			opMetadata, err := ParseOpMetadata(op.Metadata)
+			if err != nil {
+				return nil, err
+			}
+			locktime := opMetadata.StakeableLocktime
+			if locktime == 0 {
+				continue
+			}
+
+			val, err := types.AmountValue(op.Amount)
+			if err != nil {
+				return nil, err
+			}
+			key := lock{owner: op.Account.Address, locktime: locktime}
+			amount := val.Abs(val).Uint64()
+			if amount > math.MaxUint64-amounts[key] {
+				return nil, errOutputAmountOverflow
+			}
+			amounts[key] += amount
+		}
+		return amounts, nil
+	}
+
+	consumed, err := lockedAmounts(inputOps)
+	if err != nil {
+		return err
+	}
+	produced, err := lockedAmounts(outputOps)
+	if err != nil {
+		return err
+	}
+	for l, amount := range consumed {
+		if produced[l] < amount {
+			return fmt.Errorf(
+				"%w: %s spends %d and gets back %d locked until %d",
+				errLockedFundsNotProduced,
+				l.owner,
+				amount,
+				produced[l],
+				l.locktime,
+			)
+		}
+	}
+	return nil
+}
+
 func sumOutputAmounts(stakeOutputs []*avax.TransferableOutput) (uint64, error) {
 	var stakeOutputAmountSum uint64
 	for _, out := range stakeOutputs {
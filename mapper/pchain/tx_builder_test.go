@@ -0,0 +1,229 @@
+package pchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestBuildStakeableLockedDelegatorTx(t *testing.T) {
+	const locktime = uint64(1893456000)
+	account := &types.AccountIdentifier{Address: "P-fuji1xm0r37l6gyf2mly4pmzc0tz6wnwqkugedh95fk"}
+	lockedCoinID := ids.GenerateTestID().String() + ":0"
+	unlockedCoinID := ids.GenerateTestID().String() + ":1"
+
+	inputOp := func(coinID string, amount int64, stakeableLocktime uint64) *types.Operation {
+		return &types.Operation{
+			Type:    OpAddPermissionlessDelegator,
+			Account: account,
+			Amount:  mapper.AtomicAvaxAmount(big.NewInt(-amount)),
+			CoinChange: &types.CoinChange{
+				CoinIdentifier: &types.CoinIdentifier{Identifier: coinID},
+				CoinAction:     types.CoinSpent,
+			},
+			Metadata: map[string]interface{}{
+				"type":               OpTypeInput,
+				"sig_indices":        []interface{}{0.0},
+				"stakeable_locktime": float64(stakeableLocktime),
+			},
+		}
+	}
+	outputOp := func(opType string, amount int64, stakeableLocktime uint64) *types.Operation {
+		return &types.Operation{
+			Type:    OpAddPermissionlessDelegator,
+			Account: account,
+			Amount:  mapper.AtomicAvaxAmount(big.NewInt(amount)),
+			Metadata: map[string]interface{}{
+				"type":               opType,
+				"locktime":           0.0,
+				"threshold":          1.0,
+				"stakeable_locktime": float64(stakeableLocktime),
+			},
+		}
+	}
+	metadata := Metadata{
+		NetworkID:    avaconstants.FujiID,
+		BlockchainID: ids.Empty,
+		StakingMetadata: &StakingMetadata{
+			NodeID:                  ids.GenerateTestNodeID().String(),
+			ValidationRewardsOwners: []string{account.Address},
+			End:                     locktime,
+		},
+	}
+	inputOps := []*types.Operation{
+		inputOp(lockedCoinID, 3_000_000_000, locktime),
+		inputOp(unlockedCoinID, 1_000_000_000, 0),
+	}
+
+	t.Run("locked funds are staked and their change keeps the lock", func(t *testing.T) {
+		require := require.New(t)
+
+		tx, _, err := BuildTx(
+			OpAddPermissionlessDelegator,
+			[]*parser.Match{
+				{Operations: inputOps},
+				{Operations: []*types.Operation{
+					outputOp(OpTypeStakeOutput, 2_000_000_000, locktime),
+					outputOp(OpTypeOutput, 1_000_000_000, locktime),
+					outputOp(OpTypeOutput, 900_000_000, 0),
+				}},
+			},
+			metadata,
+			txs.Codec,
+			avaxAssetID,
+		)
+		require.NoError(err)
+
+		utx := tx.Unsigned.(*txs.AddPermissionlessDelegatorTx)
+		lockedIns := 0
+		for _, in := range utx.Ins {
+			if lockIn, ok := in.In.(*stakeable.LockIn); ok {
+				require.Equal(locktime, lockIn.Locktime)
+				require.Equal(lockedCoinID, in.UTXOID.String())
+				lockedIns++
+			}
+		}
+		require.Equal(1, lockedIns)
+		require.Len(utx.StakeOuts, 1)
+		require.IsType(&stakeable.LockOut{}, utx.StakeOuts[0].Out)
+		require.Equal(uint64(2_000_000_000), utx.Wght)
+		require.Len(utx.Outs, 2)
+
+		// the stakeable locktime of inputs and outputs is parsed back
+		parser, err := NewTxParser(
+			TxParserConfig{
+				IsConstruction: true,
+				Hrp:            avaconstants.FujiHRP,
+				ChainIDs:       chainIDs,
+				AvaxAssetID:    avaxAssetID,
+			},
+			map[string]*types.AccountIdentifier{lockedCoinID: account, unlockedCoinID: account},
+			nil,
+		)
+		require.NoError(err)
+		rosettaTx, err := parser.Parse(tx)
+		require.NoError(err)
+
+		var lockedOps int
+		for _, op := range rosettaTx.Operations {
+			if op.Metadata[MetadataStakeableLocktime] == float64(locktime) {
+				lockedOps++
+			}
+		}
+		require.Equal(3, lockedOps)
+	})
+
+	t.Run("locked funds not produced back", func(t *testing.T) {
+		_, _, err := BuildTx(
+			OpAddPermissionlessDelegator,
+			[]*parser.Match{
+				{Operations: inputOps},
+				{Operations: []*types.Operation{
+					outputOp(OpTypeStakeOutput, 2_000_000_000, locktime),
+					// the locked change is sent to an unlocked output
+					outputOp(OpTypeOutput, 1_900_000_000, 0),
+				}},
+			},
+			metadata,
+			txs.Codec,
+			avaxAssetID,
+		)
+		require.ErrorIs(t, err, errLockedFundsNotProduced)
+	})
+
+	t.Run("input with a locktime", func(t *testing.T) {
+		// inputs have no locktime, the stakeable locktime of a coin is set as
+		// the stakeable_locktime of its input
+		lockedInput := inputOp(lockedCoinID, 3_000_000_000, 0)
+		lockedInput.Metadata["locktime"] = float64(locktime)
+
+		_, _, err := BuildTx(
+			OpAddPermissionlessDelegator,
+			[]*parser.Match{
+				{Operations: []*types.Operation{lockedInput, inputOp(unlockedCoinID, 1_000_000_000, 0)}},
+				{Operations: []*types.Operation{
+					outputOp(OpTypeStakeOutput, 2_000_000_000, locktime),
+					outputOp(OpTypeOutput, 1_000_000_000, locktime),
+					outputOp(OpTypeOutput, 900_000_000, 0),
+				}},
+			},
+			metadata,
+			txs.Codec,
+			avaxAssetID,
+		)
+		require.ErrorIs(t, err, errInputLocktime)
+	})
+}
+
+func TestBuildTxMemo(t *testing.T) {
+	account := &types.AccountIdentifier{Address: "P-fuji1xm0r37l6gyf2mly4pmzc0tz6wnwqkugedh95fk"}
+	coinID := ids.GenerateTestID().String() + ":0"
+	matches := []*parser.Match{
+		{Operations: []*types.Operation{{
+			Type:    OpExportAvax,
+			Account: account,
+			Amount:  mapper.AtomicAvaxAmount(big.NewInt(-1_000_000_000)),
+			CoinChange: &types.CoinChange{
+				CoinIdentifier: &types.CoinIdentifier{Identifier: coinID},
+				CoinAction:     types.CoinSpent,
+			},
+			Metadata: map[string]interface{}{"type": OpTypeInput},
+		}}},
+		{Operations: []*types.Operation{{
+			Type:     OpExportAvax,
+			Account:  account,
+			Amount:   mapper.AtomicAvaxAmount(big.NewInt(999_000_000)),
+			Metadata: map[string]interface{}{"type": OpTypeExport},
+		}}},
+	}
+	metadata := Metadata{
+		NetworkID: avaconstants.FujiID,
+		Memo:      []byte("deposit 42"),
+		ExportMetadata: &ExportMetadata{
+			DestinationChain:   constants.CChain.String(),
+			DestinationChainID: cChainID,
+		},
+	}
+
+	t.Run("memo is set and parsed back", func(t *testing.T) {
+		require := require.New(t)
+
+		tx, _, err := BuildTx(OpExportAvax, matches, metadata, txs.Codec, avaxAssetID)
+		require.NoError(err)
+		require.Equal("deposit 42", string(tx.Unsigned.(*txs.ExportTx).Memo))
+
+		parser, err := NewTxParser(
+			TxParserConfig{
+				IsConstruction: true,
+				Hrp:            avaconstants.FujiHRP,
+				ChainIDs:       chainIDs,
+				AvaxAssetID:    avaxAssetID,
+			},
+			map[string]*types.AccountIdentifier{coinID: account},
+			nil,
+		)
+		require.NoError(err)
+		rosettaTx, err := parser.Parse(tx)
+		require.NoError(err)
+		require.Equal("0x6465706f736974203432", rosettaTx.Metadata[mapper.MetadataMemo])
+	})
+
+	t.Run("memo too large", func(t *testing.T) {
+		largeMemoMetadata := metadata
+		largeMemoMetadata.Memo = make([]byte, avax.MaxMemoSize+1)
+		_, _, err := BuildTx(OpExportAvax, matches, largeMemoMetadata, txs.Codec, avaxAssetID)
+		require.ErrorIs(t, err, errMemoTooLarge)
+	})
+}
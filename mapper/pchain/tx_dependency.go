@@ -1,7 +1,6 @@
 package pchain
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -20,60 +19,19 @@ type BlockTxDependencies map[ids.ID]*SingleTxDependency
 // this list is then used to fetch the dependency transactions in order to extract source addresses
 // as this information is not part of the transaction objects on chain.
 func GetTxDependenciesIDs(tx txs.UnsignedTx) ([]ids.ID, error) {
-	// collect tx inputs
-	// TODO: Move to using [txs.Visitor] from AvalancheGo
-	// Ref: https://github.com/ava-labs/avalanchego/blob/master/vms/platformvm/txs/visitor.go
-	var ins []*avax.TransferableInput
-	switch unsignedTx := tx.(type) {
-	case *txs.AddValidatorTx:
-		ins = unsignedTx.Ins
-	case *txs.AddSubnetValidatorTx:
-		ins = unsignedTx.Ins
-	case *txs.AddDelegatorTx:
-		ins = unsignedTx.Ins
-	case *txs.CreateChainTx:
-		ins = unsignedTx.Ins
-	case *txs.CreateSubnetTx:
-		ins = unsignedTx.Ins
-	case *txs.ImportTx:
-		ins = unsignedTx.Ins
-	case *txs.ExportTx:
-		ins = unsignedTx.Ins
-	case *txs.AdvanceTimeTx:
-		return []ids.ID{}, nil
-	case *txs.RewardValidatorTx:
-		return []ids.ID{unsignedTx.TxID}, nil
-	case *txs.RemoveSubnetValidatorTx:
-		ins = unsignedTx.Ins
-	case *txs.TransformSubnetTx:
-		ins = unsignedTx.Ins
-	case *txs.AddPermissionlessValidatorTx:
-		ins = unsignedTx.Ins
-	case *txs.AddPermissionlessDelegatorTx:
-		ins = unsignedTx.Ins
-	case *txs.TransferSubnetOwnershipTx:
-		ins = unsignedTx.Ins
-	case *txs.ConvertSubnetToL1Tx:
-		ins = unsignedTx.Ins
-	case *txs.RegisterL1ValidatorTx:
-		ins = unsignedTx.Ins
-	case *txs.IncreaseL1ValidatorBalanceTx:
-		ins = unsignedTx.Ins
-	case *txs.SetL1ValidatorWeightTx:
-		ins = unsignedTx.Ins
-	case *txs.DisableL1ValidatorTx:
-		ins = unsignedTx.Ins
-	case *txs.BaseTx:
-		ins = unsignedTx.Ins
-	default:
-		return nil, fmt.Errorf("unknown tx type %T", unsignedTx)
+	v, err := visitTx(tx)
+	if err != nil {
+		return nil, err
 	}
 
 	// extract txIDs and filter out duplicates
 	txIDs := make(map[ids.ID]ids.ID)
-	for _, in := range ins {
+	for _, in := range v.ins {
 		txIDs[in.UTXOID.TxID] = in.UTXOID.TxID
 	}
+	for _, txID := range v.dependencies {
+		txIDs[txID] = txID
+	}
 	uniqueTxIDs := make([]ids.ID, 0, len(txIDs))
 	for _, txnID := range txIDs {
 		uniqueTxIDs = append(uniqueTxIDs, txnID)
@@ -141,56 +99,12 @@ func (d *SingleTxDependency) GetUtxos() map[avax.UTXOID]*avax.UTXO {
 
 	if d.Tx != nil {
 		// Generate UTXOs from outputs
-		// TODO: Move to using [txs.Visitor] from AvalancheGo
-		// Ref: https://github.com/ava-labs/avalanchego/blob/master/vms/platformvm/txs/visitor.go
-		outsToAdd := make([]*avax.TransferableOutput, 0)
-		switch unsignedTx := d.Tx.Unsigned.(type) {
-		case *txs.AddValidatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-			outsToAdd = append(outsToAdd, unsignedTx.Stake()...)
-		case *txs.AddSubnetValidatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.AddDelegatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-			outsToAdd = append(outsToAdd, unsignedTx.Stake()...)
-		case *txs.CreateChainTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.CreateSubnetTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.ImportTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.ExportTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.AdvanceTimeTx:
-			// No outputs to add
-		case *txs.RewardValidatorTx:
-			// No outputs to add
-		case *txs.RemoveSubnetValidatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.TransformSubnetTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.AddPermissionlessValidatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-			outsToAdd = append(outsToAdd, unsignedTx.Stake()...)
-		case *txs.AddPermissionlessDelegatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-			outsToAdd = append(outsToAdd, unsignedTx.Stake()...)
-		case *txs.TransferSubnetOwnershipTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.ConvertSubnetToL1Tx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.RegisterL1ValidatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.IncreaseL1ValidatorBalanceTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.SetL1ValidatorWeightTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.DisableL1ValidatorTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		case *txs.BaseTx:
-			outsToAdd = append(outsToAdd, unsignedTx.Outputs()...)
-		default:
-			log.Printf("unknown type %T", unsignedTx)
+		var outsToAdd []*avax.TransferableOutput
+		v, err := visitTx(d.Tx.Unsigned)
+		if err != nil {
+			log.Printf("failed to visit tx %s: %v", d.Tx.ID(), err)
+		} else {
+			outsToAdd = v.utxoOuts()
 		}
 
 		// add collected utxos
@@ -10,6 +10,9 @@ type txOps struct {
 	StakeOuts      []*types.Operation
 	ImportIns      []*types.Operation
 	ExportOuts     []*types.Operation
+	// L1ValidatorBalances are the operations moving AVAX to the balance of L1
+	// validators, which do not produce any utxo
+	L1ValidatorBalances []*types.Operation
 }
 
 func newTxOps(isConstruction bool) *txOps {
@@ -21,12 +24,13 @@ func (t *txOps) IncludedOperations() []*types.Operation {
 	ops = append(ops, t.Ins...)
 	ops = append(ops, t.Outs...)
 	ops = append(ops, t.StakeOuts...)
+	ops = append(ops, t.L1ValidatorBalances...)
 	return ops
 }
 
 // Used to populate operation identifier
 func (t *txOps) Len() int {
-	return len(t.Ins) + len(t.Outs) + len(t.StakeOuts)
+	return len(t.Ins) + len(t.Outs) + len(t.StakeOuts) + len(t.L1ValidatorBalances)
 }
 
 // Used to populate coin identifier
@@ -58,5 +62,7 @@ func (t *txOps) Append(op *types.Operation, metaType string) {
 		t.Outs = append(t.Outs, op)
 	case OpTypeInput:
 		t.Ins = append(t.Ins, op)
+	case OpTypeL1ValidatorBalance:
+		t.L1ValidatorBalances = append(t.L1ValidatorBalances, op)
 	}
 }
@@ -4,13 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"math/big"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -52,6 +53,9 @@ type TxParserConfig struct {
 	AvaxAssetID ids.ID
 	// PChainClient holds a P-chain client, used to lookup asset descriptions for non-AVAX assets
 	PChainClient client.PChainClient
+	// GasWeights holds the weights of the dynamic fee dimensions, used to report the gas used
+	// by transactions and the gas price they paid. It is left empty before Etna.
+	GasWeights gas.Dimensions
 }
 
 func (cfg *TxParserConfig) lazyInitChainIDs() error {
@@ -115,90 +119,45 @@ func NewTxParser(
 
 // Parse converts the given unsigned P-chain tx to corresponding Rosetta Transaction
 func (t *TxParser) Parse(signedTx *txs.Tx) (*types.Transaction, error) {
-	var (
-		ops    *txOps
-		txType string
-		err    error
-	)
-
-	// TODO: Move to using [txs.Visitor] from AvalancheGo
-	// Ref: https://github.com/ava-labs/avalanchego/blob/master/vms/platformvm/txs/visitor.go
 	txID := signedTx.ID()
-	switch unsignedTx := signedTx.Unsigned.(type) {
-	case *txs.AddValidatorTx:
-		txType = OpAddValidator
-		ops, err = t.parseAddValidatorTx(txID, unsignedTx)
-	case *txs.AddSubnetValidatorTx:
-		txType = OpAddSubnetValidator
-		ops, err = t.parseAddSubnetValidatorTx(txID, unsignedTx)
-	case *txs.AddDelegatorTx:
-		txType = OpAddDelegator
-		ops, err = t.parseAddDelegatorTx(txID, unsignedTx)
-	case *txs.CreateChainTx:
-		txType = OpCreateChain
-		ops, err = t.parseCreateChainTx(txID, unsignedTx)
-	case *txs.CreateSubnetTx:
-		txType = OpCreateSubnet
-		ops, err = t.parseCreateSubnetTx(txID, unsignedTx)
-	case *txs.ImportTx:
-		txType = OpImportAvax
-		ops, err = t.parseImportTx(txID, unsignedTx)
-	case *txs.ExportTx:
-		txType = OpExportAvax
-		ops, err = t.parseExportTx(txID, unsignedTx)
-	case *txs.AdvanceTimeTx:
-		txType = OpAdvanceTime
-		// no op tx
-	case *txs.RewardValidatorTx:
-		txType = OpRewardValidator
-		ops, err = t.parseRewardValidatorTx(unsignedTx)
-	case *txs.RemoveSubnetValidatorTx:
-		txType = OpRemoveSubnetValidator
-		ops, err = t.parseRemoveSubnetValidatorTx(txID, unsignedTx)
-	case *txs.TransformSubnetTx:
-		txType = OpTransformSubnetValidator
-		ops, err = t.parseTransformSubnetTx(txID, unsignedTx)
-	case *txs.AddPermissionlessValidatorTx:
-		txType = OpAddPermissionlessValidator
-		ops, err = t.parseAddPermissionlessValidatorTx(txID, unsignedTx)
-	case *txs.AddPermissionlessDelegatorTx:
-		txType = OpAddPermissionlessDelegator
-		ops, err = t.parseAddPermissionlessDelegatorTx(txID, unsignedTx)
-	case *txs.TransferSubnetOwnershipTx:
-		txType = OpTransferSubnetOwnership
-		ops, err = t.parseTransferSubnetOwnershipTx(txID, unsignedTx)
-	case *txs.ConvertSubnetToL1Tx:
-		txType = OpConvertSubnetToL1Tx
-		ops, err = t.parseConvertSubnetToL1Tx(txID, unsignedTx)
-	case *txs.RegisterL1ValidatorTx:
-		txType = OpRegisterL1ValidatorTx
-		ops, err = t.parseRegisterL1ValidatorTx(txID, unsignedTx)
-	case *txs.IncreaseL1ValidatorBalanceTx:
-		txType = OpIncreaseL1ValidatorBalanceTx
-		ops, err = t.parseIncreaseL1ValidatorBalanceTx(txID, unsignedTx)
-	case *txs.SetL1ValidatorWeightTx:
-		txType = OpSetL1ValidatorWeightTx
-		ops, err = t.parseSetL1ValidatorWeightTx(txID, unsignedTx)
-	case *txs.DisableL1ValidatorTx:
-		txType = OpDisableL1ValidatorTx
-		ops, err = t.parseDisableL1ValidatorTx(txID, unsignedTx)
-	case *txs.BaseTx:
-		txType = OpBase
-		ops, err = t.parseBaseTx(txID, unsignedTx)
-	default:
-		log.Printf("unknown type %T", unsignedTx)
-	}
+	v, err := visitTx(signedTx.Unsigned)
 	if err != nil {
 		return nil, err
 	}
 
+	var ops *txOps
+	if v.parse != nil {
+		ops, err = v.parse(t, txID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	txMetadata := map[string]interface{}{
-		MetadataTxType: txType,
+		MetadataTxType: v.txType,
+	}
+	if len(v.memo) != 0 {
+		memo, err := formatting.Encode(formatting.HexNC, v.memo)
+		if err != nil {
+			return nil, err
+		}
+		txMetadata[mapper.MetadataMemo] = memo
+	}
+
+	// Genesis txs produce outputs out of thin air and therefore burn no fee
+	fee, feeErr := BurnedFee(signedTx.Unsigned, t.cfg.AvaxAssetID)
+	if feeErr == nil {
+		t.addFeeMetadata(txMetadata, signedTx.Unsigned, fee)
 	}
 
 	var operations []*types.Operation
 	if ops != nil {
 		operations = ops.IncludedOperations()
+		if feeErr == nil {
+			if feeOp := t.feeOperation(ops, fee, len(operations)); feeOp != nil {
+				operations = append(operations, feeOp)
+			}
+		}
 		idx := len(operations)
 		if ops.ImportIns != nil {
 			importedInputs := addOperationIdentifiers(ops.ImportIns, idx)
@@ -435,26 +394,10 @@ func (t *TxParser) parseCreateChainTx(txID ids.ID, tx *txs.CreateChainTx) (*txOp
 	return t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpCreateChain)
 }
 
-func (t *TxParser) parseConvertSubnetToL1Tx(txID ids.ID, tx *txs.ConvertSubnetToL1Tx) (*txOps, error) {
-	return t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpConvertSubnetToL1Tx)
-}
-
-func (t *TxParser) parseRegisterL1ValidatorTx(txID ids.ID, tx *txs.RegisterL1ValidatorTx) (*txOps, error) {
-	return t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpRegisterL1ValidatorTx)
-}
-
-func (t *TxParser) parseIncreaseL1ValidatorBalanceTx(txID ids.ID, tx *txs.IncreaseL1ValidatorBalanceTx) (*txOps, error) {
-	return t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpIncreaseL1ValidatorBalanceTx)
-}
-
 func (t *TxParser) parseSetL1ValidatorWeightTx(txID ids.ID, tx *txs.SetL1ValidatorWeightTx) (*txOps, error) {
 	return t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpSetL1ValidatorWeightTx)
 }
 
-func (t *TxParser) parseDisableL1ValidatorTx(txID ids.ID, tx *txs.DisableL1ValidatorTx) (*txOps, error) {
-	return t.baseTxToCombinedOperations(txID, &tx.BaseTx, OpDisableL1ValidatorTx)
-}
-
 func (t *TxParser) baseTxToCombinedOperations(txID ids.ID, tx *txs.BaseTx, txType string) (*txOps, error) {
 	ops := newTxOps(t.cfg.IsConstruction)
 
@@ -489,7 +432,7 @@ func (t *TxParser) insToOperations(
 
 		input := in.In
 		if stakeableIn, ok := input.(*stakeable.LockIn); ok {
-			metadata.Locktime = stakeableIn.Locktime
+			metadata.StakeableLocktime = stakeableIn.Locktime
 			input = stakeableIn.TransferableIn
 		}
 		transferInput, ok := input.(*secp256k1fx.TransferInput)
@@ -594,7 +537,9 @@ func (t *TxParser) outsToOperations(
 	for outIndex, out := range txOut {
 		transferOut := out.Out
 
+		var stakeableLocktime uint64
 		if lockOut, ok := transferOut.(*stakeable.LockOut); ok {
+			stakeableLocktime = lockOut.Locktime
 			transferOut = lockOut.TransferableOut
 		}
 
@@ -617,6 +562,7 @@ func (t *TxParser) outsToOperations(
 
 		outOp, err := t.buildOutputOperation(
 			transferOutput,
+			stakeableLocktime,
 			out.AssetID(),
 			status,
 			outOps.Len(),
@@ -650,7 +596,9 @@ func (t *TxParser) utxosToOperations(
 
 	for _, utxo := range utxos {
 		outIntf := utxo.Out
+		var stakeableLocktime uint64
 		if lockedOut, ok := outIntf.(*stakeable.LockOut); ok {
+			stakeableLocktime = lockedOut.Locktime
 			outIntf = lockedOut.TransferableOut
 		}
 
@@ -674,6 +622,7 @@ func (t *TxParser) utxosToOperations(
 
 		outOp, err := t.buildOutputOperation(
 			out,
+			stakeableLocktime,
 			utxo.AssetID(),
 			status,
 			outOps.Len(),
@@ -695,6 +644,7 @@ func (t *TxParser) utxosToOperations(
 
 func (t *TxParser) buildOutputOperation(
 	out *secp256k1fx.TransferOutput,
+	stakeableLocktime uint64,
 	assetID ids.ID,
 	status *string,
 	startIndex int,
@@ -714,9 +664,10 @@ func (t *TxParser) buildOutputOperation(
 	}
 
 	metadata := &OperationMetadata{
-		Type:      metaType,
-		Threshold: out.OutputOwners.Threshold,
-		Locktime:  out.OutputOwners.Locktime,
+		Type:              metaType,
+		Threshold:         out.OutputOwners.Threshold,
+		Locktime:          out.OutputOwners.Locktime,
+		StakeableLocktime: stakeableLocktime,
 	}
 
 	opMetadata, err := mapper.MarshalJSONMap(metadata)
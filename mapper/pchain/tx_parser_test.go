@@ -6,6 +6,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -15,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/mapper"
 
 	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
+	txfee "github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 )
 
 var (
@@ -74,7 +76,8 @@ func TestMapInOperation(t *testing.T) {
 	require.Equal(OpTypeInput, rosettaOp.Metadata["type"])
 	require.Equal(OpAddValidator, rosettaOp.Type)
 	require.Equal(types.String(mapper.StatusSuccess), rosettaOp.Status)
-	require.Equal(float64(1666781236), rosettaOp.Metadata["locktime"])
+	require.Equal(float64(0), rosettaOp.Metadata["locktime"])
+	require.Equal(float64(1666781236), rosettaOp.Metadata["stakeable_locktime"])
 	require.Nil(rosettaOp.Metadata["threshold"])
 	require.NotNil(rosettaOp.Metadata["sig_indices"])
 }
@@ -467,7 +470,8 @@ func TestMapNonConstructionExportTx(t *testing.T) {
 	rosettaTransaction, err := parser.Parse(signedTx)
 	require.NoError(err)
 
-	total := len(exportTx.Ins) + len(exportTx.Outs)
+	// inputs and outputs are followed by the fee operation
+	total := len(exportTx.Ins) + len(exportTx.Outs) + 1
 	require.Len(rosettaTransaction.Operations, total)
 
 	cntTxType, cntInputMeta, cntOutputMeta, cntMetaType := verifyRosettaTransaction(rosettaTransaction.Operations, OpExportAvax, OpTypeExport)
@@ -481,6 +485,29 @@ func TestMapNonConstructionExportTx(t *testing.T) {
 	require.True(ok)
 	require.Equal(OpExportAvax, txType)
 
+	// Verify that the burned fee is reported on the account of the input
+	fee := mapper.AtomicAvaxAmount(big.NewInt(1_000_000))
+	require.Equal(fee, rosettaTransaction.Metadata[mapper.MetadataTxFee])
+	complexity, err := txfee.TxComplexity(exportTx)
+	require.NoError(err)
+	require.Equal(&GasComplexity{
+		Bandwidth: complexity[gas.Bandwidth],
+		DBRead:    complexity[gas.DBRead],
+		DBWrite:   complexity[gas.DBWrite],
+		Compute:   complexity[gas.Compute],
+	}, rosettaTransaction.Metadata[MetadataGasComplexity])
+	require.NotContains(rosettaTransaction.Metadata, MetadataGasPrice)
+	feeOp := rosettaTransaction.Operations[2]
+	require.Equal(mapper.OpFee, feeOp.Type)
+	require.Equal(int64(2), feeOp.OperationIdentifier.Index)
+	require.Equal(rosettaTransaction.Operations[0].Account, feeOp.Account)
+	require.Nil(feeOp.Amount)
+	require.Equal(fee, feeOp.Metadata[mapper.MetadataTxFee])
+
+	// the input still spends the whole utxo, fee included
+	spent := new(big.Int).SetUint64(exportTx.Ins[0].In.Amount())
+	require.Equal(mapper.AtomicAvaxAmount(spent.Neg(spent)), rosettaTransaction.Operations[0].Amount)
+
 	// Verify that export output are properly generated
 	exportOutputs, ok := rosettaTransaction.Metadata[mapper.MetadataExportedOutputs].([]*types.Operation)
 	require.True(ok)
@@ -501,6 +528,8 @@ func TestMapNonConstructionExportTx(t *testing.T) {
 	out := rosettaTransactionWithExportOperations.Operations[2]
 	out.Status = types.String(mapper.StatusSuccess)
 	out.CoinChange = exportOutputs[0].CoinChange
+	// exported outputs are indexed after the fee operation
+	out.OperationIdentifier = &types.OperationIdentifier{Index: 3}
 	require.Equal([]*types.Operation{out}, exportOutputs)
 }
 
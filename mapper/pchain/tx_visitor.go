@@ -0,0 +1,254 @@
+package pchain
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// A new tx type added to AvalancheGo comes with a new [txs.Visitor] method,
+// so that it fails to compile until the visitor handles it
+var _ txs.Visitor = (*txVisitor)(nil)
+
+// txVisitor collects the type, inputs, outputs and dependencies of a P-chain tx,
+// and how its operations are parsed, so that they are handled in a single place
+// for each tx type
+type txVisitor struct {
+	// txType is the Rosetta type of the tx
+	txType string
+	// parse generates the operations of the tx
+	parse func(t *TxParser, txID ids.ID) (*txOps, error)
+
+	// ins are the inputs consumed on the P-chain
+	ins []*avax.TransferableInput
+	// signedIns are the inputs whose spending is authorized by the tx credentials
+	signedIns []*avax.TransferableInput
+	// importedIns are the inputs consumed from the shared memory of the source chain
+	importedIns []*avax.TransferableInput
+
+	// outs are the outputs produced on the P-chain
+	outs []*avax.TransferableOutput
+	// stakeOuts are the outputs locked until the end of the staking period,
+	// which are indexed after [outs]
+	stakeOuts []*avax.TransferableOutput
+	// exportedOuts are the outputs produced in the shared memory of the destination chain
+	exportedOuts []*avax.TransferableOutput
+	// balance is the AVAX moved to the balance of L1 validators
+	balance uint64
+
+	// dependencies are the txs referenced by the tx other than through its inputs
+	dependencies []ids.ID
+
+	// memo is the memo of the tx, if any
+	memo []byte
+}
+
+// visitTx collects the type, inputs, outputs and dependencies of [tx]
+func visitTx(tx txs.UnsignedTx) (*txVisitor, error) {
+	v := &txVisitor{}
+	if err := tx.Visit(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SignedInputs returns the inputs of [tx] whose spending is authorized by its
+// credentials: the imported inputs of import txs and the inputs of other txs.
+func SignedInputs(tx txs.UnsignedTx) ([]*avax.TransferableInput, error) {
+	v, err := visitTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return v.signedIns, nil
+}
+
+// utxoOuts returns the outputs of the tx in the order of their utxo indices
+func (v *txVisitor) utxoOuts() []*avax.TransferableOutput {
+	outs := make([]*avax.TransferableOutput, 0, len(v.outs)+len(v.stakeOuts))
+	outs = append(outs, v.outs...)
+	return append(outs, v.stakeOuts...)
+}
+
+func (v *txVisitor) baseTx(txType string, tx *txs.BaseTx) {
+	v.txType = txType
+	v.ins = tx.Ins
+	v.signedIns = tx.Ins
+	v.outs = tx.Outs
+	v.memo = tx.Memo
+}
+
+func (v *txVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	v.baseTx(OpAddValidator, &tx.BaseTx)
+	v.stakeOuts = tx.StakeOuts
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseAddValidatorTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	v.baseTx(OpAddSubnetValidator, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseAddSubnetValidatorTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	v.baseTx(OpAddDelegator, &tx.BaseTx)
+	v.stakeOuts = tx.StakeOuts
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseAddDelegatorTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
+	v.baseTx(OpCreateChain, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseCreateChainTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	v.baseTx(OpCreateSubnet, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseCreateSubnetTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) ImportTx(tx *txs.ImportTx) error {
+	v.baseTx(OpImportAvax, &tx.BaseTx)
+	v.signedIns = tx.ImportedInputs
+	v.importedIns = tx.ImportedInputs
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseImportTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) ExportTx(tx *txs.ExportTx) error {
+	v.baseTx(OpExportAvax, &tx.BaseTx)
+	v.exportedOuts = tx.ExportedOutputs
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseExportTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	// no op tx
+	v.txType = OpAdvanceTime
+	return nil
+}
+
+func (v *txVisitor) RewardValidatorTx(tx *txs.RewardValidatorTx) error {
+	v.txType = OpRewardValidator
+	v.dependencies = []ids.ID{tx.TxID}
+	v.parse = func(t *TxParser, _ ids.ID) (*txOps, error) {
+		return t.parseRewardValidatorTx(tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	v.baseTx(OpRemoveSubnetValidator, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseRemoveSubnetValidatorTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	v.baseTx(OpTransformSubnetValidator, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseTransformSubnetTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	v.baseTx(OpAddPermissionlessValidator, &tx.BaseTx)
+	v.stakeOuts = tx.StakeOuts
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseAddPermissionlessValidatorTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	v.baseTx(OpAddPermissionlessDelegator, &tx.BaseTx)
+	v.stakeOuts = tx.StakeOuts
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseAddPermissionlessDelegatorTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	v.baseTx(OpTransferSubnetOwnership, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseTransferSubnetOwnershipTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) BaseTx(tx *txs.BaseTx) error {
+	v.baseTx(OpBase, tx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseBaseTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) ConvertSubnetToL1Tx(tx *txs.ConvertSubnetToL1Tx) error {
+	v.baseTx(OpConvertSubnetToL1Tx, &tx.BaseTx)
+	for _, validator := range tx.Validators {
+		balance, err := math.Add(v.balance, validator.Balance)
+		if err != nil {
+			return err
+		}
+		v.balance = balance
+	}
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseConvertSubnetToL1Tx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) RegisterL1ValidatorTx(tx *txs.RegisterL1ValidatorTx) error {
+	v.baseTx(OpRegisterL1ValidatorTx, &tx.BaseTx)
+	v.balance = tx.Balance
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseRegisterL1ValidatorTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) SetL1ValidatorWeightTx(tx *txs.SetL1ValidatorWeightTx) error {
+	v.baseTx(OpSetL1ValidatorWeightTx, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseSetL1ValidatorWeightTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) IncreaseL1ValidatorBalanceTx(tx *txs.IncreaseL1ValidatorBalanceTx) error {
+	v.baseTx(OpIncreaseL1ValidatorBalanceTx, &tx.BaseTx)
+	v.balance = tx.Balance
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseIncreaseL1ValidatorBalanceTx(txID, tx)
+	}
+	return nil
+}
+
+func (v *txVisitor) DisableL1ValidatorTx(tx *txs.DisableL1ValidatorTx) error {
+	v.baseTx(OpDisableL1ValidatorTx, &tx.BaseTx)
+	v.parse = func(t *TxParser, txID ids.ID) (*txOps, error) {
+		return t.parseDisableL1ValidatorTx(txID, tx)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package pchain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxVisitorHandlesAllTxTypes(t *testing.T) {
+	allTxs := []txs.UnsignedTx{
+		&txs.AddValidatorTx{},
+		&txs.AddSubnetValidatorTx{},
+		&txs.AddDelegatorTx{},
+		&txs.CreateChainTx{},
+		&txs.CreateSubnetTx{},
+		&txs.ImportTx{},
+		&txs.ExportTx{},
+		&txs.AdvanceTimeTx{},
+		&txs.RewardValidatorTx{},
+		&txs.RemoveSubnetValidatorTx{},
+		&txs.TransformSubnetTx{},
+		&txs.AddPermissionlessValidatorTx{},
+		&txs.AddPermissionlessDelegatorTx{},
+		&txs.TransferSubnetOwnershipTx{},
+		&txs.BaseTx{},
+		&txs.ConvertSubnetToL1Tx{},
+		&txs.RegisterL1ValidatorTx{},
+		&txs.SetL1ValidatorWeightTx{},
+		&txs.IncreaseL1ValidatorBalanceTx{},
+		&txs.DisableL1ValidatorTx{},
+	}
+
+	// Each method of [txs.Visitor] is named after the tx type it visits, so a tx
+	// type added to AvalancheGo must be added to [allTxs]
+	visitedTypes := map[string]bool{}
+	for _, tx := range allTxs {
+		visitedTypes[reflect.TypeOf(tx).Elem().Name()] = true
+	}
+	visitorType := reflect.TypeOf((*txs.Visitor)(nil)).Elem()
+	require.Equal(t, visitorType.NumMethod(), len(visitedTypes))
+	for i := 0; i < visitorType.NumMethod(); i++ {
+		require.Contains(t, visitedTypes, visitorType.Method(i).Name)
+	}
+
+	for _, tx := range allTxs {
+		v, err := visitTx(tx)
+		require.NoError(t, err)
+		require.NotEmpty(t, v.txType, "%T has no type", tx)
+		if _, ok := tx.(*txs.AdvanceTimeTx); !ok {
+			require.NotNil(t, v.parse, "%T is not parsed", tx)
+		}
+
+		_, err = GetTxDependenciesIDs(tx)
+		require.NoError(t, err)
+		_, err = BurnedFee(tx, ids.Empty)
+		require.NoError(t, err)
+		_, err = SignedInputs(tx)
+		require.NoError(t, err)
+	}
+}
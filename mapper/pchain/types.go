@@ -3,6 +3,10 @@ package pchain
 import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/coinbase/rosetta-sdk-go/parser"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	avatypes "github.com/ava-labs/avalanchego/vms/types"
 )
 
 const (
@@ -34,6 +38,8 @@ const (
 	OpTypeStakeOutput = "STAKE"
 	OpTypeReward      = "REWARD"
 
+	OpTypeL1ValidatorBalance = "L1_VALIDATOR_BALANCE"
+
 	MetadataOpType           = "type"
 	MetadataTxType           = "tx_type"
 	MetadataStakingTxID      = "staking_tx_id"
@@ -43,8 +49,17 @@ const (
 	MetadataMessage          = "message"
 	MetadataSigner           = "signer"
 
-	MetadataBaseFee = "base_fee"
-	MetadataMatches = "matches"
+	MetadataBlockType       = "block_type"
+	MetadataBurnedFee       = "burned_fee"
+	MetadataProposerBlockID = "proposer_block_id"
+	MetadataProposerNodeID  = "proposer_node_id"
+	MetadataPChainHeight    = "p_chain_height"
+
+	MetadataBaseFee       = "base_fee"
+	MetadataGasComplexity = "gas_complexity"
+	MetadataGasUsed       = "gas_used"
+	MetadataGasPrice      = "gas_price"
+	MetadataMatches       = "matches"
 
 	MetadataValidatorRewards       = "validator_rewards"
 	MetadataValidatorRewardsOwner  = "validator_rewards_owner"
@@ -53,12 +68,24 @@ const (
 	MetadataDelegationRewards      = "delegation_rewards"
 	MetadataDelegationFeeRewards   = "delegation_fee_rewards"
 	MetadataSubnetID               = "subnet_id"
+	MetadataStakerType             = "staker_type"
+	MetadataRewardOwner            = "reward_owner"
+	MetadataPotentialReward        = "potential_reward"
+	MetadataValidationID           = "validation_id"
+	MetadataBalance                = "balance"
+	MetadataStakeableLocktime      = "stakeable_locktime"
 
 	SubAccountTypeSharedMemory       = "shared_memory"
 	SubAccountTypeUnlocked           = "unlocked"
 	SubAccountTypeLockedStakeable    = "locked_stakeable"
 	SubAccountTypeLockedNotStakeable = "locked_not_stakeable"
 	SubAccountTypeStaked             = "staked"
+	SubAccountTypeL1ValidatorBalance = "l1_validator_balance"
+
+	StakerTypeValidator = "validator"
+	StakerTypeDelegator = "delegator"
+
+	SignerNodeLocal = "local"
 )
 
 var (
@@ -76,7 +103,11 @@ var (
 		OpAddPermissionlessValidator,
 		OpAddPermissionlessDelegator,
 	}
-	CallMethods = []string{}
+	CallMethods = []string{
+		mapper.MetadataBundleCallMethod,
+		mapper.SimulateCallMethod,
+		mapper.TxStatusCallMethod,
+	}
 )
 
 // OperationMetadata contains metadata fields specific to individual Rosetta operations as opposed to transactions
@@ -86,6 +117,12 @@ type OperationMetadata struct {
 	Locktime   uint64          `json:"locktime"`
 	Threshold  uint32          `json:"threshold,omitempty"`
 	Matches    []*parser.Match `json:"matches,omitempty"`
+
+	// StakeableLocktime is the time until which the funds of a stakeable locked output
+	// can only be staked. An input spending a stakeable locked utxo carries the
+	// stakeable locktime of the utxo, as listed by /account/coins, while [Locktime]
+	// is the secp256k1fx locktime of outputs, which inputs don't have.
+	StakeableLocktime uint64 `json:"stakeable_locktime,omitempty"`
 }
 
 // ImportExportOptions contain response fields returned by /construction/preprocess for P-chain Import/Export transactions
@@ -94,6 +131,12 @@ type ImportExportOptions struct {
 	DestinationChain string `json:"destination_chain"`
 }
 
+// TxOptions contain response fields returned by /construction/preprocess for all P-chain transactions
+type TxOptions struct {
+	// Memo is hex encoded, as the memo reported when transactions are parsed
+	Memo avatypes.JSONByteSlice `json:"memo"`
+}
+
 // StakingOptions contain response fields returned by /construction/preprocess for P-chain AddValidator/AddDelegator transactions
 type StakingOptions struct {
 	NodeID                  string   `json:"node_id"`
@@ -107,12 +150,18 @@ type StakingOptions struct {
 	Shares                  uint32   `json:"shares"`
 	Locktime                uint64   `json:"locktime"`
 	Threshold               uint32   `json:"threshold"`
+
+	// SignerNode is the node whose BLS public key and proof of possession are used
+	// when they are not provided: [SignerNodeLocal] for the node Rosetta is connected
+	// to, or the URL of the node to query, which must be configured on the server
+	SignerNode string `json:"signer_node"`
 }
 
 // Metadata contains metadata values returned by /construction/metadata for P-chain transactions
 type Metadata struct {
-	NetworkID    uint32 `json:"network_id"`
-	BlockchainID ids.ID `json:"blockchain_id"`
+	NetworkID    uint32                 `json:"network_id"`
+	BlockchainID ids.ID                 `json:"blockchain_id"`
+	Memo         avatypes.JSONByteSlice `json:"memo,omitempty"`
 	*ImportMetadata
 	*ExportMetadata
 	*StakingMetadata
@@ -42,6 +42,7 @@ func Transaction(
 	trace *client.Call,
 	flattenedTrace []*client.FlatCall,
 	rpcClient client.Client,
+	nativeCurrency *types.Currency,
 	isAnalyticsMode bool,
 	standardModeWhiteList []string,
 	includeUnknownTokens bool,
@@ -61,7 +62,7 @@ func Transaction(
 			Type:    OpFee,
 			Status:  types.String(StatusSuccess),
 			Account: Account(&sender),
-			Amount:  AvaxAmount(new(big.Int).Neg(txFee)),
+			Amount:  Amount(new(big.Int).Neg(txFee), nativeCurrency),
 		},
 		{
 			OperationIdentifier: &types.OperationIdentifier{
@@ -75,13 +76,13 @@ func Transaction(
 			Type:    OpFee,
 			Status:  types.String(StatusSuccess),
 			Account: Account(feeReceiver),
-			Amount:  AvaxAmount(txFee),
+			Amount:  Amount(txFee, nativeCurrency),
 		},
 	}
 
 	ops = append(ops, feeOps...)
 
-	traceOps := traceOps(flattenedTrace, len(feeOps))
+	traceOps := traceOps(flattenedTrace, len(feeOps), nativeCurrency)
 	ops = append(ops, traceOps...)
 	for _, log := range receipt.Logs {
 		// Only check transfer logs
@@ -260,6 +261,28 @@ func crossChainTransaction(
 		return nil, nil, fmt.Errorf("unsupported transaction: %T", t)
 	}
 
+	// tx fee is the diff between sums of input/importedInput and output/exportedOutput amounts
+	txFeeAtomicAvax := new(big.Int).Sub(totalInputAmount, totalOutputAmount)
+	metadata[MetadataTxFee] = AtomicAvaxAmount(txFeeAtomicAvax)
+
+	// The fee of export txs is paid by the account of their first input. Imported inputs are
+	// not attributed to any account, so the fee of import txs is only reported as metadata.
+	// As inputs already spend the fee, the fee operation carries no amount.
+	if _, ok := tx.UnsignedAtomicTx.(*evm.UnsignedExportTx); ok && len(ops) > 0 && txFeeAtomicAvax.Sign() > 0 {
+		ops = append(ops, &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: idx,
+			},
+			Type:    OpFee,
+			Status:  types.String(StatusSuccess),
+			Account: ops[0].Account,
+			Metadata: map[string]interface{}{
+				MetadataTxFee: AtomicAvaxAmount(txFeeAtomicAvax),
+			},
+		})
+		idx++
+	}
+
 	// Adding operation identifiers to exported outs here since OperationIdentifier is a required field in the spec.
 	// As Rosetta does not allow gaps in operation identifiers within the same transaction,
 	// setting the identifier is deferred to here and all operations in the transaction are given sequential indices
@@ -269,10 +292,6 @@ func crossChainTransaction(
 		}
 	}
 
-	// tx fee is the diff between sums of input/importedInput and output/exportedOutput amounts
-	txFeeAtomicAvax := new(big.Int).Sub(totalInputAmount, totalOutputAmount)
-	metadata[MetadataTxFee] = AtomicAvaxAmount(txFeeAtomicAvax)
-
 	return ops, metadata, nil
 }
 
@@ -381,7 +400,7 @@ func MempoolTransactionsIDs(accountMap client.TxAccountMap) []*types.Transaction
 	return result
 }
 
-func traceOps(trace []*client.FlatCall, startIndex int) []*types.Operation {
+func traceOps(trace []*client.FlatCall, startIndex int, currency *types.Currency) []*types.Operation {
 	ops := []*types.Operation{}
 	if len(trace) == 0 {
 		return ops
@@ -427,7 +446,7 @@ func traceOps(trace []*client.FlatCall, startIndex int) []*types.Operation {
 				},
 				Amount: &types.Amount{
 					Value:    new(big.Int).Neg(call.Value).String(),
-					Currency: AvaxCurrency,
+					Currency: currency,
 				},
 				Metadata: metadata,
 			}
@@ -488,7 +507,7 @@ func traceOps(trace []*client.FlatCall, startIndex int) []*types.Operation {
 				},
 				Amount: &types.Amount{
 					Value:    call.Value.String(),
-					Currency: AvaxCurrency,
+					Currency: currency,
 				},
 				Metadata: metadata,
 			}
@@ -527,7 +546,7 @@ func traceOps(trace []*client.FlatCall, startIndex int) []*types.Operation {
 			},
 			Amount: &types.Amount{
 				Value:    new(big.Int).Neg(val).String(),
-				Currency: AvaxCurrency,
+				Currency: currency,
 			},
 		})
 	}
@@ -348,12 +348,25 @@ func TestCrossChainExportedOuts(t *testing.T) {
 				"asset_id":          "U8iRqJoiJm8xZHAacmvYyZVwqQx6uDNtQeP3CQ6fcgQk3JqnK",
 			},
 		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 1,
+			},
+			Type:   OpFee,
+			Status: types.String(StatusSuccess),
+			Account: &types.AccountIdentifier{
+				Address: "0x3158e80abD5A1e1aa716003C9Db096792C379621",
+			},
+			Metadata: map[string]interface{}{
+				MetadataTxFee: AtomicAvaxAmount(big.NewInt(280750)),
+			},
+		},
 	}, ops)
 
 	require.Equal([]*types.Operation{
 		{
 			OperationIdentifier: &types.OperationIdentifier{
-				Index: 1,
+				Index: 2,
 			},
 			Type:   OpExport,
 			Status: types.String(StatusSuccess),
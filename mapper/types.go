@@ -8,6 +8,9 @@ import (
 const (
 	ContractAddressMetadata  = "contractAddress"
 	IndexTransferredMetadata = "indexTransferred"
+	Erc721Metadata           = "erc721"
+	OwnedTokenIDsMetadata    = "ownedTokenIds"
+	SpenderMetadata          = "spender"
 
 	OpCall          = "CALL"
 	OpFee           = "FEE"
@@ -23,6 +26,7 @@ const (
 	OpErc20Transfer = "ERC20_TRANSFER"
 	OpErc20Mint     = "ERC20_MINT"
 	OpErc20Burn     = "ERC20_BURN"
+	OpErc20Approve  = "ERC20_APPROVE"
 
 	OpErc721TransferSender  = "ERC721_SENDER"
 	OpErc721TransferReceive = "ERC721_RECEIVE"
@@ -35,8 +39,16 @@ const (
 	MetadataTxFee           = "tx_fee"
 	MetadataImportedInputs  = "imported_inputs"
 	MetadataExportedOutputs = "exported_outputs"
+	MetadataMemo            = "memo"
 	MetadataAddressFormat   = "address_format"
 	AddressFormatBech32     = "bech32"
+
+	// MetadataBundleCallMethod is the /call method exporting a metadata bundle
+	MetadataBundleCallMethod = "construction_metadata_bundle"
+	// SimulateCallMethod is the /call method dry-running a constructed transaction
+	SimulateCallMethod = "construction_simulate"
+	// TxStatusCallMethod is the /call method returning the status of a submitted transaction
+	TxStatusCallMethod = "construction_transaction_status"
 )
 
 var (
@@ -50,6 +62,21 @@ var (
 		Stage:  types.String("SYNCED"),
 	}
 
+	StageStateSync = &types.SyncStatus{
+		Synced: types.Bool(false),
+		Stage:  types.String("STATE_SYNC"),
+	}
+
+	StageExecuting = &types.SyncStatus{
+		Synced: types.Bool(false),
+		Stage:  types.String("EXECUTING"),
+	}
+
+	StageLagging = &types.SyncStatus{
+		Synced: types.Bool(false),
+		Stage:  types.String("LAGGING"),
+	}
+
 	AvaxCurrency = &types.Currency{
 		Symbol:   "AVAX",
 		Decimals: 18,
@@ -86,6 +113,7 @@ var (
 		OpErc20Burn,
 		OpErc20Mint,
 		OpErc20Transfer,
+		OpErc20Approve,
 		OpErc721TransferReceive,
 		OpErc721TransferSender,
 		OpErc721Mint,
@@ -94,6 +122,9 @@ var (
 
 	CallMethods = []string{
 		"eth_getTransactionReceipt",
+		MetadataBundleCallMethod,
+		SimulateCallMethod,
+		TxStatusCallMethod,
 	}
 )
 
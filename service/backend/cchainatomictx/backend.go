@@ -43,30 +43,49 @@ func NewBackend(cClient client.Client, avaxAssetID ids.ID, avalancheNetworkID ui
 func (b *Backend) ShouldHandleRequest(req interface{}) bool {
 	switch r := req.(type) {
 	case *types.AccountBalanceRequest:
-		return cmapper.IsCChainBech32Address(r.AccountIdentifier)
+		return isCChain(r.NetworkIdentifier) && cmapper.IsCChainBech32Address(r.AccountIdentifier)
 	case *types.AccountCoinsRequest:
-		return cmapper.IsCChainBech32Address(r.AccountIdentifier)
+		return isCChain(r.NetworkIdentifier) && cmapper.IsCChainBech32Address(r.AccountIdentifier)
 	case *types.ConstructionDeriveRequest:
-		return r.Metadata[mapper.MetadataAddressFormat] == mapper.AddressFormatBech32
+		return isCChain(r.NetworkIdentifier) && r.Metadata[mapper.MetadataAddressFormat] == mapper.AddressFormatBech32
 	case *types.ConstructionMetadataRequest:
-		return r.Options[cmapper.MetadataAtomicTxGas] != nil
+		return isCChain(r.NetworkIdentifier) && r.Options[cmapper.MetadataAtomicTxGas] != nil
 	case *types.ConstructionPreprocessRequest:
-		return cmapper.IsAtomicOpType(r.Operations[0].Type)
+		return isCChain(r.NetworkIdentifier) && cmapper.IsAtomicOpType(r.Operations[0].Type)
 	case *types.ConstructionPayloadsRequest:
-		return cmapper.IsAtomicOpType(r.Operations[0].Type)
+		return isCChain(r.NetworkIdentifier) && cmapper.IsAtomicOpType(r.Operations[0].Type)
 	case *types.ConstructionParseRequest:
-		return b.isCchainAtomicTx(r.Transaction)
+		return isCChain(r.NetworkIdentifier) && b.isCchainAtomicTx(r.Transaction)
 	case *types.ConstructionCombineRequest:
-		return b.isCchainAtomicTx(r.UnsignedTransaction)
+		return isCChain(r.NetworkIdentifier) && b.isCchainAtomicTx(r.UnsignedTransaction)
 	case *types.ConstructionHashRequest:
-		return b.isCchainAtomicTx(r.SignedTransaction)
+		return isCChain(r.NetworkIdentifier) && b.isCchainAtomicTx(r.SignedTransaction)
 	case *types.ConstructionSubmitRequest:
-		return b.isCchainAtomicTx(r.SignedTransaction)
+		return isCChain(r.NetworkIdentifier) && b.isCchainAtomicTx(r.SignedTransaction)
+	case *service.TxStatusRequest:
+		return isCChain(r.NetworkIdentifier) && isAtomicTxID(r.TransactionIdentifier)
 	}
 
 	return false
 }
 
+// isAtomicTxID returns true if [txIdentifier] holds the ID of an atomic
+// transaction rather than the hash of an EVM transaction
+func isAtomicTxID(txIdentifier *types.TransactionIdentifier) bool {
+	if txIdentifier == nil {
+		return false
+	}
+	_, err := ids.FromString(txIdentifier.Hash)
+	return err == nil
+}
+
+// isCChain returns true if [networkIdentifier] identifies the C-chain. Atomic
+// transactions are not supported by the other EVM chains, which are served as
+// sub networks.
+func isCChain(networkIdentifier *types.NetworkIdentifier) bool {
+	return networkIdentifier == nil || networkIdentifier.SubNetworkIdentifier == nil
+}
+
 func (b *Backend) isCchainAtomicTx(transaction string) bool {
 	_, err := b.parsePayloadTxFromString(transaction)
 	return err == nil
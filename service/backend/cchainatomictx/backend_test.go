@@ -88,6 +88,10 @@ func TestShouldHandleRequest(t *testing.T) {
 			NetworkIdentifier: cChainNetworkIdentifier,
 			SignedTransaction: atomicTxString,
 		}))
+		require.True(backend.ShouldHandleRequest(&service.TxStatusRequest{
+			NetworkIdentifier:     cChainNetworkIdentifier,
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "2QmMXKS6rKQMnEh2XYZ4ZWCJmy8RpD3LyVZWxBG25t4N1JJqxY"},
+		}))
 	})
 
 	t.Run("return false for other c-chain requests", func(t *testing.T) {
@@ -131,6 +135,10 @@ func TestShouldHandleRequest(t *testing.T) {
 			NetworkIdentifier: cChainNetworkIdentifier,
 			SignedTransaction: nonAtomicTxString,
 		}))
+		require.False(backend.ShouldHandleRequest(&service.TxStatusRequest{
+			NetworkIdentifier:     cChainNetworkIdentifier,
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "0x3a4e3f9c1bc7ae1e5a4c15ba1b9b7a84e3f74e1e1f3c5d6b2ad1b0c1c0a6c1f2"},
+		}))
 
 		// Backend does not support /block and /network endpoints
 		require.False(backend.ShouldHandleRequest(&types.BlockRequest{
@@ -43,6 +43,11 @@ func (b *Backend) ConstructionPreprocess(
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
 
+	// unlike P-chain and X-chain txs, C-chain atomic txs have no memo field
+	if _, ok := req.Metadata[mapper.MetadataMemo]; ok {
+		return nil, service.WrapError(service.ErrInvalidInput, "memo is not supported by C-chain atomic transactions")
+	}
+
 	firstIn, _ := matches[0].First()
 	firstOut, _ := matches[1].First()
 
@@ -192,14 +197,23 @@ func (b *Backend) ConstructionMetadata(
 }
 
 func (b *Backend) calculateSuggestedFee(ctx context.Context, gasUsed *big.Int) (*types.Amount, error) {
+	suggestedFee, err := b.calculateFee(ctx, gasUsed)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AtomicAvaxAmount(suggestedFee), nil
+}
+
+// calculateFee returns the fee, in nAVAX, of an atomic transaction using [gasUsed]
+// at the estimated base fee
+func (b *Backend) calculateFee(ctx context.Context, gasUsed *big.Int) (*big.Int, error) {
 	baseFee, err := b.cClient.EstimateBaseFee(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	suggestedFeeEth := new(big.Int).Mul(gasUsed, baseFee)
-	suggestedFee := new(big.Int).Div(suggestedFeeEth, mapper.X2crate)
-	return mapper.AtomicAvaxAmount(suggestedFee), nil
+	feeEth := new(big.Int).Mul(gasUsed, baseFee)
+	return new(big.Int).Div(feeEth, mapper.X2crate), nil
 }
 
 // ConstructionPayloads implements /construction/payloads endpoint for C-chain atomic transactions
@@ -230,8 +244,9 @@ func (b *Backend) ConstructionParse(_ context.Context, req *types.ConstructionPa
 	}
 
 	txParser := cAtomicTxParser{
-		hrp:      hrp,
-		chainIDs: chainIDs,
+		hrp:         hrp,
+		chainIDs:    chainIDs,
+		avaxAssetID: b.avaxAssetID,
 	}
 
 	return common.Parse(txParser, rosettaTx, req.Signed)
@@ -333,3 +348,164 @@ func (b *Backend) ConstructionSubmit(
 
 	return common.SubmitTx(ctx, b.cClient, rosettaTx)
 }
+
+// ConstructionTxStatus returns the status of a C-chain atomic transaction submitted to the node
+func (b *Backend) ConstructionTxStatus(
+	ctx context.Context,
+	req *service.TxStatusRequest,
+) (*service.TxStatus, *types.Error) {
+	txID, err := ids.FromString(req.TransactionIdentifier.Hash)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	status, err := b.cClient.GetAtomicTxStatus(ctx, txID)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+
+	txStatus := &service.TxStatus{TransactionIdentifier: req.TransactionIdentifier}
+	switch status {
+	case evm.Accepted:
+		txStatus.Status = service.TxStatusAccepted
+	case evm.Processing:
+		txStatus.Status = service.TxStatusPending
+	case evm.Dropped:
+		txStatus.Status = service.TxStatusDropped
+		txStatus.Reason = "transaction failed verification"
+	default:
+		// the transaction may not have reached the node yet
+		txStatus.Status = service.TxStatusPending
+		txStatus.Reason = "transaction is not known by the node"
+	}
+	return txStatus, nil
+}
+
+// ConstructionSimulate checks that the inputs of a C-chain atomic transaction are
+// still spendable and that it burns a sufficient fee
+func (b *Backend) ConstructionSimulate(
+	ctx context.Context,
+	req *types.ConstructionParseRequest,
+) (*service.SimulationResult, *types.Error) {
+	parsed, terr := b.ConstructionParse(ctx, req)
+	if terr != nil {
+		return nil, terr
+	}
+
+	rosettaTx, err := b.parsePayloadTxFromString(req.Transaction)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+	cTx, ok := rosettaTx.Tx.(*cAtomicTx)
+	if !ok {
+		return nil, service.WrapError(service.ErrInvalidInput, "invalid transaction")
+	}
+
+	result := &service.SimulationResult{
+		Success:    true,
+		Operations: parsed.Operations,
+	}
+
+	var failure string
+	switch tx := cTx.Tx.UnsignedAtomicTx.(type) {
+	case *evm.UnsignedImportTx:
+		failure, err = b.checkImportedInputs(ctx, rosettaTx, tx)
+	case *evm.UnsignedExportTx:
+		failure, err = b.checkEVMInputs(ctx, tx)
+	default:
+		return nil, service.WrapError(service.ErrInvalidInput, errUnknownTxType)
+	}
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+	if len(failure) > 0 {
+		result.Success = false
+		result.Error = failure
+		return result, nil
+	}
+
+	gasUsed, err := cTx.Tx.GasUsed(true)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+	requiredFee, err := b.calculateFee(ctx, new(big.Int).SetUint64(gasUsed))
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+	burned, err := cTx.Tx.Burned(b.avaxAssetID)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+	common.CheckFee(result, burned, requiredFee.Uint64())
+
+	return result, nil
+}
+
+// checkImportedInputs returns why the inputs of [tx] cannot be imported, if
+// they are not in the shared memory of the source chain anymore
+func (b *Backend) checkImportedInputs(
+	ctx context.Context,
+	rosettaTx *common.RosettaTx,
+	tx *evm.UnsignedImportTx,
+) (string, error) {
+	addrs, err := common.SignerAddresses(rosettaTx)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		utxos [][]byte
+
+		// Used for pagination
+		lastUtxoAddress ids.ShortID
+		lastUtxoID      ids.ID
+	)
+	for {
+		page, newUtxoAddress, newUtxoID, err := b.cClient.GetAtomicUTXOs(ctx, addrs, tx.SourceChain.String(), b.getUTXOsPageSize, lastUtxoAddress, lastUtxoID)
+		if err != nil {
+			return "", err
+		}
+		utxos = append(utxos, page...)
+
+		// Fetch next page only if there may be more UTXOs
+		if len(page) < int(b.getUTXOsPageSize) {
+			break
+		}
+
+		lastUtxoAddress = newUtxoAddress
+		lastUtxoID = newUtxoID
+	}
+
+	missing, err := common.MissingInputs(b.codec, tx.ImportedInputs, utxos)
+	if err != nil {
+		return "", err
+	}
+	if len(missing) > 0 {
+		return fmt.Sprintf("inputs %v are spent or do not exist", missing), nil
+	}
+	return "", nil
+}
+
+// checkEVMInputs returns why the EVM inputs of [tx] cannot be spent, if their
+// nonce is stale or their balance too low
+func (b *Backend) checkEVMInputs(ctx context.Context, tx *evm.UnsignedExportTx) (string, error) {
+	for _, in := range tx.Ins {
+		nonce, err := b.cClient.NonceAt(ctx, in.Address, nil)
+		if err != nil {
+			return "", err
+		}
+		if nonce != in.Nonce {
+			return fmt.Sprintf("nonce of %s is %d, input uses %d", in.Address.Hex(), nonce, in.Nonce), nil
+		}
+
+		balance, err := b.cClient.BalanceAt(ctx, in.Address, nil)
+		if err != nil {
+			return "", err
+		}
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(in.Amount), mapper.X2crate)
+		if balance.Cmp(amount) < 0 {
+			return fmt.Sprintf("balance of %s is lower than its input of %d nAVAX", in.Address.Hex(), in.Amount), nil
+		}
+	}
+	return "", nil
+}
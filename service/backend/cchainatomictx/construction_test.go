@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/service"
 	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
 
+	cmapper "github.com/ava-labs/avalanche-rosetta/mapper/cchainatomictx"
 	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 )
@@ -32,9 +33,11 @@ var (
 	cAccountIdentifier       = &types.AccountIdentifier{Address: "0x3158e80abD5A1e1aa716003C9Db096792C379621"}
 	cAccountBech32Identifier = &types.AccountIdentifier{Address: "C-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"}
 	pAccountIdentifier       = &types.AccountIdentifier{Address: "P-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"}
+	xAccountIdentifier       = &types.AccountIdentifier{Address: "X-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"}
 
 	cChainID, _ = ids.FromString("yH8D7ThNJkxmtkuv2jgBa4P1Rn3Qpr4pPr7QYNfcdoS6k6HWp")
 	pChainID    = ids.Empty
+	xChainID, _ = ids.FromString("2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm")
 
 	avalancheNetworkID = avaconstants.FujiID
 
@@ -171,6 +174,18 @@ func TestExportTxConstruction(t *testing.T) {
 		require.Equal(t, metadataOptions, resp.Options)
 	})
 
+	t.Run("preprocess endpoint rejects memos", func(t *testing.T) {
+		req := &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        exportOperations,
+			Metadata:          map[string]interface{}{"memo": "deposit 42"},
+		}
+
+		_, terr := backend.ConstructionPreprocess(ctx, req)
+
+		require.Equal(t, service.ErrInvalidInput.Code, terr.Code)
+	})
+
 	t.Run("metadata endpoint", func(t *testing.T) {
 		req := &types.ConstructionMetadataRequest{
 			NetworkIdentifier: networkIdentifier,
@@ -217,6 +232,11 @@ func TestExportTxConstruction(t *testing.T) {
 		require.Nil(t, terr)
 		require.Nil(t, resp.AccountIdentifierSigners)
 		require.Equal(t, exportOperations, resp.Operations)
+		require.Equal(t, map[string]interface{}{
+			mapper.MetadataTxFee:        mapper.AtomicAvaxAmount(big.NewInt(280_750)),
+			cmapper.MetadataAtomicTxGas: uint64(11_230),
+			cmapper.MetadataGasPrice:    big.NewInt(25_000_000_000),
+		}, resp.Metadata)
 	})
 
 	t.Run("combine endpoint", func(t *testing.T) {
@@ -491,3 +511,137 @@ func TestImportTxConstruction(t *testing.T) {
 		require.Equal(signedImportTxHash, resp.TransactionIdentifier.Hash)
 	})
 }
+
+func TestXChainAtomicTxConstruction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("export to x-chain", func(t *testing.T) {
+		require := require.New(t)
+
+		exportOperations := []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                "EXPORT",
+				Account:             cAccountIdentifier,
+				Amount:              mapper.AtomicAvaxAmount(big.NewInt(-10_000_000)),
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				RelatedOperations: []*types.OperationIdentifier{
+					{Index: 0},
+				},
+				Type:    "EXPORT",
+				Account: xAccountIdentifier,
+				Amount:  mapper.AtomicAvaxAmount(big.NewInt(9_719_250)),
+			},
+		}
+
+		ctrl := gomock.NewController(t)
+		clientMock := client.NewMockClient(ctrl)
+		backend := NewBackend(clientMock, avaxAssetID, avalancheNetworkID)
+
+		preprocessResp, terr := backend.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        exportOperations,
+		})
+		require.Nil(terr)
+		require.Equal(constants.XChain.String(), preprocessResp.Options["destination_chain"])
+
+		clientMock.EXPECT().GetBlockchainID(ctx, constants.CChain.String()).Return(cChainID, nil)
+		clientMock.EXPECT().GetBlockchainID(ctx, constants.XChain.String()).Return(xChainID, nil)
+		clientMock.EXPECT().
+			NonceAt(ctx, ethcommon.HexToAddress(cAccountIdentifier.Address), (*big.Int)(nil)).
+			Return(uint64(48), nil)
+		clientMock.EXPECT().EstimateBaseFee(ctx).Return(big.NewInt(25_000_000_000), nil)
+
+		metadataResp, terr := backend.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResp.Options,
+		})
+		require.Nil(terr)
+		require.Equal(constants.XChain.String(), metadataResp.Metadata["destination_chain"])
+		require.Equal(xChainID.String(), metadataResp.Metadata["destination_chain_id"])
+
+		payloadsResp, terr := backend.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Metadata:          metadataResp.Metadata,
+			Operations:        exportOperations,
+		})
+		require.Nil(terr)
+
+		parseResp, terr := backend.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Transaction:       payloadsResp.UnsignedTransaction,
+			Signed:            false,
+		})
+		require.Nil(terr)
+		require.Equal(exportOperations, parseResp.Operations)
+	})
+
+	t.Run("import from x-chain", func(t *testing.T) {
+		require := require.New(t)
+
+		coinID := ids.GenerateTestID().String() + ":0"
+		importOperations := []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                "IMPORT",
+				Account:             cAccountBech32Identifier,
+				Amount:              mapper.AtomicAvaxAmount(big.NewInt(-20_000_000)),
+				CoinChange: &types.CoinChange{
+					CoinIdentifier: &types.CoinIdentifier{Identifier: coinID},
+					CoinAction:     types.CoinSpent,
+				},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				RelatedOperations: []*types.OperationIdentifier{
+					{Index: 0},
+				},
+				Type:    "IMPORT",
+				Account: cAccountIdentifier,
+				Amount:  mapper.AtomicAvaxAmount(big.NewInt(19_700_000)),
+			},
+		}
+
+		ctrl := gomock.NewController(t)
+		clientMock := client.NewMockClient(ctrl)
+		backend := NewBackend(clientMock, avaxAssetID, avalancheNetworkID)
+
+		preprocessResp, terr := backend.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        importOperations,
+			Metadata: map[string]interface{}{
+				"source_chain": constants.XChain.String(),
+			},
+		})
+		require.Nil(terr)
+		require.Equal(constants.XChain.String(), preprocessResp.Options["source_chain"])
+
+		clientMock.EXPECT().GetBlockchainID(ctx, constants.CChain.String()).Return(cChainID, nil)
+		clientMock.EXPECT().GetBlockchainID(ctx, constants.XChain.String()).Return(xChainID, nil)
+		clientMock.EXPECT().EstimateBaseFee(ctx).Return(big.NewInt(25_000_000_000), nil)
+
+		metadataResp, terr := backend.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResp.Options,
+		})
+		require.Nil(terr)
+		require.Equal(xChainID.String(), metadataResp.Metadata["source_chain_id"])
+
+		payloadsResp, terr := backend.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Metadata:          metadataResp.Metadata,
+			Operations:        importOperations,
+		})
+		require.Nil(terr)
+
+		parseResp, terr := backend.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Transaction:       payloadsResp.UnsignedTransaction,
+			Signed:            false,
+		})
+		require.Nil(terr)
+		require.Equal(importOperations, parseResp.Operations)
+	})
+}
@@ -93,15 +93,16 @@ func (c cAtomicTxBuilder) BuildTx(operations []*types.Operation, metadata map[st
 }
 
 type cAtomicTxParser struct {
-	hrp      string
-	chainIDs map[ids.ID]string
+	hrp         string
+	chainIDs    map[ids.ID]string
+	avaxAssetID ids.ID
 }
 
-func (c cAtomicTxParser) ParseTx(tx *common.RosettaTx, inputAddresses map[string]*types.AccountIdentifier) ([]*types.Operation, error) {
+func (c cAtomicTxParser) ParseTx(tx *common.RosettaTx, inputAddresses map[string]*types.AccountIdentifier) ([]*types.Operation, map[string]interface{}, error) {
 	cTx, ok := tx.Tx.(*cAtomicTx)
 	if !ok {
-		return nil, errors.New("invalid transaction")
+		return nil, nil, errors.New("invalid transaction")
 	}
-	parser := cmapper.NewTxParser(c.hrp, c.chainIDs, inputAddresses)
+	parser := cmapper.NewTxParser(c.hrp, c.chainIDs, inputAddresses, c.avaxAssetID)
 	return parser.Parse(*cTx.Tx)
 }
@@ -1,9 +1,11 @@
 package common
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
@@ -14,6 +16,9 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/coinbase/rosetta-sdk-go/parser"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
 
 	"github.com/ava-labs/avalanche-rosetta/constants"
 	"github.com/ava-labs/avalanche-rosetta/mapper"
@@ -184,7 +189,7 @@ func BuildPayloads(
 
 // TxParser implements backend specific transaction parsing logic
 type TxParser interface {
-	ParseTx(tx *RosettaTx, inputAddresses map[string]*types.AccountIdentifier) ([]*types.Operation, error)
+	ParseTx(tx *RosettaTx, inputAddresses map[string]*types.AccountIdentifier) ([]*types.Operation, map[string]interface{}, error)
 }
 
 // Parse contains transaction parsing logic for /construction/parse endpoint
@@ -192,7 +197,7 @@ type TxParser interface {
 func Parse(parser TxParser, payloadsTx *RosettaTx, isSigned bool) (*types.ConstructionParseResponse, *types.Error) {
 	// Convert input tx into operations
 	inputAddresses := getInputAddresses(payloadsTx)
-	operations, err := parser.ParseTx(payloadsTx, inputAddresses)
+	operations, metadata, err := parser.ParseTx(payloadsTx, inputAddresses)
 	if err != nil {
 		return nil, service.WrapError(service.ErrInvalidInput, "incorrect transaction input")
 	}
@@ -211,6 +216,7 @@ func Parse(parser TxParser, payloadsTx *RosettaTx, isSigned bool) (*types.Constr
 	return &types.ConstructionParseResponse{
 		Operations:               operations,
 		AccountIdentifierSigners: signers,
+		Metadata:                 metadata,
 	}, nil
 }
 
@@ -236,6 +242,10 @@ func Combine(
 	rosettaTx *RosettaTx,
 	signatures []*types.Signature,
 ) (*types.ConstructionCombineResponse, *types.Error) {
+	if tErr := verifySigners(rosettaTx, signatures); tErr != nil {
+		return nil, tErr
+	}
+
 	combinedTx, tErr := combiner.CombineTx(rosettaTx.Tx, signatures)
 	if tErr != nil {
 		return nil, tErr
@@ -256,6 +266,67 @@ func Combine(
 	}, nil
 }
 
+// verifySigners checks that each signature was produced over the signing payload
+// of [rosettaTx] by one of the accounts signing its inputs and, if the signature
+// names the account of its signing payload, by that account.
+//
+// The signing payload covers the network and blockchain IDs, so a signature
+// produced for another chain recovers another public key and is rejected.
+func verifySigners(rosettaTx *RosettaTx, signatures []*types.Signature) *types.Error {
+	payload := rosettaTx.Tx.SigningPayload()
+	for i, signature := range signatures {
+		signingPayload := signature.SigningPayload
+		if signingPayload != nil && len(signingPayload.Bytes) > 0 && !bytes.Equal(signingPayload.Bytes, payload) {
+			return service.WrapError(
+				service.ErrSigningPayloadMismatch,
+				fmt.Sprintf("signing payload of signature %d is not the one of the transaction", i),
+			)
+		}
+
+		if len(signature.Bytes) != secp256k1.SignatureLen {
+			return service.WrapError(service.ErrInvalidInput, errInvalidSignatureLen)
+		}
+		pub, err := secp256k1.RecoverPublicKeyFromHash(payload, signature.Bytes)
+		if err != nil {
+			return service.WrapError(service.ErrInvalidInput, err)
+		}
+
+		if signingPayload != nil && signingPayload.AccountIdentifier != nil &&
+			!isSigner(pub, signingPayload.AccountIdentifier.Address) {
+			return service.WrapError(
+				service.ErrSignerMismatch,
+				fmt.Sprintf("signature %d was not produced by %s", i, signingPayload.AccountIdentifier.Address),
+			)
+		}
+
+		signer := false
+		for _, s := range rosettaTx.AccountIdentifierSigners {
+			if s.AccountIdentifier != nil && isSigner(pub, s.AccountIdentifier.Address) {
+				signer = true
+				break
+			}
+		}
+		if !signer {
+			return service.WrapError(
+				service.ErrSignerMismatch,
+				fmt.Sprintf("signature %d was not produced by an input owner", i),
+			)
+		}
+	}
+	return nil
+}
+
+// isSigner returns true if [addr], either a bech32 or an EVM address, is the
+// address of [pub]
+func isSigner(pub *secp256k1.PublicKey, addr string) bool {
+	if ethcommon.IsHexAddress(addr) {
+		return crypto.PubkeyToAddress(*pub.ToECDSA()) == ethcommon.HexToAddress(addr)
+	}
+
+	id, err := address.ParseToID(addr)
+	return err == nil && id == pub.Address()
+}
+
 // BuildCredentialList builds a list of *secp256k1fx.Credentials using the given signatures
 //
 // Based on tx inputs, we can determine the number of signatures
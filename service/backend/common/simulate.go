@@ -0,0 +1,65 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+// SignerAddresses returns the distinct addresses signing the inputs of [rosettaTx]
+func SignerAddresses(rosettaTx *RosettaTx) ([]ids.ShortID, error) {
+	addrs := set.Set[ids.ShortID]{}
+	for _, signer := range rosettaTx.AccountIdentifierSigners {
+		if signer.AccountIdentifier == nil {
+			continue
+		}
+		addr, err := address.ParseToID(signer.AccountIdentifier.Address)
+		if err != nil {
+			return nil, err
+		}
+		addrs.Add(addr)
+	}
+	return addrs.List(), nil
+}
+
+// MissingInputs returns the UTXO IDs of the inputs in [ins] that are not among
+// [utxos], the serialized UTXOs owned by the input signers. These inputs are
+// either spent or do not exist.
+func MissingInputs(codec codec.Manager, ins []*avax.TransferableInput, utxos [][]byte) ([]string, error) {
+	unspent := set.NewSet[ids.ID](len(utxos))
+	for _, utxoBytes := range utxos {
+		var utxo avax.UTXO
+		if _, err := codec.Unmarshal(utxoBytes, &utxo); err != nil {
+			return nil, err
+		}
+		unspent.Add(utxo.InputID())
+	}
+
+	missing := []string{}
+	for _, in := range ins {
+		if !unspent.Contains(in.InputID()) {
+			missing = append(missing, in.UTXOID.String())
+		}
+	}
+	return missing, nil
+}
+
+// CheckFee sets the fee burned by a transaction, [burned], and the fee it must burn,
+// [required], on [result] and fails the simulation if the burned fee is too low.
+// Both fees are in nAVAX.
+func CheckFee(result *service.SimulationResult, burned uint64, required uint64) {
+	result.Fee = mapper.AtomicAvaxAmount(new(big.Int).SetUint64(burned))
+	result.RequiredFee = mapper.AtomicAvaxAmount(new(big.Int).SetUint64(required))
+	if burned < required {
+		result.Success = false
+		result.Error = fmt.Sprintf("transaction burns %d nAVAX but the fee is %d nAVAX", burned, required)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -61,6 +62,9 @@ func (b *Backend) AccountBalance(ctx context.Context, req *types.AccountBalanceR
 	if strings.HasPrefix(balanceType, ids.NodeIDPrefix) {
 		return b.getPendingRewardsBalance(ctx, req)
 	}
+	if balanceType == pmapper.SubAccountTypeL1ValidatorBalance {
+		return b.getL1ValidatorBalance(ctx, req)
+	}
 
 	fetchImportable := balanceType == pmapper.SubAccountTypeSharedMemory
 
@@ -125,6 +129,9 @@ func (b *Backend) AccountCoins(ctx context.Context, req *types.AccountCoinsReque
 	if req.AccountIdentifier.SubAccount != nil {
 		subAccountAddress = req.AccountIdentifier.SubAccount.Address
 	}
+	if subAccountAddress == pmapper.SubAccountTypeStaked {
+		return b.getStakedCoins(ctx, addr, assetIDs)
+	}
 	fetchSharedMemory := subAccountAddress == pmapper.SubAccountTypeSharedMemory
 
 	// utxos from fetchUTXOsAndStakedOutputs are guarateed to:
@@ -138,12 +145,18 @@ func (b *Backend) AccountCoins(ctx context.Context, req *types.AccountCoinsReque
 	}
 
 	// convert UTXOs to Rosetta Coins
+	currentTime := uint64(time.Now().Unix())
 	coins := []*types.Coin{}
 	for _, utxo := range utxos {
 		amounter, ok := utxo.Out.(avax.Amounter)
 		if !ok {
 			return nil, service.WrapError(service.ErrInternalError, errUnableToGetUTXOOut)
 		}
+		lockOut, isStakeableLocked := utxo.Out.(*stakeable.LockOut)
+		if subAccountAddress == pmapper.SubAccountTypeLockedStakeable && !isLockedStakeable(utxo.Out, currentTime) {
+			continue
+		}
+
 		coin := &types.Coin{
 			CoinIdentifier: &types.CoinIdentifier{Identifier: utxo.UTXOID.String()},
 			Amount: &types.Amount{
@@ -151,6 +164,13 @@ func (b *Backend) AccountCoins(ctx context.Context, req *types.AccountCoinsReque
 				Currency: mapper.AtomicAvaxCurrency,
 			},
 		}
+		// inputs spending stakeable locked utxos must carry their stakeable locktime,
+		// see [pmapper.OperationMetadata.StakeableLocktime]
+		if isStakeableLocked {
+			coin.Amount.Metadata = map[string]interface{}{
+				pmapper.MetadataStakeableLocktime: lockOut.Locktime,
+			}
+		}
 		coins = append(coins, coin)
 	}
 
@@ -254,6 +274,46 @@ func (b *Backend) getPendingRewardsBalance(ctx context.Context, req *types.Accou
 	}, nil
 }
 
+// getL1ValidatorBalance returns the remaining balance of the L1 validator whose
+// validation id is in the sub-account metadata, which pays for its continuous fee.
+// The account must be the owner of its remaining balance.
+func (b *Backend) getL1ValidatorBalance(ctx context.Context, req *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
+	addr, err := address.ParseToID(req.AccountIdentifier.Address)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "malformed address")
+	}
+
+	validationIDStr, _ := req.AccountIdentifier.SubAccount.Metadata[pmapper.MetadataValidationID].(string)
+	validationID, err := ids.FromString(validationIDStr)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "malformed validation_id")
+	}
+
+	validator, height, err := b.pClient.GetL1Validator(ctx, validationID)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+	if validator.RemainingBalanceOwner == nil || !slices.Contains(validator.RemainingBalanceOwner.Addrs, addr) {
+		return nil, service.WrapError(service.ErrInvalidInput, "account does not own the remaining balance of the L1 validator")
+	}
+
+	block, err := b.indexerParser.ParseNonGenesisBlock(ctx, "", height)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, "unable to get current block")
+	}
+
+	return &types.AccountBalanceResponse{
+		BlockIdentifier: &types.BlockIdentifier{
+			Index: int64(height),
+			Hash:  block.BlockID.String(),
+		},
+		Balances: []*types.Amount{{
+			Value:    strconv.FormatUint(validator.Balance, 10),
+			Currency: mapper.AtomicAvaxCurrency,
+		}},
+	}, nil
+}
+
 func (b *Backend) fetchBalance(ctx context.Context, addrString string, fetchImportable bool, assetIds set.Set[ids.ID]) (uint64, *AccountBalance, *types.Error) {
 	addr, err := address.ParseToID(addrString)
 	if err != nil {
@@ -287,6 +347,17 @@ func (b *Backend) fetchBalance(ctx context.Context, addrString string, fetchImpo
 	return height, balance, nil
 }
 
+// isLockedStakeable returns whether [out] is part of the locked stakeable balance,
+// following the same rules as [getBalancesWithoutMultisig]
+func isLockedStakeable(out verify.State, currentTime uint64) bool {
+	lockOut, ok := out.(*stakeable.LockOut)
+	if !ok {
+		return false
+	}
+	innerOut, ok := lockOut.TransferableOut.(*secp256k1fx.TransferOutput)
+	return ok && innerOut.Locktime <= currentTime && lockOut.Locktime > currentTime
+}
+
 // Copy of the platformvm service's GetBalance implementation.
 // This is needed as multisig UTXOs are cleaned in parseUTXOs and its output must be used for the calculations. Ref:
 // https://github.com/ava-labs/avalanchego/blob/0950acab667e0c16a55e9a9bb72bcbe25c3b88cf/vms/platformvm/service.go#L184
@@ -3,12 +3,16 @@ package pchain
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/require"
@@ -17,6 +21,8 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/client"
 	"github.com/ava-labs/avalanche-rosetta/constants"
 	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
 	"github.com/ava-labs/avalanche-rosetta/service/backend/pchain/indexer"
 
 	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
@@ -508,6 +514,65 @@ func TestAccountCoins(t *testing.T) {
 		require.Nil(terr)
 		require.Equal(expected, resp)
 	})
+
+	t.Run("Account Coins Test locked stakeable coins", func(t *testing.T) {
+		require := require.New(t)
+
+		pChainMock.EXPECT().GetAssetDescription(ctx, mapper.AtomicAvaxCurrency.Symbol).Return(mockAssetDescription, nil)
+
+		pChainAddrID, err := address.ParseToID(pChainAddr)
+		require.NoError(err)
+		utxo0Bytes := makeUtxoBytes(t, backend, utxos[0].id, utxos[0].amount)
+		utxo1ID, err := mapper.DecodeUTXOID(utxos[1].id)
+		require.NoError(err)
+		locktime := uint64(time.Now().Add(365 * 24 * time.Hour).Unix())
+		utxo1Bytes, err := backend.codec.Marshal(0, &avax.UTXO{
+			UTXOID: *utxo1ID,
+			Out: &stakeable.LockOut{
+				Locktime: locktime,
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt:          utxos[1].amount,
+					OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{pChainAddrID}},
+				},
+			},
+		})
+		require.NoError(err)
+
+		// once before other calls, once after
+		pChainMock.EXPECT().GetHeight(ctx).Return(blockHeight, nil).Times(2)
+		pageSize := uint32(1024)
+		backend.getUTXOsPageSize = pageSize
+		pChainMock.EXPECT().GetAtomicUTXOs(ctx, []ids.ShortID{pChainAddrID}, "", pageSize, ids.ShortEmpty, ids.Empty).
+			Return([][]byte{utxo0Bytes, utxo1Bytes}, pChainAddrID, utxo1ID.InputID(), nil)
+
+		resp, terr := backend.AccountCoins(
+			ctx,
+			&types.AccountCoinsRequest{
+				NetworkIdentifier: pChainNetworkIdentifier,
+				AccountIdentifier: &types.AccountIdentifier{
+					Address:    pChainAddr,
+					SubAccount: &types.SubAccountIdentifier{Address: pmapper.SubAccountTypeLockedStakeable},
+				},
+				Currencies: []*types.Currency{
+					mapper.AtomicAvaxCurrency,
+				},
+			})
+
+		// only the stakeable locked utxo is listed, along with its stakeable locktime
+		require.Nil(terr)
+		require.Equal([]*types.Coin{
+			{
+				CoinIdentifier: &types.CoinIdentifier{Identifier: utxos[1].id},
+				Amount: &types.Amount{
+					Value:    "2000000000",
+					Currency: mapper.AtomicAvaxCurrency,
+					Metadata: map[string]interface{}{
+						pmapper.MetadataStakeableLocktime: locktime,
+					},
+				},
+			},
+		}, resp.Coins)
+	})
 }
 
 func makeUtxoBytes(t *testing.T, backend *Backend, utxoIDStr string, amount uint64) []byte {
@@ -538,3 +603,273 @@ func makeStakeUtxoBytes(t *testing.T, backend *Backend, amount uint64) []byte {
 
 	return utxoBytes
 }
+
+func TestAccountL1ValidatorBalance(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	pChainMock := client.NewMockPChainClient(ctrl)
+	parserMock := indexer.NewMockParser(ctrl)
+
+	parserMock.EXPECT().GetGenesisBlock(ctx).Return(dummyGenesis, nil)
+	parserMock.EXPECT().ParseNonGenesisBlock(ctx, "", blockHeight).Return(parsedBlock, nil).AnyTimes()
+
+	backend, err := NewBackend(
+		pChainMock,
+		parserMock,
+		avaxAssetID,
+		pChainNetworkIdentifier,
+		avalancheNetworkID,
+	)
+	require.NoError(t, err)
+
+	ownerStr := "P-fuji1csj0hzu7rtljuhqnzp8m9shawlcefuvyl0m3e9"
+	owner, err := address.ParseToID(ownerStr)
+	require.NoError(t, err)
+	validationID := ids.GenerateTestID()
+
+	pChainMock.EXPECT().GetL1Validator(ctx, validationID).Return(platformvm.L1Validator{
+		RemainingBalanceOwner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+		Balance:               7_000_000,
+	}, blockHeight, nil).Times(2)
+
+	t.Run("remaining balance of the L1 validator", func(t *testing.T) {
+		resp, err := backend.AccountBalance(ctx, &types.AccountBalanceRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			AccountIdentifier: pmapper.L1ValidatorBalanceAccount(ownerStr, validationID),
+		})
+		require.Nil(t, err)
+		require.Equal(t, &types.AccountBalanceResponse{
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: int64(blockHeight),
+				Hash:  parsedBlock.BlockID.String(),
+			},
+			Balances: []*types.Amount{{
+				Value:    "7000000",
+				Currency: mapper.AtomicAvaxCurrency,
+			}},
+		}, resp)
+	})
+
+	t.Run("account does not own the remaining balance", func(t *testing.T) {
+		_, err := backend.AccountBalance(ctx, &types.AccountBalanceRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			AccountIdentifier: pmapper.L1ValidatorBalanceAccount("P-fuji1raffss40pyr7hdhyp7p4hs6p049hjlc60xxwks", validationID),
+		})
+		require.Equal(t, service.ErrInvalidInput.Code, err.Code)
+	})
+
+	t.Run("malformed validation id", func(t *testing.T) {
+		_, err := backend.AccountBalance(ctx, &types.AccountBalanceRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			AccountIdentifier: &types.AccountIdentifier{
+				Address:    ownerStr,
+				SubAccount: &types.SubAccountIdentifier{Address: pmapper.SubAccountTypeL1ValidatorBalance},
+			},
+		})
+		require.Equal(t, service.ErrInvalidInput.Code, err.Code)
+	})
+}
+
+func TestAccountStakedCoins(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	pChainMock := client.NewMockPChainClient(ctrl)
+	parserMock := indexer.NewMockParser(ctrl)
+
+	parserMock.EXPECT().GetGenesisBlock(ctx).Return(dummyGenesis, nil)
+	parserMock.EXPECT().ParseNonGenesisBlock(ctx, "", blockHeight).Return(parsedBlock, nil).AnyTimes()
+
+	backend, err := NewBackend(
+		pChainMock,
+		parserMock,
+		avaxAssetID,
+		pChainNetworkIdentifier,
+		avalancheNetworkID,
+	)
+	require.NoError(t, err)
+
+	ownerStr := "P-fuji1csj0hzu7rtljuhqnzp8m9shawlcefuvyl0m3e9"
+	owner, err := address.ParseToID(ownerStr)
+	require.NoError(t, err)
+	otherStr := "P-fuji1raffss40pyr7hdhyp7p4hs6p049hjlc60xxwks"
+	other, err := address.ParseToID(otherStr)
+	require.NoError(t, err)
+
+	stakeOut := func(addr ids.ShortID, amount uint64) *avax.TransferableOutput {
+		return &avax.TransferableOutput{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+			},
+		}
+	}
+	baseTx := txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    avalancheNetworkID,
+		BlockchainID: pChainID,
+		Outs:         []*avax.TransferableOutput{stakeOut(owner, 1)},
+	}}
+
+	// the validator stakes for the owner and another address, and rewards the owner
+	validatorNodeID := ids.GenerateTestNodeID()
+	validatorTx, err := txs.NewSigned(&txs.AddPermissionlessValidatorTx{
+		BaseTx:                baseTx,
+		Validator:             txs.Validator{NodeID: validatorNodeID},
+		Subnet:                ids.Empty,
+		Signer:                &signer.Empty{},
+		StakeOuts:             []*avax.TransferableOutput{stakeOut(owner, 2_000), stakeOut(other, 500)},
+		ValidatorRewardsOwner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+		DelegatorRewardsOwner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+	}, txs.Codec, nil)
+	require.NoError(t, err)
+
+	// the delegator stakes for the owner to a validator rewarding another address
+	delegatedNodeID := ids.GenerateTestNodeID()
+	delegatorTx, err := txs.NewSigned(&txs.AddPermissionlessDelegatorTx{
+		BaseTx:                 baseTx,
+		Validator:              txs.Validator{NodeID: delegatedNodeID},
+		Subnet:                 ids.Empty,
+		StakeOuts:              []*avax.TransferableOutput{stakeOut(owner, 1_000)},
+		DelegationRewardsOwner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+	}, txs.Codec, nil)
+	require.NoError(t, err)
+
+	// the stake co-owned by the owner, and its stake of subnet tokens, are returned
+	// by GetStake but not listed
+	coOwnedOut := stakeOut(owner, 700)
+	coOwnedOut.Out.(*secp256k1fx.TransferOutput).Addrs = []ids.ShortID{owner, other}
+	subnetOut := stakeOut(owner, 300)
+	subnetOut.Asset = avax.Asset{ID: ids.GenerateTestID()}
+
+	var stakedOutputs [][]byte
+	for _, out := range []*avax.TransferableOutput{stakeOut(owner, 2_000), stakeOut(owner, 1_000), coOwnedOut, subnetOut} {
+		outBytes, err := backend.codec.Marshal(backend.codecVersion, out)
+		require.NoError(t, err)
+		stakedOutputs = append(stakedOutputs, outBytes)
+	}
+
+	validatorReward, delegatorReward, delegatorCount := uint64(100), uint64(10), uint64(1)
+	validators := []platformvm.ClientPermissionlessValidator{
+		{
+			ClientStaker:          platformvm.ClientStaker{TxID: validatorTx.ID(), NodeID: validatorNodeID, StartTime: 1, EndTime: 2},
+			ValidationRewardOwner: &platformvm.ClientOwner{Addresses: []ids.ShortID{owner}},
+			PotentialReward:       &validatorReward,
+		},
+		{
+			ClientStaker:          platformvm.ClientStaker{TxID: ids.GenerateTestID(), NodeID: delegatedNodeID},
+			ValidationRewardOwner: &platformvm.ClientOwner{Addresses: []ids.ShortID{other}},
+			DelegatorCount:        &delegatorCount,
+		},
+	}
+	delegatedValidator := validators[1]
+	delegatedValidator.Delegators = []platformvm.ClientDelegator{
+		{
+			ClientStaker:    platformvm.ClientStaker{TxID: delegatorTx.ID(), NodeID: delegatedNodeID, StartTime: 3, EndTime: 4},
+			RewardOwner:     &platformvm.ClientOwner{Addresses: []ids.ShortID{owner}},
+			PotentialReward: &delegatorReward,
+		},
+	}
+
+	pChainMock.EXPECT().GetHeight(ctx).Return(blockHeight, nil).Times(2)
+	pChainMock.EXPECT().GetStake(ctx, []ids.ShortID{owner}, false).Return(nil, stakedOutputs, nil)
+	pChainMock.EXPECT().GetCurrentValidators(ctx, ids.Empty, nil).Return(validators, nil)
+	pChainMock.EXPECT().GetTx(gomock.Any(), validatorTx.ID()).Return(validatorTx.Bytes(), nil)
+	pChainMock.EXPECT().GetCurrentValidators(gomock.Any(), ids.Empty, []ids.NodeID{delegatedNodeID}).
+		Return([]platformvm.ClientPermissionlessValidator{delegatedValidator}, nil)
+	pChainMock.EXPECT().GetTx(gomock.Any(), delegatorTx.ID()).Return(delegatorTx.Bytes(), nil)
+
+	stakedRequest := &types.AccountCoinsRequest{
+		NetworkIdentifier: pChainNetworkIdentifier,
+		AccountIdentifier: &types.AccountIdentifier{
+			Address:    ownerStr,
+			SubAccount: &types.SubAccountIdentifier{Address: pmapper.SubAccountTypeStaked},
+		},
+	}
+	resp, terr := backend.AccountCoins(ctx, stakedRequest)
+	require.Nil(t, terr)
+
+	// stake outputs are indexed after the outputs of the staking tx
+	validatorUTXOID := avax.UTXOID{TxID: validatorTx.ID(), OutputIndex: 1}
+	delegatorUTXOID := avax.UTXOID{TxID: delegatorTx.ID(), OutputIndex: 1}
+	expectedCoins := common.SortUnique([]*types.Coin{
+		{
+			CoinIdentifier: &types.CoinIdentifier{Identifier: validatorUTXOID.String()},
+			Amount: &types.Amount{
+				Value:    "2000",
+				Currency: mapper.AtomicAvaxCurrency,
+				Metadata: map[string]interface{}{
+					pmapper.MetadataStakingTxID:      validatorTx.ID().String(),
+					pmapper.MetadataStakerType:       pmapper.StakerTypeValidator,
+					pmapper.MetadataValidatorNodeID:  validatorNodeID.String(),
+					pmapper.MetadataStakingStartTime: uint64(1),
+					pmapper.MetadataStakingEndTime:   uint64(2),
+					pmapper.MetadataPotentialReward:  "100",
+					pmapper.MetadataRewardOwner:      []string{ownerStr},
+				},
+			},
+		},
+		{
+			CoinIdentifier: &types.CoinIdentifier{Identifier: delegatorUTXOID.String()},
+			Amount: &types.Amount{
+				Value:    "1000",
+				Currency: mapper.AtomicAvaxCurrency,
+				Metadata: map[string]interface{}{
+					pmapper.MetadataStakingTxID:      delegatorTx.ID().String(),
+					pmapper.MetadataStakerType:       pmapper.StakerTypeDelegator,
+					pmapper.MetadataValidatorNodeID:  delegatedNodeID.String(),
+					pmapper.MetadataStakingStartTime: uint64(3),
+					pmapper.MetadataStakingEndTime:   uint64(4),
+					pmapper.MetadataPotentialReward:  "10",
+					pmapper.MetadataRewardOwner:      []string{ownerStr},
+				},
+			},
+		},
+	})
+	require.Equal(t, &types.AccountCoinsResponse{
+		BlockIdentifier: &types.BlockIdentifier{
+			Index: int64(blockHeight),
+			Hash:  parsedBlock.BlockID.String(),
+		},
+		Coins: expectedCoins,
+	}, resp)
+
+	t.Run("stake lookup is bounded", func(t *testing.T) {
+		// the delegated validator only stakes for another address
+		otherOwner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{other}}
+		otherValidatorTx, err := txs.NewSigned(&txs.AddPermissionlessValidatorTx{
+			BaseTx:                baseTx,
+			Validator:             txs.Validator{NodeID: delegatedNodeID},
+			Subnet:                ids.Empty,
+			Signer:                &signer.Empty{},
+			StakeOuts:             []*avax.TransferableOutput{stakeOut(other, 500)},
+			ValidatorRewardsOwner: otherOwner,
+			DelegatorRewardsOwner: otherOwner,
+		}, txs.Codec, nil)
+		require.NoError(t, err)
+
+		// and has more delegators not rewarding the owner than the lookup may list
+		crowdedValidators := []platformvm.ClientPermissionlessValidator{validators[0], validators[1]}
+		crowdedValidators[1].TxID = otherValidatorTx.ID()
+		crowdedValidator := crowdedValidators[1]
+		crowdedValidator.Delegators = make([]platformvm.ClientDelegator, MaxStakeLookupRequests)
+		for i := range crowdedValidator.Delegators {
+			crowdedValidator.Delegators[i] = platformvm.ClientDelegator{
+				ClientStaker: platformvm.ClientStaker{TxID: ids.GenerateTestID(), NodeID: delegatedNodeID},
+				RewardOwner:  &platformvm.ClientOwner{Addresses: []ids.ShortID{other}},
+			}
+		}
+
+		pChainMock.EXPECT().GetHeight(ctx).Return(blockHeight, nil)
+		pChainMock.EXPECT().GetStake(ctx, []ids.ShortID{owner}, false).Return(nil, stakedOutputs, nil)
+		pChainMock.EXPECT().GetCurrentValidators(ctx, ids.Empty, nil).Return(crowdedValidators, nil)
+		pChainMock.EXPECT().GetTx(gomock.Any(), validatorTx.ID()).Return(validatorTx.Bytes(), nil)
+		// the delegators of every validator are listed by a single request
+		pChainMock.EXPECT().GetCurrentValidators(gomock.Any(), ids.Empty, []ids.NodeID{delegatedNodeID}).
+			Return([]platformvm.ClientPermissionlessValidator{crowdedValidator}, nil)
+		pChainMock.EXPECT().GetTx(gomock.Any(), otherValidatorTx.ID()).Return(otherValidatorTx.Bytes(), nil)
+
+		_, terr := backend.AccountCoins(ctx, stakedRequest)
+		require.Equal(t, service.ErrNotSupported.Code, terr.Code)
+		require.Contains(t, terr.Details["error"], "too many requests")
+	})
+}
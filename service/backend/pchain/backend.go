@@ -1,11 +1,17 @@
 package pchain
 
 import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/coinbase/rosetta-sdk-go/types"
 
 	"github.com/ava-labs/avalanche-rosetta/client"
@@ -17,6 +23,14 @@ import (
 	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 )
 
+const (
+	// dependencyCacheSize is the number of dependency txs, along with their reward
+	// utxos, kept in memory to serve /block and /block/transaction
+	dependencyCacheSize = 4096
+	// defaultFetchConcurrency is the default number of dependency txs fetched concurrently
+	defaultFetchConcurrency = 16
+)
+
 var (
 	_ service.ConstructionBackend = &Backend{}
 	_ service.NetworkBackend      = &Backend{}
@@ -24,6 +38,11 @@ var (
 	_ service.BlockBackend        = &Backend{}
 )
 
+// nodeIDClient fetches the node ID and BLS signer of a node
+type nodeIDClient interface {
+	GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, error)
+}
+
 type Backend struct {
 	genesisHandler
 	networkID          *types.NetworkIdentifier
@@ -38,6 +57,11 @@ type Backend struct {
 	txParserCfg        pmapper.TxParserConfig
 	upgradeConfig      upgrade.Config
 	feeConfig          genesis.TxFeeConfig
+	syncStatus         *service.SyncStatusTracker
+	dependencyCache    *cache.LRU[ids.ID, *pmapper.SingleTxDependency]
+	fetchConcurrency   int
+	newNodeIDClient    func(uri string) nodeIDClient
+	signerNodeURLs     map[string]struct{}
 }
 
 // NewBackend creates a P-chain service backend
@@ -79,11 +103,35 @@ func NewBackend(
 			AvaxAssetID:    avaxAssetID,
 			PChainClient:   pClient,
 		},
-		upgradeConfig: upgradeConfig,
-		feeConfig:     feeConfig,
+		upgradeConfig:    upgradeConfig,
+		feeConfig:        feeConfig,
+		syncStatus:       service.NewSyncStatusTracker(service.SyncStatusConfig{}),
+		dependencyCache:  &cache.LRU[ids.ID, *pmapper.SingleTxDependency]{Size: dependencyCacheSize},
+		fetchConcurrency: defaultFetchConcurrency,
+		newNodeIDClient: func(uri string) nodeIDClient {
+			return info.NewClient(uri)
+		},
 	}, nil
 }
 
+// SetFetchConcurrency configures the number of dependency txs fetched concurrently
+// from the node by /block and /block/transaction. It is left unchanged if [concurrency]
+// is not positive.
+func (b *Backend) SetFetchConcurrency(concurrency int) {
+	if concurrency > 0 {
+		b.fetchConcurrency = concurrency
+	}
+}
+
+// SetSignerNodeURLs configures the URLs of the nodes, other than the local one,
+// that the signer_node staking option may query
+func (b *Backend) SetSignerNodeURLs(urls []string) {
+	b.signerNodeURLs = make(map[string]struct{}, len(urls))
+	for _, url := range urls {
+		b.signerNodeURLs[url] = struct{}{}
+	}
+}
+
 // ShouldHandleRequest returns whether a given request should be handled by this backend
 func (*Backend) ShouldHandleRequest(req interface{}) bool {
 	switch r := req.(type) {
@@ -113,6 +161,8 @@ func (*Backend) ShouldHandleRequest(req interface{}) bool {
 		return isPChain(r.NetworkIdentifier)
 	case *types.NetworkRequest:
 		return isPChain(r.NetworkIdentifier)
+	case *service.TxStatusRequest:
+		return isPChain(r.NetworkIdentifier)
 	}
 
 	return false
@@ -2,16 +2,21 @@ package pchain
 
 import (
 	"context"
+	"math/big"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/ava-labs/avalanche-rosetta/mapper"
 	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/pchain/indexer"
 
 	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 )
@@ -77,7 +82,7 @@ func (b *Backend) Block(ctx context.Context, request *types.BlockRequest) (*type
 			return nil, service.WrapError(service.ErrInternalError, err)
 		}
 
-		rosettaTxs, err := pmapper.ParseRosettaTxs(b.txParserCfg, block.Txs, blkDeps)
+		rosettaTxs, err := pmapper.ParseRosettaTxs(b.blockTxParserCfg(block.Timestamp), block.Txs, blkDeps)
 		if err != nil {
 			return nil, service.WrapError(service.ErrInternalError, err)
 		}
@@ -92,7 +97,7 @@ func (b *Backend) Block(ctx context.Context, request *types.BlockRequest) (*type
 		}
 		blkTime = block.Timestamp
 		rTxs = rosettaTxs
-		metadata = nil
+		metadata = blockMetadata(block, rosettaTxs)
 	}
 
 	resp := &types.BlockResponse{
@@ -107,11 +112,49 @@ func (b *Backend) Block(ctx context.Context, request *types.BlockRequest) (*type
 	return resp, nil
 }
 
+// blockMetadata returns the metadata of [block]: its platformvm type, the fee burned
+// by its transactions [rosettaTxs] and the proposervm block wrapping it, if any
+func blockMetadata(block *indexer.ParsedBlock, rosettaTxs []*types.Transaction) map[string]interface{} {
+	burnedFee := new(big.Int)
+	for _, rTx := range rosettaTxs {
+		if fee, ok := rTx.Metadata[mapper.MetadataTxFee].(*types.Amount); ok {
+			if value, ok := new(big.Int).SetString(fee.Value, 10); ok {
+				burnedFee.Add(burnedFee, value)
+			}
+		}
+	}
+
+	metadata := map[string]interface{}{
+		pmapper.MetadataBlockType: block.BlockType,
+		pmapper.MetadataBurnedFee: mapper.AtomicAvaxAmount(burnedFee),
+	}
+	if block.Proposer != nil {
+		metadata[pmapper.MetadataProposerBlockID] = block.Proposer.BlockID.String()
+		// option blocks are not signed by a proposer
+		if block.Proposer.NodeID != ids.EmptyNodeID {
+			metadata[pmapper.MetadataProposerNodeID] = block.Proposer.NodeID.String()
+			metadata[pmapper.MetadataPChainHeight] = block.Proposer.PChainHeight
+		}
+	}
+	return metadata
+}
+
+// blockTxParserCfg returns the config parsing the txs of a block issued at [blkTime],
+// in milliseconds. The gas used by txs is only reported once dynamic fees are active.
+func (b *Backend) blockTxParserCfg(blkTime int64) pmapper.TxParserConfig {
+	cfg := b.txParserCfg
+	if b.upgradeConfig.IsEtnaActivated(time.UnixMilli(blkTime)) {
+		cfg.GasWeights = b.feeConfig.DynamicFeeConfig.Weights
+	}
+	return cfg
+}
+
 // BlockTransaction implements the /block/transaction endpoint.
 func (b *Backend) BlockTransaction(ctx context.Context, request *types.BlockTransactionRequest) (*types.BlockTransactionResponse, *types.Error) {
 	var (
 		targetTxs     []*txs.Tx
 		dependencyTxs pmapper.BlockTxDependencies
+		parserCfg     = b.txParserCfg
 	)
 
 	isGenesisReq, err := b.isGenesisBlockRequest(request.BlockIdentifier.Index, request.BlockIdentifier.Hash)
@@ -134,16 +177,28 @@ func (b *Backend) BlockTransaction(ctx context.Context, request *types.BlockTran
 		if err != nil {
 			return nil, service.WrapError(service.ErrClientError, err)
 		}
-		deps, err := b.fetchBlkDependencies(ctx, block.Txs)
+
+		// Only the requested tx and its own dependencies are fetched and parsed
+		for _, tx := range block.Txs {
+			if tx.ID().String() == request.TransactionIdentifier.Hash {
+				targetTxs = []*txs.Tx{tx}
+				break
+			}
+		}
+		if len(targetTxs) == 0 {
+			return nil, service.ErrTransactionNotFound
+		}
+
+		deps, err := b.fetchBlkDependencies(ctx, targetTxs)
 		if err != nil {
 			return nil, service.WrapError(service.ErrInternalError, err)
 		}
 
-		targetTxs = block.Txs
 		dependencyTxs = deps
+		parserCfg = b.blockTxParserCfg(block.Timestamp)
 	}
 
-	rosettaTxs, err := pmapper.ParseRosettaTxs(b.txParserCfg, targetTxs, dependencyTxs)
+	rosettaTxs, err := pmapper.ParseRosettaTxs(parserCfg, targetTxs, dependencyTxs)
 	if err != nil {
 		return nil, service.WrapError(service.ErrInternalError, err)
 	}
@@ -160,19 +215,29 @@ func (b *Backend) BlockTransaction(ctx context.Context, request *types.BlockTran
 
 func (b *Backend) fetchBlkDependencies(ctx context.Context, txs []*txs.Tx) (pmapper.BlockTxDependencies, error) {
 	blockDeps := make(pmapper.BlockTxDependencies)
-	depsTxIDs := []ids.ID{}
+	depsTxIDs := set.Set[ids.ID]{}
 	for _, tx := range txs {
 		inputTxsIds, err := pmapper.GetTxDependenciesIDs(tx.Unsigned)
 		if err != nil {
 			return nil, err
 		}
-		depsTxIDs = append(depsTxIDs, inputTxsIds...)
+		depsTxIDs.Add(inputTxsIds...)
 	}
 
-	dependencyTxChan := make(chan *pmapper.SingleTxDependency, len(depsTxIDs))
+	dependencyTxChan := make(chan *pmapper.SingleTxDependency, depsTxIDs.Len())
 	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(b.fetchConcurrency)
+
+	for txID := range depsTxIDs {
+		// A new dependency is returned for each block, as it lazily caches its utxos
+		if dep, ok := b.dependencyCache.Get(txID); ok {
+			dependencyTxChan <- &pmapper.SingleTxDependency{
+				Tx:          dep.Tx,
+				RewardUTXOs: dep.RewardUTXOs,
+			}
+			continue
+		}
 
-	for _, txID := range depsTxIDs {
 		txID := txID
 		eg.Go(func() error {
 			return b.fetchDependencyTx(ctx, txID, dependencyTxChan)
@@ -196,6 +261,7 @@ func (b *Backend) fetchDependencyTx(ctx context.Context, txID ids.ID, out chan *
 	if txID == ids.Empty {
 		allocationTx, err := b.buildGenesisAllocationTx()
 		if allocationTx != nil {
+			b.dependencyCache.Put(txID, &pmapper.SingleTxDependency{Tx: allocationTx})
 			out <- &pmapper.SingleTxDependency{
 				Tx: allocationTx,
 			}
@@ -230,6 +296,14 @@ func (b *Backend) fetchDependencyTx(ctx context.Context, txID ids.ID, out chan *
 		}
 		utxos = append(utxos, &utxo)
 	}
+	// Staker txs are only rewarded at the end of their staking period, so they
+	// are not cached until their reward utxos are known
+	if len(utxos) > 0 || !isStakerTx(tx.Unsigned) {
+		b.dependencyCache.Put(txID, &pmapper.SingleTxDependency{
+			Tx:          tx,
+			RewardUTXOs: utxos,
+		})
+	}
 	out <- &pmapper.SingleTxDependency{
 		Tx:          tx,
 		RewardUTXOs: utxos,
@@ -237,3 +311,14 @@ func (b *Backend) fetchDependencyTx(ctx context.Context, txID ids.ID, out chan *
 
 	return nil
 }
+
+// isStakerTx returns true if [tx] adds a validator or a delegator to the primary
+// network or to a permissionless subnet, which may be rewarded
+func isStakerTx(tx txs.UnsignedTx) bool {
+	switch tx.(type) {
+	case *txs.AddValidatorTx, *txs.AddDelegatorTx, *txs.AddPermissionlessValidatorTx, *txs.AddPermissionlessDelegatorTx:
+		return true
+	default:
+		return false
+	}
+}
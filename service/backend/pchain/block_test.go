@@ -2,6 +2,7 @@ package pchain
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/api"
@@ -18,9 +19,11 @@ import (
 
 	"github.com/ava-labs/avalanche-rosetta/client"
 	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
 	"github.com/ava-labs/avalanche-rosetta/service"
 	"github.com/ava-labs/avalanche-rosetta/service/backend/pchain/indexer"
 
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
 	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
 	avatypes "github.com/ava-labs/avalanchego/vms/types"
 )
@@ -109,6 +112,40 @@ func TestFetchBlkDependencies(t *testing.T) {
 	require.Equal(t, ids.Empty, deps[genesisTxID].Tx.ID())
 	require.NotEqual(t, ids.Empty, deps[nonGenesisTxID].Tx.ID())
 	require.Equal(t, signedImportTx, deps[nonGenesisTxID].Tx)
+
+	// dependencies are served from the cache once fetched
+	deps, err = backend.fetchBlkDependencies(ctx, []*txs.Tx{tx})
+	require.NoError(t, err)
+
+	require.Len(t, deps, 2)
+	require.Equal(t, ids.Empty, deps[genesisTxID].Tx.ID())
+	require.Equal(t, signedImportTx, deps[nonGenesisTxID].Tx)
+
+	t.Run("block transaction only fetches its own dependencies", func(t *testing.T) {
+		blkID := ids.GenerateTestID()
+		mockIndexerParser.EXPECT().ParseNonGenesisBlock(ctx, blkID.String(), uint64(10)).Return(&indexer.ParsedBlock{
+			BlockID: blkID,
+			Height:  10,
+			Txs:     []*txs.Tx{tx, signedImportTx},
+		}, nil).Times(2)
+		mockPClient.EXPECT().GetBlockchainID(ctx, constants.CChain.String()).Return(cChainID, nil).AnyTimes()
+		mockPClient.EXPECT().GetBlockchainID(ctx, constants.XChain.String()).Return(ids.GenerateTestID(), nil).AnyTimes()
+
+		resp, terr := backend.BlockTransaction(ctx, &types.BlockTransactionRequest{
+			NetworkIdentifier:     networkIdentifier,
+			BlockIdentifier:       &types.BlockIdentifier{Index: 10, Hash: blkID.String()},
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: signedImportTx.ID().String()},
+		})
+		require.Nil(t, terr)
+		require.Equal(t, signedImportTx.ID().String(), resp.Transaction.TransactionIdentifier.Hash)
+
+		_, terr = backend.BlockTransaction(ctx, &types.BlockTransactionRequest{
+			NetworkIdentifier:     networkIdentifier,
+			BlockIdentifier:       &types.BlockIdentifier{Index: 10, Hash: blkID.String()},
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: ids.GenerateTestID().String()},
+		})
+		require.Equal(t, service.ErrTransactionNotFound, terr)
+	})
 }
 
 func makeImportTx(t *testing.T, networkID uint32) (*txs.Tx, error) {
@@ -140,3 +177,54 @@ func makeImportTx(t *testing.T, networkID uint32) (*txs.Tx, error) {
 	signedImportTx.Creds = []verify.Verifiable{}
 	return signedImportTx, err
 }
+
+func TestBlockMetadata(t *testing.T) {
+	var (
+		proposerBlkID = ids.GenerateTestID()
+		proposerID    = ids.GenerateTestNodeID()
+		rosettaTxs    = []*types.Transaction{
+			{Metadata: map[string]interface{}{mapper.MetadataTxFee: mapper.AtomicAvaxAmount(big.NewInt(1_000_000))}},
+			// reward txs burn no fee
+			{Metadata: map[string]interface{}{pmapper.MetadataTxType: pmapper.OpRewardValidator}},
+			{Metadata: map[string]interface{}{mapper.MetadataTxFee: mapper.AtomicAvaxAmount(big.NewInt(500_000))}},
+		}
+	)
+
+	t.Run("proposer block", func(t *testing.T) {
+		blk := &indexer.ParsedBlock{
+			BlockType: indexer.BlockTypeBanffStandard,
+			Proposer: &indexer.ProposerBlock{
+				BlockID:      proposerBlkID,
+				NodeID:       proposerID,
+				PChainHeight: 42,
+			},
+		}
+		require.Equal(t, map[string]interface{}{
+			pmapper.MetadataBlockType:       indexer.BlockTypeBanffStandard,
+			pmapper.MetadataBurnedFee:       mapper.AtomicAvaxAmount(big.NewInt(1_500_000)),
+			pmapper.MetadataProposerBlockID: proposerBlkID.String(),
+			pmapper.MetadataProposerNodeID:  proposerID.String(),
+			pmapper.MetadataPChainHeight:    uint64(42),
+		}, blockMetadata(blk, rosettaTxs))
+	})
+
+	t.Run("option block", func(t *testing.T) {
+		blk := &indexer.ParsedBlock{
+			BlockType: indexer.BlockTypeBanffCommit,
+			Proposer:  &indexer.ProposerBlock{BlockID: proposerBlkID},
+		}
+		require.Equal(t, map[string]interface{}{
+			pmapper.MetadataBlockType:       indexer.BlockTypeBanffCommit,
+			pmapper.MetadataBurnedFee:       mapper.AtomicAvaxAmount(big.NewInt(0)),
+			pmapper.MetadataProposerBlockID: proposerBlkID.String(),
+		}, blockMetadata(blk, nil))
+	})
+
+	t.Run("pre-proposervm block", func(t *testing.T) {
+		blk := &indexer.ParsedBlock{BlockType: indexer.BlockTypeApricotAtomic}
+		require.Equal(t, map[string]interface{}{
+			pmapper.MetadataBlockType: indexer.BlockTypeApricotAtomic,
+			pmapper.MetadataBurnedFee: mapper.AtomicAvaxAmount(big.NewInt(1_500_000)),
+		}, blockMetadata(blk, rosettaTxs))
+	})
+}
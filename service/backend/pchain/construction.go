@@ -9,8 +9,9 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/rpc"
-	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/coinbase/rosetta-sdk-go/types"
 
@@ -24,8 +25,11 @@ import (
 )
 
 var (
-	errUnknownTxType = errors.New("unknown tx type")
-	errUndecodableTx = errors.New("undecodable transaction")
+	errUndecodableTx        = errors.New("undecodable transaction")
+	errSignerNodeWithSigner = errors.New("signer node can't be set along with a BLS public key or proof of possession")
+	errNodeWithoutSigner    = errors.New("signer node has no BLS key")
+	errSignerNodeMismatch   = errors.New("node id doesn't match signer node")
+	errSignerNodeNotAllowed = errors.New("signer node is neither local nor a configured signer node url")
 )
 
 // ConstructionDerive implements /construction/derive endpoint for P-chain
@@ -48,6 +52,11 @@ func (*Backend) ConstructionPreprocess(
 		reqMetadata = make(map[string]interface{})
 	}
 
+	var txOptions pmapper.TxOptions
+	if err := mapper.UnmarshalJSONMap(reqMetadata, &txOptions); err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
 	reqMetadata[pmapper.MetadataOpType] = matches[0].Operations[0].Type
 	reqMetadata[pmapper.MetadataMatches] = matches
 
@@ -94,6 +103,12 @@ func (b *Backend) ConstructionMetadata(
 		return nil, service.WrapError(service.ErrInternalError, err)
 	}
 
+	var txOptions pmapper.TxOptions
+	if err := mapper.UnmarshalJSONMap(req.Options, &txOptions); err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+	metadata.Memo = txOptions.Memo
+
 	// Suggested fee calculation
 	tx, _, err := pmapper.BuildTx(
 		opMetadata.Type,
@@ -173,8 +188,8 @@ func (b *Backend) buildExportMetadata(
 	return &pmapper.Metadata{ExportMetadata: exportMetadata}, nil
 }
 
-func (*Backend) buildStakingMetadata(
-	_ context.Context,
+func (b *Backend) buildStakingMetadata(
+	ctx context.Context,
 	options map[string]interface{},
 ) (*pmapper.Metadata, error) {
 	var preprocessOptions pmapper.StakingOptions
@@ -182,6 +197,19 @@ func (*Backend) buildStakingMetadata(
 		return nil, err
 	}
 
+	if preprocessOptions.SignerNode != "" {
+		if err := b.fillNodeSigner(ctx, &preprocessOptions); err != nil {
+			return nil, err
+		}
+	}
+	// A tx with an invalid proof of possession is only rejected once its fee is burned
+	if preprocessOptions.BLSPublicKey != "" || preprocessOptions.BLSProofOfPossession != "" {
+		_, err := pmapper.ParseProofOfPossession(preprocessOptions.BLSPublicKey, preprocessOptions.BLSProofOfPossession)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	stakingMetadata := &pmapper.Metadata{
 		StakingMetadata: &pmapper.StakingMetadata{
 			NodeID:                  preprocessOptions.NodeID,
@@ -201,6 +229,45 @@ func (*Backend) buildStakingMetadata(
 	return stakingMetadata, nil
 }
 
+// fillNodeSigner sets the BLS public key and proof of possession of [options] to
+// the ones of its signer node, which must be the node being staked for. Only
+// the local node and the configured signer node URLs are queried.
+func (b *Backend) fillNodeSigner(ctx context.Context, options *pmapper.StakingOptions) error {
+	if options.BLSPublicKey != "" || options.BLSProofOfPossession != "" {
+		return errSignerNodeWithSigner
+	}
+
+	var nodeClient nodeIDClient = b.pClient
+	if options.SignerNode != pmapper.SignerNodeLocal {
+		if _, ok := b.signerNodeURLs[options.SignerNode]; !ok {
+			return fmt.Errorf("%w: %s", errSignerNodeNotAllowed, options.SignerNode)
+		}
+		nodeClient = b.newNodeIDClient(options.SignerNode)
+	}
+	nodeID, pop, err := nodeClient.GetNodeID(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get signer of node %s: %w", options.SignerNode, err)
+	}
+	if pop == nil {
+		return fmt.Errorf("%w: %s", errNodeWithoutSigner, nodeID)
+	}
+
+	switch options.NodeID {
+	case "":
+		options.NodeID = nodeID.String()
+	case nodeID.String():
+	default:
+		return fmt.Errorf("%w: expected %s, signer node is %s", errSignerNodeMismatch, options.NodeID, nodeID)
+	}
+
+	options.BLSPublicKey, err = formatting.Encode(formatting.HexNC, pop.PublicKey[:])
+	if err != nil {
+		return err
+	}
+	options.BLSProofOfPossession, err = formatting.Encode(formatting.HexNC, pop.ProofOfPossession[:])
+	return err
+}
+
 // ConstructionPayloads implements /construction/payloads endpoint for P-chain
 func (b *Backend) ConstructionPayloads(_ context.Context, req *types.ConstructionPayloadsRequest) (*types.ConstructionPayloadsResponse, *types.Error) {
 	builder := pTxBuilder{
@@ -229,6 +296,9 @@ func (b *Backend) ConstructionParse(_ context.Context, req *types.ConstructionPa
 		chainIDs:    chainIDs,
 		avaxAssetID: b.avaxAssetID,
 	}
+	if b.upgradeConfig.IsEtnaActivated(time.Now()) {
+		txParser.gasWeights = b.feeConfig.DynamicFeeConfig.Weights
+	}
 
 	return common.Parse(txParser, rosettaTx, req.Signed)
 }
@@ -250,7 +320,7 @@ func (*Backend) CombineTx(tx common.AvaxTx, signatures []*types.Signature) (comm
 		return nil, service.WrapError(service.ErrInvalidInput, "invalid transaction")
 	}
 
-	ins, err := getTxInputs(pTx.Tx.Unsigned)
+	ins, err := pmapper.SignedInputs(pTx.Tx.Unsigned)
 	if err != nil {
 		return nil, service.WrapError(service.ErrInvalidInput, err)
 	}
@@ -313,56 +383,6 @@ func (b *Backend) NewStaticFeeCalculator(timestamp time.Time) txfee.Calculator {
 	return txfee.NewStaticCalculator(feeConfig)
 }
 
-// getTxInputs fetches tx inputs based on the tx type.
-func getTxInputs(
-	unsignedTx txs.UnsignedTx,
-) ([]*avax.TransferableInput, error) {
-	// TODO: Move to using [txs.Visitor] from AvalancheGo
-	// Ref: https://github.com/ava-labs/avalanchego/blob/master/vms/platformvm/txs/visitor.go
-	switch utx := unsignedTx.(type) {
-	case *txs.AddValidatorTx:
-		return utx.Ins, nil
-	case *txs.AddSubnetValidatorTx:
-		return utx.Ins, nil
-	case *txs.AddDelegatorTx:
-		return utx.Ins, nil
-	case *txs.CreateChainTx:
-		return utx.Ins, nil
-	case *txs.CreateSubnetTx:
-		return utx.Ins, nil
-	case *txs.ImportTx:
-		return utx.ImportedInputs, nil
-	case *txs.ExportTx:
-		return utx.Ins, nil
-	case *txs.AdvanceTimeTx:
-		return nil, nil
-	case *txs.RewardValidatorTx:
-		return nil, nil
-	case *txs.TransformSubnetTx:
-		return utx.Ins, nil
-	case *txs.AddPermissionlessValidatorTx:
-		return utx.Ins, nil
-	case *txs.AddPermissionlessDelegatorTx:
-		return utx.Ins, nil
-	case *txs.TransferSubnetOwnershipTx:
-		return utx.Ins, nil
-	case *txs.ConvertSubnetToL1Tx:
-		return utx.Ins, nil
-	case *txs.RegisterL1ValidatorTx:
-		return utx.Ins, nil
-	case *txs.IncreaseL1ValidatorBalanceTx:
-		return utx.Ins, nil
-	case *txs.SetL1ValidatorWeightTx:
-		return utx.Ins, nil
-	case *txs.DisableL1ValidatorTx:
-		return utx.Ins, nil
-	case *txs.BaseTx:
-		return utx.Ins, nil
-	default:
-		return nil, errUnknownTxType
-	}
-}
-
 // ConstructionHash implements /construction/hash endpoint for P-chain
 func (b *Backend) ConstructionHash(
 	_ context.Context,
@@ -389,6 +409,115 @@ func (b *Backend) ConstructionSubmit(
 	return common.SubmitTx(ctx, b, rosettaTx)
 }
 
+// ConstructionTxStatus returns the status of a P-chain transaction submitted to the node
+func (b *Backend) ConstructionTxStatus(
+	ctx context.Context,
+	req *service.TxStatusRequest,
+) (*service.TxStatus, *types.Error) {
+	txID, err := ids.FromString(req.TransactionIdentifier.Hash)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	resp, err := b.pClient.GetTxStatus(ctx, txID)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+
+	txStatus := &service.TxStatus{TransactionIdentifier: req.TransactionIdentifier}
+	switch resp.Status {
+	case status.Committed:
+		txStatus.Status = service.TxStatusAccepted
+	case status.Processing:
+		txStatus.Status = service.TxStatusPending
+	case status.Dropped:
+		txStatus.Status = service.TxStatusDropped
+		txStatus.Reason = resp.Reason
+	case status.Aborted:
+		txStatus.Status = service.TxStatusDropped
+		txStatus.Reason = "transaction was aborted"
+	default:
+		// the transaction may not have reached the node yet
+		txStatus.Status = service.TxStatusPending
+		txStatus.Reason = "transaction is not known by the node"
+	}
+	return txStatus, nil
+}
+
+// ConstructionSimulate checks that the inputs of a P-chain transaction are still
+// unspent and that it burns a sufficient fee
+func (b *Backend) ConstructionSimulate(
+	ctx context.Context,
+	req *types.ConstructionParseRequest,
+) (*service.SimulationResult, *types.Error) {
+	parsed, terr := b.ConstructionParse(ctx, req)
+	if terr != nil {
+		return nil, terr
+	}
+
+	rosettaTx, err := b.parsePayloadTxFromString(req.Transaction)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+	pTx, ok := rosettaTx.Tx.(*pTx)
+	if !ok {
+		return nil, service.WrapError(service.ErrInvalidInput, "invalid transaction")
+	}
+
+	result := &service.SimulationResult{
+		Success:    true,
+		Operations: parsed.Operations,
+	}
+
+	ins, err := pmapper.SignedInputs(pTx.Tx.Unsigned)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+	addrs, err := common.SignerAddresses(rosettaTx)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+
+	// imported inputs are in the shared memory of the source chain
+	sourceChains := []constants.ChainIDAlias{constants.AnyChain}
+	if _, ok := pTx.Tx.Unsigned.(*txs.ImportTx); ok {
+		sourceChains = []constants.ChainIDAlias{constants.CChain, constants.XChain}
+	}
+
+	var utxos [][]byte
+	for _, addr := range addrs {
+		for _, sourceChain := range sourceChains {
+			addrUTXOs, err := b.getAccountUTXOs(ctx, addr, sourceChain)
+			if err != nil {
+				return nil, service.WrapError(service.ErrClientError, err)
+			}
+			utxos = append(utxos, addrUTXOs...)
+		}
+	}
+
+	missing, err := common.MissingInputs(b.codec, ins, utxos)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+	if len(missing) > 0 {
+		result.Success = false
+		result.Error = fmt.Sprintf("inputs %v are spent or do not exist", missing)
+		return result, nil
+	}
+
+	requiredFee, err := b.calculateFee(ctx, pTx.Tx)
+	if err != nil {
+		return nil, service.WrapError(service.ErrClientError, err)
+	}
+	burned, err := pmapper.BurnedFee(pTx.Tx.Unsigned, b.avaxAssetID)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, err)
+	}
+	common.CheckFee(result, burned, requiredFee)
+
+	return result, nil
+}
+
 // IssueTx broadcasts given transaction on P-chain
 func (b *Backend) IssueTx(ctx context.Context, txByte []byte, options ...rpc.Option) (ids.ID, error) {
 	return b.pClient.IssueTx(ctx, txByte, options...)
@@ -11,9 +11,13 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -45,6 +49,7 @@ var (
 	// Public Key (hex): 0327448e78ffa8cdb24cf19be0204ad954b1bdb4db8c51183534c1eecf2ebd094e
 	// Private Key: PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN
 	ewoqAccountP = &types.AccountIdentifier{Address: "P-fuji18jma8ppw3nhx5r4ap8clazz0dps7rv5u6wmu4t"}
+	ewoqAccountC = &types.AccountIdentifier{Address: "C-fuji18jma8ppw3nhx5r4ap8clazz0dps7rv5u6wmu4t"}
 
 	cChainID, _ = ids.FromString("yH8D7ThNJkxmtkuv2jgBa4P1Rn3Qpr4pPr7QYNfcdoS6k6HWp")
 	pChainID    = ids.Empty
@@ -122,6 +127,69 @@ func TestConstructionDerive(t *testing.T) {
 	})
 }
 
+func TestConstructionTxStatus(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	pChainMock := client.NewMockPChainClient(ctrl)
+	parserMock := indexer.NewMockParser(ctrl)
+	parserMock.EXPECT().GetGenesisBlock(ctx).Return(dummyGenesis, nil)
+	backend, err := NewBackend(
+		pChainMock,
+		parserMock,
+		avaxAssetID,
+		pChainNetworkIdentifier,
+		avalancheNetworkID,
+	)
+	require.NoError(t, err)
+
+	txID := ids.GenerateTestID()
+	req := &service.TxStatusRequest{
+		NetworkIdentifier:     pChainNetworkIdentifier,
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: txID.String()},
+	}
+	require.True(t, backend.ShouldHandleRequest(req))
+
+	tests := []struct {
+		name     string
+		response *platformvm.GetTxStatusResponse
+		status   string
+		reason   string
+	}{
+		{
+			name:     "committed",
+			response: &platformvm.GetTxStatusResponse{Status: status.Committed},
+			status:   service.TxStatusAccepted,
+		},
+		{
+			name:     "processing",
+			response: &platformvm.GetTxStatusResponse{Status: status.Processing},
+			status:   service.TxStatusPending,
+		},
+		{
+			name:     "dropped",
+			response: &platformvm.GetTxStatusResponse{Status: status.Dropped, Reason: "insufficient funds"},
+			status:   service.TxStatusDropped,
+			reason:   "insufficient funds",
+		},
+		{
+			name:     "unknown",
+			response: &platformvm.GetTxStatusResponse{Status: status.Unknown},
+			status:   service.TxStatusPending,
+			reason:   "transaction is not known by the node",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pChainMock.EXPECT().GetTxStatus(ctx, txID).Return(test.response, nil)
+
+			txStatus, terr := backend.ConstructionTxStatus(ctx, req)
+			require.Nil(t, terr)
+			require.Equal(t, test.status, txStatus.Status)
+			require.Equal(t, test.reason, txStatus.Reason)
+		})
+	}
+}
+
 func TestExportTxConstruction(t *testing.T) {
 	exportOperations := []*types.Operation{
 		{
@@ -234,6 +302,21 @@ func TestExportTxConstruction(t *testing.T) {
 		require.Equal(t, metadataOptions, resp.Options)
 	})
 
+	t.Run("preprocess endpoint rejects memos that are not hex encoded", func(t *testing.T) {
+		_, err := backend.ConstructionPreprocess(
+			ctx,
+			&types.ConstructionPreprocessRequest{
+				NetworkIdentifier: pChainNetworkIdentifier,
+				Operations:        exportOperations,
+				Metadata: map[string]interface{}{
+					"destination_chain": constants.CChain.String(),
+					"memo":              "deposit 42",
+				},
+			},
+		)
+		require.Equal(t, service.ErrInvalidInput.Code, err.Code)
+	})
+
 	t.Run("metadata endpoint", func(t *testing.T) {
 		shouldMockGetFeeState(clientMock)
 		clientMock.EXPECT().GetBlockchainID(ctx, constants.PChain.String()).Return(pChainID, nil)
@@ -281,6 +364,38 @@ func TestExportTxConstruction(t *testing.T) {
 		require.Equal(t, exportOperations, resp.Operations)
 	})
 
+	t.Run("simulate endpoint", func(t *testing.T) {
+		require := require.New(t)
+
+		addr, err := address.ParseToID(pAccountIdentifier.Address)
+		require.NoError(err)
+		req := &types.ConstructionParseRequest{
+			NetworkIdentifier: pChainNetworkIdentifier,
+			Transaction:       wrappedUnsignedExportTx,
+		}
+
+		// the input was spent
+		clientMock.EXPECT().GetAtomicUTXOs(ctx, []ids.ShortID{addr}, "", backend.getUTXOsPageSize, ids.ShortEmpty, ids.Empty).
+			Return([][]byte{}, addr, ids.Empty, nil)
+		result, terr := backend.ConstructionSimulate(ctx, req)
+		require.Nil(terr)
+		require.False(result.Success)
+		require.Contains(result.Error, coinID1)
+		require.Equal(exportOperations, result.Operations)
+
+		// the input is unspent but the fixture burns less than the current fee
+		utxoBytes := makeUtxoBytes(t, backend, coinID1, 1_000_000_000)
+		clientMock.EXPECT().GetAtomicUTXOs(ctx, []ids.ShortID{addr}, "", backend.getUTXOsPageSize, ids.ShortEmpty, ids.Empty).
+			Return([][]byte{utxoBytes}, addr, ids.Empty, nil)
+		shouldMockGetFeeState(clientMock)
+		result, terr = backend.ConstructionSimulate(ctx, req)
+		require.Nil(terr)
+		require.Equal(mapper.AtomicAvaxAmount(big.NewInt(1_000_000)), result.Fee)
+		require.Equal(mapper.AtomicAvaxAmount(big.NewInt(4_135_000)), result.RequiredFee)
+		require.False(result.Success)
+		require.Equal("transaction burns 1000000 nAVAX but the fee is 4135000 nAVAX", result.Error)
+	})
+
 	t.Run("combine endpoint", func(t *testing.T) {
 		resp, err := backend.ConstructionCombine(
 			ctx,
@@ -295,6 +410,60 @@ func TestExportTxConstruction(t *testing.T) {
 		require.Equal(t, wrappedSignedExportTx, resp.SignedTransaction)
 	})
 
+	t.Run("combine endpoint rejects mismatching signatures", func(t *testing.T) {
+		combine := func(signature *types.Signature) *types.Error {
+			_, err := backend.ConstructionCombine(
+				ctx,
+				&types.ConstructionCombineRequest{
+					NetworkIdentifier:   pChainNetworkIdentifier,
+					UnsignedTransaction: wrappedUnsignedExportTx,
+					Signatures:          []*types.Signature{signature},
+				},
+			)
+			return err
+		}
+
+		// signature produced by another account
+		otherSigner := *signatures[0]
+		otherSigner.SigningPayload = &types.SigningPayload{
+			AccountIdentifier: ewoqAccountP,
+			Bytes:             unsignedExportTxHash,
+			SignatureType:     types.EcdsaRecovery,
+		}
+		err := combine(&otherSigner)
+		require.Equal(t, service.ErrSignerMismatch.Code, err.Code)
+
+		// signature produced over another payload
+		otherPayload := *signatures[0]
+		otherPayload.SigningPayload = &types.SigningPayload{
+			AccountIdentifier: pAccountIdentifier,
+			Bytes:             unsignedExportTxHash[1:],
+			SignatureType:     types.EcdsaRecovery,
+		}
+		err = combine(&otherPayload)
+		require.Equal(t, service.ErrSigningPayloadMismatch.Code, err.Code)
+
+		// signature over the right payload, but not by an input owner
+		var ewoqKey secp256k1.PrivateKey
+		require.NoError(t, ewoqKey.UnmarshalText([]byte(`"PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN"`)))
+		ewoqSignature, serr := ewoqKey.SignHash(unsignedExportTxHash)
+		require.NoError(t, serr)
+		err = combine(&types.Signature{
+			SigningPayload: &types.SigningPayload{Bytes: unsignedExportTxHash},
+			SignatureType:  types.EcdsaRecovery,
+			Bytes:          ewoqSignature,
+		})
+		require.Equal(t, service.ErrSignerMismatch.Code, err.Code)
+
+		// truncated signature
+		err = combine(&types.Signature{
+			SigningPayload: signatures[0].SigningPayload,
+			SignatureType:  types.EcdsaRecovery,
+			Bytes:          signedExportTxSignature[1:],
+		})
+		require.Equal(t, service.ErrInvalidInput.Code, err.Code)
+	})
+
 	t.Run("parse endpoint (signed)", func(t *testing.T) {
 		resp, err := backend.ConstructionParse(
 			ctx,
@@ -344,7 +513,7 @@ func TestImportTxConstruction(t *testing.T) {
 			OperationIdentifier: &types.OperationIdentifier{Index: 0},
 			RelatedOperations:   nil,
 			Type:                pmapper.OpImportAvax,
-			Account:             cAccountIdentifier,
+			Account:             ewoqAccountC,
 			Amount:              mapper.AtomicAvaxAmount(big.NewInt(-1_000_000_000)),
 			CoinChange: &types.CoinChange{
 				CoinIdentifier: &types.CoinIdentifier{Identifier: coinID1},
@@ -388,7 +557,7 @@ func TestImportTxConstruction(t *testing.T) {
 		"blockchain_id":   pChainID.String(),
 	}
 
-	signers := []*types.AccountIdentifier{cAccountIdentifier}
+	signers := []*types.AccountIdentifier{ewoqAccountC}
 	importSigners := buildRosettaSignerJSON([]string{coinID1}, signers)
 
 	unsignedImportTx := "0x000000000011000000050000000000000000000000000000000000000000000000000000000000000000000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000007000000003b8b87c0000000000000000000000001000000015445cd01d75b4a06b6b41939193c0b1c5544490d00000000000000007fc93d85c6d62c5b2ac0b519c87010ea5294012d1e407030d6acd0021cac10d500000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000005000000003b9aca000000000100000000000000004ce8b27d"
@@ -398,22 +567,22 @@ func TestImportTxConstruction(t *testing.T) {
 
 	signingPayloads := []*types.SigningPayload{
 		{
-			AccountIdentifier: cAccountIdentifier,
+			AccountIdentifier: ewoqAccountC,
 			Bytes:             unsignedImportTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
 	}
 
-	signedImportTx := "0x000000000011000000050000000000000000000000000000000000000000000000000000000000000000000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000007000000003b8b87c0000000000000000000000001000000015445cd01d75b4a06b6b41939193c0b1c5544490d00000000000000007fc93d85c6d62c5b2ac0b519c87010ea5294012d1e407030d6acd0021cac10d500000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000005000000003b9aca0000000001000000000000000100000009000000017403e32bb967e71902a988b7da635b4bca2475eedbfd23176610a88162f3a92f20b61f2185825b04b7f8ee8c76427c8dc80eb6091f9e594ef259a59856e5401b018ac25b4e"
-	signedImportTxSignature, err := hex.DecodeString("7403e32bb967e71902a988b7da635b4bca2475eedbfd23176610a88162f3a92f20b61f2185825b04b7f8ee8c76427c8dc80eb6091f9e594ef259a59856e5401b01")
+	signedImportTx := "0x000000000011000000050000000000000000000000000000000000000000000000000000000000000000000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000007000000003b8b87c0000000000000000000000001000000015445cd01d75b4a06b6b41939193c0b1c5544490d00000000000000007fc93d85c6d62c5b2ac0b519c87010ea5294012d1e407030d6acd0021cac10d500000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000005000000003b9aca000000000100000000000000010000000900000001bec8652aafe356f8ddf735fcb7b5f05de975bce44b1f173f5101e1e4756fc79f08ab384078955c8ad6caf1498bfb79610603c5320b33a30107b0680be7366632001d24e933"
+	signedImportTxSignature, err := hex.DecodeString("bec8652aafe356f8ddf735fcb7b5f05de975bce44b1f173f5101e1e4756fc79f08ab384078955c8ad6caf1498bfb79610603c5320b33a30107b0680be736663200")
 	require.NoError(t, err)
-	signedImportTxHash := "byyEVU6RL7PQNSVT8qEnybWGV5BbBfJwFV6bEDV5mkymXRz62"
+	signedImportTxHash := "24NW1GZFGyg3qJWJnx6FvYEaHD4atfEMUKCSqPRVsjt39Nfu8M"
 
 	wrappedSignedImportTx := `{"tx":"` + signedImportTx + `","signers":` + importSigners + `}`
 
 	signatures := []*types.Signature{{
 		SigningPayload: &types.SigningPayload{
-			AccountIdentifier: cAccountIdentifier,
+			AccountIdentifier: ewoqAccountC,
 			Bytes:             unsignedImportTxHash,
 			SignatureType:     types.EcdsaRecovery,
 		},
@@ -655,10 +824,10 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 		},
 	}
 
-	signedTx := "0x0000000000190000000500000000000000000000000000000000000000000000000000000000000000000000000000000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000005000001d1a94a200000000001000000000000000077e1d5c6c289c49976f744749d54369d2129d7500000000062eb5de30000000062fdd2e3000001d1a94a200000000000000000000000000000000000000000000000000000000000000000000000001c90f8c7a0425d6b433fb1bd95b41ef76221cdd05d922247356912abfb14db1642ae410bd627052b023e1ea7fb49a909ff8df4c907d6d41db47fbe12834789c10572805e61d32d09411ccc3a1d10ea3a978496d6449eb802bc721fed1571ef251300e83bfd41573947b63d4ce631c8724d98b087d0f10ae05426f52e13656be277531fc459f82400e66822d514073bcb7b000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000007000001d1a94a2000000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c0000000b000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c0000000b000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c00030d4000000001000000090000000137d7f04741b3492cb9ad8dbeda64513e569a049a5e5fb5d9c53e27bdfa428acb5b526a45fb9de09b38f1d2f4bb7c0a35bfa753e46c5cc339c0d9a0d9ed0aa415019f1a796e"
-	signedTxSignature, err := hex.DecodeString("37d7f04741b3492cb9ad8dbeda64513e569a049a5e5fb5d9c53e27bdfa428acb5b526a45fb9de09b38f1d2f4bb7c0a35bfa753e46c5cc339c0d9a0d9ed0aa41501")
+	signedTx := "0x0000000000190000000500000000000000000000000000000000000000000000000000000000000000000000000000000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000005000001d1a94a200000000001000000000000000077e1d5c6c289c49976f744749d54369d2129d7500000000062eb5de30000000062fdd2e3000001d1a94a200000000000000000000000000000000000000000000000000000000000000000000000001c90f8c7a0425d6b433fb1bd95b41ef76221cdd05d922247356912abfb14db1642ae410bd627052b023e1ea7fb49a909ff8df4c907d6d41db47fbe12834789c10572805e61d32d09411ccc3a1d10ea3a978496d6449eb802bc721fed1571ef251300e83bfd41573947b63d4ce631c8724d98b087d0f10ae05426f52e13656be277531fc459f82400e66822d514073bcb7b000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa00000007000001d1a94a2000000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c0000000b000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c0000000b000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c00030d40000000010000000900000001a4ecae97964160e9addeb6bfdab4919d79ee7b1426e915bb943b082dbe74a1317676b79a60655bdc164571051e72bf25629cec38e29ae8f1797ca182d1aec96f00d17214eb"
+	signedTxSignature, err := hex.DecodeString("a4ecae97964160e9addeb6bfdab4919d79ee7b1426e915bb943b082dbe74a1317676b79a60655bdc164571051e72bf25629cec38e29ae8f1797ca182d1aec96f00")
 	require.NoError(t, err)
-	signedTxHash := "7MgvKBj4pWRFoUosicfdzcJf3ejFnnE4LkSPNNfZDCzJ5SVwE"
+	signedTxHash := "aRapWCBND5o1EJmfJ7VVaENQF8dkYeaw8LogV19X6PCjFB9Wo"
 
 	wrappedSignedTx := `{"tx":"` + signedTx + `","signers":` + stakeSigners + `}`
 
@@ -802,6 +971,110 @@ func TestAddValidatorTxConstruction(t *testing.T) {
 	})
 }
 
+func TestStakingMetadataSigner(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientMock := client.NewMockPChainClient(ctrl)
+	parserMock := indexer.NewMockParser(ctrl)
+	parserMock.EXPECT().GetGenesisBlock(ctx).Return(dummyGenesis, nil)
+	backend, err := NewBackend(
+		clientMock,
+		parserMock,
+		avaxAssetID,
+		pChainNetworkIdentifier,
+		avalancheNetworkID,
+	)
+	require.NoError(t, err)
+
+	backend.SetSignerNodeURLs([]string{"http://validator:9650"})
+	remoteMock := client.NewMockPChainClient(ctrl)
+	var remoteURI string
+	backend.newNodeIDClient = func(uri string) nodeIDClient {
+		remoteURI = uri
+		return remoteMock
+	}
+
+	pop, err := parsePoP(sampleBlsPublicKey, sampleProofOfPossession)
+	require.NoError(t, err)
+	stakingNodeID, err := ids.NodeIDFromString(nodeID)
+	require.NoError(t, err)
+
+	t.Run("local signer node", func(t *testing.T) {
+		clientMock.EXPECT().GetNodeID(ctx).Return(stakingNodeID, pop, nil)
+
+		metadata, err := backend.buildStakingMetadata(ctx, map[string]interface{}{
+			"node_id":     nodeID,
+			"signer_node": pmapper.SignerNodeLocal,
+		})
+		require.NoError(t, err)
+		require.Equal(t, nodeID, metadata.NodeID)
+		require.Equal(t, sampleBlsPublicKey, metadata.BLSPublicKey)
+		require.Equal(t, sampleProofOfPossession, metadata.BLSProofOfPossession)
+	})
+
+	t.Run("remote signer node", func(t *testing.T) {
+		remoteMock.EXPECT().GetNodeID(ctx).Return(stakingNodeID, pop, nil)
+
+		// the node id defaults to the one of the signer node
+		metadata, err := backend.buildStakingMetadata(ctx, map[string]interface{}{
+			"signer_node": "http://validator:9650",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "http://validator:9650", remoteURI)
+		require.Equal(t, nodeID, metadata.NodeID)
+		require.Equal(t, sampleBlsPublicKey, metadata.BLSPublicKey)
+		require.Equal(t, sampleProofOfPossession, metadata.BLSProofOfPossession)
+	})
+
+	t.Run("signer node not configured", func(t *testing.T) {
+		remoteURI = ""
+
+		_, err := backend.buildStakingMetadata(ctx, map[string]interface{}{
+			"signer_node": "http://169.254.169.254",
+		})
+		require.ErrorIs(t, err, errSignerNodeNotAllowed)
+		require.Empty(t, remoteURI)
+	})
+
+	t.Run("signer node mismatch", func(t *testing.T) {
+		clientMock.EXPECT().GetNodeID(ctx).Return(ids.GenerateTestNodeID(), pop, nil)
+
+		_, err := backend.buildStakingMetadata(ctx, map[string]interface{}{
+			"node_id":     nodeID,
+			"signer_node": pmapper.SignerNodeLocal,
+		})
+		require.ErrorIs(t, err, errSignerNodeMismatch)
+	})
+
+	t.Run("signer node along with signer", func(t *testing.T) {
+		_, err := backend.buildStakingMetadata(ctx, map[string]interface{}{
+			"node_id":                 nodeID,
+			"signer_node":             pmapper.SignerNodeLocal,
+			"bls_public_key":          sampleBlsPublicKey,
+			"bls_proof_of_possession": sampleProofOfPossession,
+		})
+		require.ErrorIs(t, err, errSignerNodeWithSigner)
+	})
+
+	t.Run("invalid proof of possession", func(t *testing.T) {
+		// the proof of possession of another key
+		_, err := backend.buildStakingMetadata(ctx, map[string]interface{}{
+			"node_id":                 nodeID,
+			"bls_public_key":          "0xa1d2e3f40ee1e96b4c9a3ee4fb6e2ab1fb02a2c9fbcfeebaee2fe5d8b3c7a6f01d5aea0e4ebb2d8c9b6d9e3e0c9c1a4b",
+			"bls_proof_of_possession": sampleProofOfPossession,
+		})
+		require.ErrorContains(t, err, "invalid BLS proof of possession")
+
+		// a truncated proof of possession
+		_, err = backend.buildStakingMetadata(ctx, map[string]interface{}{
+			"node_id":                 nodeID,
+			"bls_public_key":          sampleBlsPublicKey,
+			"bls_proof_of_possession": sampleProofOfPossession[:len(sampleProofOfPossession)-2],
+		})
+		require.ErrorContains(t, err, "invalid BLS proof of possession")
+	})
+}
+
 func TestAddDelegatorTxConstruction(t *testing.T) {
 	startTime := uint64(1659592163)
 	endTime := startTime + 14*86400
@@ -894,10 +1167,10 @@ func TestAddDelegatorTxConstruction(t *testing.T) {
 		},
 	}
 
-	signedTx := "0x00000000001a0000000500000000000000000000000000000000000000000000000000000000000000000000000000000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa0000000500000005d21dba0000000001000000000000000077e1d5c6c289c49976f744749d54369d2129d7500000000062eb5de30000000062fdd2e300000005d21dba000000000000000000000000000000000000000000000000000000000000000000000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa0000000700000005d21dba00000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c0000000b000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c000000010000000900000001ec8550b25b2a82364ae4944b52ed16c645fc51fa3f239fd9430912760a70f6795381ea7d76a59e22443934e015f695a418c30666415773206e1a8e96bc0ff9bb01a538423f"
-	signedTxSignature, err := hex.DecodeString("ec8550b25b2a82364ae4944b52ed16c645fc51fa3f239fd9430912760a70f6795381ea7d76a59e22443934e015f695a418c30666415773206e1a8e96bc0ff9bb01")
+	signedTx := "0x00000000001a0000000500000000000000000000000000000000000000000000000000000000000000000000000000000001f52a5a6dd8f1b3fe05204bdab4f6bcb5a7059f88d0443c636f6c158f838dd1a8000000003d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa0000000500000005d21dba0000000001000000000000000077e1d5c6c289c49976f744749d54369d2129d7500000000062eb5de30000000062fdd2e300000005d21dba000000000000000000000000000000000000000000000000000000000000000000000000013d9bdac0ed1d761330cf680efdeb1a42159eb387d6d2950c96f7d28f61bbe2aa0000000700000005d21dba00000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c0000000b000000000000000000000001000000013cb7d3842e8cee6a0ebd09f1fe884f6861e1b29c0000000100000009000000014e943983f8071c304cb96085799d3878dde4f11823aa1ce835f220d5b91d3b796721d570fca3c48cd1a4b6784b0cdbad8f33bf82609190b1e04e7a5219af5d790032f14878"
+	signedTxSignature, err := hex.DecodeString("4e943983f8071c304cb96085799d3878dde4f11823aa1ce835f220d5b91d3b796721d570fca3c48cd1a4b6784b0cdbad8f33bf82609190b1e04e7a5219af5d7900")
 	require.NoError(t, err)
-	signedTxHash := "2qiPWNr3TgsWbiZLWMyVRQQqgLiq4cmvg7e7gsUjgmVZZwtgsD"
+	signedTxHash := "2Z2V5yD5DWwb7LzA4LSUE34XdYJvLdpmVk2bCEqhJmvysTKLpY"
 
 	wrappedSignedTx := `{"tx":"` + signedTx + `","signers":` + stakeSigners + `}`
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
@@ -22,6 +23,9 @@ import (
 	proposervmblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
 )
 
+// proposerCacheSize is the number of heights whose proposervm wrapper is cached
+const proposerCacheSize = 1024
+
 var (
 	_ Parser = &parser{}
 
@@ -58,6 +62,18 @@ type parser struct {
 	networkID uint32
 
 	aliaser ids.Aliaser
+
+	// proposers caches the proposervm wrapper of the blocks fetched from
+	// the indexer, by height, so that blocks looked up by hash don't fetch it again
+	proposers *cache.LRU[uint64, *proposerWrapper]
+}
+
+// proposerWrapper holds the fields of a block that are only known from its
+// proposervm wrapper
+type proposerWrapper struct {
+	blockID   ids.ID
+	timestamp int64
+	proposer  *ProposerBlock
 }
 
 // NewParser creates a new P-chain indexer parser
@@ -76,6 +92,7 @@ func NewParser(pChainClient client.PChainClient, avalancheNetworkID uint32) (Par
 		codecVersion: block.CodecVersion,
 		networkID:    avalancheNetworkID,
 		aliaser:      aliaser,
+		proposers:    &cache.LRU[uint64, *proposerWrapper]{Size: proposerCacheSize},
 	}, nil
 }
 
@@ -176,7 +193,12 @@ func (p *parser) parseBlockAtHeight(ctx context.Context, height uint64) (*Parsed
 		return nil, err
 	}
 
-	return p.parseProposerBlock(container.Bytes)
+	blk, err := p.parseProposerBlock(container.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	p.cacheProposer(blk)
+	return blk, nil
 }
 
 func (p *parser) parseBlockWithHash(ctx context.Context, hash string) (*ParsedBlock, error) {
@@ -192,15 +214,60 @@ func (p *parser) parseBlockWithHash(ctx context.Context, hash string) (*ParsedBl
 		return nil, err
 	}
 
-	return p.parsePChainBlock(blkBytes, noProposerTime)
+	blk, err := p.parsePChainBlock(blkBytes, noProposerTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// The proposervm wrapper of the block, which holds its proposer and the
+	// timestamp of pre-Banff blocks, is only available from the indexer, which
+	// does not hold the genesis block. The block is still served without it
+	// if the indexer can't provide it.
+	if blk.Height == 0 {
+		return blk, nil
+	}
+	wrapper, ok := p.proposers.Get(blk.Height)
+	if !ok {
+		container, err := p.pChainClient.GetContainerByIndex(ctx, blk.Height-1)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return blk, nil
+		}
+		indexedBlk, err := p.parseProposerBlock(container.Bytes)
+		if err != nil {
+			return blk, nil
+		}
+		wrapper = p.cacheProposer(indexedBlk)
+	}
+	if wrapper.blockID == blk.BlockID {
+		blk.Timestamp = wrapper.timestamp
+		blk.Proposer = wrapper.proposer
+	}
+	return blk, nil
+}
+
+// cacheProposer records the proposervm wrapper of [blk], parsed from the indexer
+func (p *parser) cacheProposer(blk *ParsedBlock) *proposerWrapper {
+	wrapper := &proposerWrapper{
+		blockID:   blk.BlockID,
+		timestamp: blk.Timestamp,
+		proposer:  blk.Proposer,
+	}
+	p.proposers.Put(blk.Height, wrapper)
+	return wrapper
 }
 
 // [parseProposerBlock] parses blocks are retrieved from index api.
 // [parseProposerBlock] tries to parse block as ProposerVM block first.
 // In case of failure, it tries to parse it as a pre-proposerVM block.
 func (p *parser) parseProposerBlock(blkBytes []byte) (*ParsedBlock, error) {
-	pChainBlkBytes := blkBytes
-	proposerTime := noProposerTime
+	var (
+		pChainBlkBytes = blkBytes
+		proposerTime   = noProposerTime
+		proposer       *ProposerBlock
+	)
 
 	proBlk, err := proposervmblock.ParseWithoutVerification(blkBytes)
 	if err == nil {
@@ -208,12 +275,20 @@ func (p *parser) parseProposerBlock(blkBytes []byte) (*ParsedBlock, error) {
 		pChainBlkBytes = proBlk.Block()
 
 		// retrieve relevant proposer data
+		proposer = &ProposerBlock{BlockID: proBlk.ID()}
 		if b, ok := proBlk.(proposervmblock.SignedBlock); ok {
 			proposerTime = b.Timestamp()
+			proposer.NodeID = b.Proposer()
+			proposer.PChainHeight = b.PChainHeight()
 		}
 	}
 
-	return p.parsePChainBlock(pChainBlkBytes, proposerTime)
+	blk, err := p.parsePChainBlock(pChainBlkBytes, proposerTime)
+	if err != nil {
+		return nil, err
+	}
+	blk.Proposer = proposer
+	return blk, nil
 }
 
 func (p *parser) parsePChainBlock(pChainBlkBytes []byte, proposerTime time.Time) (*ParsedBlock, error) {
@@ -236,7 +311,7 @@ func (p *parser) parsePChainBlock(pChainBlkBytes []byte, proposerTime time.Time)
 
 	return &ParsedBlock{
 		BlockID:   blk.ID(),
-		BlockType: fmt.Sprintf("%T", blk),
+		BlockType: blockType(blk),
 		ParentID:  blk.Parent(),
 		Timestamp: blkTime.UnixMilli(),
 
@@ -245,6 +320,32 @@ func (p *parser) parsePChainBlock(pChainBlkBytes []byte, proposerTime time.Time)
 	}, nil
 }
 
+// blockType returns the platformvm type of [pchainBlk], such as banff_standard
+func blockType(pchainBlk block.Block) string {
+	switch pchainBlk.(type) {
+	case *block.BanffProposalBlock:
+		return BlockTypeBanffProposal
+	case *block.BanffStandardBlock:
+		return BlockTypeBanffStandard
+	case *block.BanffAbortBlock:
+		return BlockTypeBanffAbort
+	case *block.BanffCommitBlock:
+		return BlockTypeBanffCommit
+	case *block.ApricotProposalBlock:
+		return BlockTypeApricotProposal
+	case *block.ApricotStandardBlock:
+		return BlockTypeApricotStandard
+	case *block.ApricotAbortBlock:
+		return BlockTypeApricotAbort
+	case *block.ApricotCommitBlock:
+		return BlockTypeApricotCommit
+	case *block.ApricotAtomicBlock:
+		return BlockTypeApricotAtomic
+	default:
+		return fmt.Sprintf("%T", pchainBlk)
+	}
+}
+
 func retrieveTime(pchainBlk block.Block, proposerTime time.Time) (time.Time, error) {
 	switch b := pchainBlk.(type) {
 	// Banff blocks serialize pchain time
@@ -3,15 +3,18 @@ package indexer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/indexer"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/stretchr/testify/require"
@@ -21,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanche-rosetta/constants"
 
 	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
+	proposervmblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
 )
 
 var (
@@ -161,6 +165,68 @@ func TestFixtures(t *testing.T) {
 	}
 }
 
+func TestParseBlockWithHash(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	pchainClient := client.NewMockPChainClient(ctrl)
+	hashParser, err := NewParser(pchainClient, avaconstants.MainnetID)
+	require.NoError(t, err)
+
+	// container 1000004 holds the proposervm wrapper of block 1000005
+	var container indexer.Container
+	require.NoError(t, json.Unmarshal(readFixture("ins/1000004.json"), &container))
+	proBlk, err := proposervmblock.ParseWithoutVerification(container.Bytes)
+	require.NoError(t, err)
+	pChainBlk, err := block.Parse(block.Codec, proBlk.Block())
+	require.NoError(t, err)
+	blkID := pChainBlk.ID()
+
+	t.Run("indexer failure", func(t *testing.T) {
+		pchainClient.EXPECT().GetBlock(ctx, blkID).Return(proBlk.Block(), nil)
+		pchainClient.EXPECT().GetContainerByIndex(ctx, uint64(1000004)).Return(indexer.Container{}, errors.New("index not found"))
+
+		blk, err := hashParser.ParseNonGenesisBlock(ctx, blkID.String(), 0)
+		require.NoError(t, err)
+		require.Equal(t, blkID, blk.BlockID)
+		require.Equal(t, uint64(1000005), blk.Height)
+		require.Nil(t, blk.Proposer)
+	})
+
+	t.Run("proposer block from the indexer", func(t *testing.T) {
+		pchainClient.EXPECT().GetBlock(ctx, blkID).Return(proBlk.Block(), nil)
+		pchainClient.EXPECT().GetContainerByIndex(ctx, uint64(1000004)).Return(container, nil)
+
+		blk, err := hashParser.ParseNonGenesisBlock(ctx, blkID.String(), 0)
+		require.NoError(t, err)
+
+		j, err := json.Marshal(blk)
+		require.NoError(t, err)
+		require.JSONEq(t, string(readFixture("outs/1000004.json")), string(j))
+	})
+
+	t.Run("proposer block is fetched once per height", func(t *testing.T) {
+		pchainClient.EXPECT().GetBlock(ctx, blkID).Return(proBlk.Block(), nil)
+
+		blk, err := hashParser.ParseNonGenesisBlock(ctx, blkID.String(), 0)
+		require.NoError(t, err)
+
+		j, err := json.Marshal(blk)
+		require.NoError(t, err)
+		require.JSONEq(t, string(readFixture("outs/1000004.json")), string(j))
+	})
+
+	t.Run("genesis height is not looked up in the indexer", func(t *testing.T) {
+		genesisBlk, err := block.NewBanffStandardBlock(time.Unix(1_600_000_000, 0), ids.Empty, 0, nil)
+		require.NoError(t, err)
+		pchainClient.EXPECT().GetBlock(ctx, genesisBlk.ID()).Return(genesisBlk.Bytes(), nil)
+
+		blk, err := hashParser.ParseNonGenesisBlock(ctx, genesisBlk.ID().String(), 0)
+		require.NoError(t, err)
+		require.Equal(t, genesisBlk.ID(), blk.BlockID)
+		require.Nil(t, blk.Proposer)
+	})
+}
+
 func initializeTxCtx(txs []*txs.Tx, networkID uint32) {
 	aliaser := ids.NewAliaser()
 	_ = aliaser.Alias(avaconstants.PlatformChainID, constants.PChain.String())
@@ -6,14 +6,36 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
+// Types of the platformvm blocks
+const (
+	BlockTypeBanffProposal   = "banff_proposal"
+	BlockTypeBanffStandard   = "banff_standard"
+	BlockTypeBanffAbort      = "banff_abort"
+	BlockTypeBanffCommit     = "banff_commit"
+	BlockTypeApricotProposal = "apricot_proposal"
+	BlockTypeApricotStandard = "apricot_standard"
+	BlockTypeApricotAbort    = "apricot_abort"
+	BlockTypeApricotCommit   = "apricot_commit"
+	BlockTypeApricotAtomic   = "apricot_atomic"
+)
+
 // ParsedBlock contains block details parsed from indexer containers
 type ParsedBlock struct {
-	BlockID   ids.ID    `json:"id"`
-	BlockType string    `json:"type"`
-	ParentID  ids.ID    `json:"parent"`
-	Timestamp int64     `json:"timestamp"`
-	Height    uint64    `json:"height"`
-	Txs       []*txs.Tx `json:"transactions"`
+	BlockID   ids.ID         `json:"id"`
+	BlockType string         `json:"type"`
+	ParentID  ids.ID         `json:"parent"`
+	Timestamp int64          `json:"timestamp"`
+	Height    uint64         `json:"height"`
+	Txs       []*txs.Tx      `json:"transactions"`
+	Proposer  *ProposerBlock `json:"proposer,omitempty"`
+}
+
+// ProposerBlock contains the details of the proposervm block wrapping a P-chain block.
+// Option blocks, which follow proposal blocks, have no proposer nor P-chain height.
+type ProposerBlock struct {
+	BlockID      ids.ID     `json:"id"`
+	NodeID       ids.NodeID `json:"nodeID"`
+	PChainHeight uint64     `json:"pChainHeight"`
 }
 
 // GenesisBlockData contains Genesis state details
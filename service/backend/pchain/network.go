@@ -34,19 +34,19 @@ func (b *Backend) NetworkStatus(ctx context.Context, _ *types.NetworkRequest) (*
 		return nil, service.WrapError(service.ErrClientError, err)
 	}
 
-	if !ready {
+	// Current block height
+	currentBlock, err := b.indexerParser.ParseCurrentBlock(ctx)
+	if !ready && err != nil {
+		// the indexer may not serve blocks until the node is done bootstrapping
 		genesisBlock := b.getGenesisBlock()
 		return &types.NetworkStatusResponse{
 			CurrentBlockIdentifier: b.getGenesisIdentifier(),
 			CurrentBlockTimestamp:  genesisBlock.Timestamp,
 			GenesisBlockIdentifier: b.getGenesisIdentifier(),
-			SyncStatus:             mapper.StageBootstrap,
+			SyncStatus:             b.syncStatus.SyncStatus(ctx, ready, int64(genesisBlock.Height), genesisBlock.Timestamp),
 			Peers:                  peers,
 		}, nil
 	}
-
-	// Current block height
-	currentBlock, err := b.indexerParser.ParseCurrentBlock(ctx)
 	if err != nil {
 		return nil, service.WrapError(service.ErrClientError, err)
 	}
@@ -58,11 +58,16 @@ func (b *Backend) NetworkStatus(ctx context.Context, _ *types.NetworkRequest) (*
 		},
 		CurrentBlockTimestamp:  currentBlock.Timestamp,
 		GenesisBlockIdentifier: b.getGenesisIdentifier(),
-		SyncStatus:             mapper.StageSynced,
+		SyncStatus:             b.syncStatus.SyncStatus(ctx, ready, int64(currentBlock.Height), currentBlock.Timestamp),
 		Peers:                  peers,
 	}, nil
 }
 
+// SetSyncStatusConfig configures the sync progress reported by /network/status
+func (b *Backend) SetSyncStatusConfig(config service.SyncStatusConfig) {
+	b.syncStatus = service.NewSyncStatusTracker(config)
+}
+
 // NetworkOptions implements /network/options endpoint for P-chain
 func (*Backend) NetworkOptions(_ context.Context, _ *types.NetworkRequest) (*types.NetworkOptionsResponse, *types.Error) {
 	return &types.NetworkOptionsResponse{
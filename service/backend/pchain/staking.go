@@ -0,0 +1,411 @@
+package pchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+	"github.com/ava-labs/avalanche-rosetta/service/backend/common"
+
+	pmapper "github.com/ava-labs/avalanche-rosetta/mapper/pchain"
+	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// MaxStakeLookupRequests is the maximum number of requests made to the node to
+// list the staked coins of an address, beyond the listing of the current
+// validators. Listing the delegators of a validator and fetching a staking tx
+// take a request each. The stake must be listed within a single block, which
+// a larger scan would hardly achieve.
+const MaxStakeLookupRequests = 256
+
+var errStakeLookupTooLarge = errors.New("listing the stake requires too many requests to the node")
+
+// requestBudget bounds the number of requests made to the node
+type requestBudget struct {
+	remaining int
+}
+
+// spend consumes [n] requests of the budget, or fails if there are not enough
+// remaining
+func (r *requestBudget) spend(n int) error {
+	if n > r.remaining {
+		return fmt.Errorf("%w: more than %d requests", errStakeLookupTooLarge, MaxStakeLookupRequests)
+	}
+	r.remaining -= n
+	return nil
+}
+
+// staker is a current staker of the primary network
+type staker struct {
+	platformvm.ClientStaker
+	stakerType      string
+	rewardOwner     *platformvm.ClientOwner
+	potentialReward *uint64
+}
+
+func (s *staker) isRewarding(addr ids.ShortID) bool {
+	if s.rewardOwner == nil {
+		return false
+	}
+	for _, owner := range s.rewardOwner.Addresses {
+		if owner == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// getStakedCoins lists the outputs staked by [addr] in the current stakers of the
+// primary network, along with the staking position they belong to.
+//
+// Staked outputs don't reference their staking tx, so they are looked up in the
+// staking txs of the current stakers. Stakers rewarding [addr] are most likely
+// staked by [addr] and are looked up first, starting with validators as listing
+// delegators takes a request. The delegators of every validator are listed at
+// once by that request. The other stakers are only looked up if some of the stake
+// [addr] may be listed for is still missing. The lookup fails once it takes more
+// than [MaxStakeLookupRequests] requests.
+func (b *Backend) getStakedCoins(ctx context.Context, addr ids.ShortID, assetIDs set.Set[ids.ID]) (*types.AccountCoinsResponse, *types.Error) {
+	preHeight, err := b.pClient.GetHeight(ctx)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, "unable to get chain height pre-lookup")
+	}
+
+	_, stakedOutputs, err := b.pClient.GetStake(ctx, []ids.ShortID{addr}, false)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, "unable to get stake")
+	}
+	staked, err := b.listableStake(addr, stakedOutputs)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+
+	validators, err := b.pClient.GetCurrentValidators(ctx, avaconstants.PrimaryNetworkID, nil)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, "unable to fetch validators")
+	}
+
+	var (
+		coins      []*types.Coin
+		listed     uint64
+		budget     = &requestBudget{remaining: MaxStakeLookupRequests}
+		delegators []*staker
+		// listDelegators lists the delegators of every validator on first use
+		listDelegators = func() ([]*staker, error) {
+			if delegators != nil {
+				return delegators, nil
+			}
+			var err error
+			delegators, err = b.fetchDelegatorStakers(ctx, validators, budget)
+			return delegators, err
+		}
+		// stakers are looked up in this order until all the stake of [addr] is listed
+		lookups = []func() ([]*staker, error){
+			func() ([]*staker, error) {
+				return rewardingStakers(validatorStakers(validators), addr, true), nil
+			},
+			func() ([]*staker, error) {
+				stakers, err := listDelegators()
+				return rewardingStakers(stakers, addr, true), err
+			},
+			func() ([]*staker, error) {
+				return rewardingStakers(validatorStakers(validators), addr, false), nil
+			},
+			func() ([]*staker, error) {
+				stakers, err := listDelegators()
+				return rewardingStakers(stakers, addr, false), err
+			},
+		}
+	)
+	for _, lookup := range lookups {
+		if listed >= staked {
+			break
+		}
+		stakers, err := lookup()
+		if errors.Is(err, errStakeLookupTooLarge) {
+			return nil, service.WrapError(service.ErrNotSupported, err)
+		}
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+		if err := budget.spend(len(stakers)); err != nil {
+			return nil, service.WrapError(service.ErrNotSupported, err)
+		}
+		stakerCoins, amount, err := b.fetchStakedCoins(ctx, addr, stakers)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+		coins = append(coins, stakerCoins...)
+		listed, err = math.Add(listed, amount)
+		if err != nil {
+			return nil, service.WrapError(service.ErrInternalError, err)
+		}
+	}
+
+	postHeight, err := b.pClient.GetHeight(ctx)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, "unable to get chain height post-lookup")
+	}
+	if postHeight != preHeight {
+		return nil, service.WrapError(service.ErrInternalError, "new block added while fetching stake")
+	}
+
+	block, err := b.indexerParser.ParseNonGenesisBlock(ctx, "", postHeight)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInvalidInput, "unable to get height")
+	}
+
+	// staked outputs are only AVAX
+	if assetIDs.Len() > 0 && !assetIDs.Contains(b.avaxAssetID) {
+		coins = nil
+	}
+
+	return &types.AccountCoinsResponse{
+		BlockIdentifier: &types.BlockIdentifier{
+			Index: int64(postHeight),
+			Hash:  block.BlockID.String(),
+		},
+		Coins: common.SortUnique(coins),
+	}, nil
+}
+
+// listableStake returns the amount of [stakedOutputs], as returned by GetStake,
+// that is listed as staked coins of [addr]. GetStake also returns the outputs
+// [addr] co-owns, which are not listed.
+func (b *Backend) listableStake(addr ids.ShortID, stakedOutputs [][]byte) (uint64, error) {
+	var staked uint64
+	for _, outBytes := range stakedOutputs {
+		out := avax.TransferableOutput{}
+		if _, err := b.codec.Unmarshal(outBytes, &out); err != nil {
+			return 0, errUnableToParseUTXO
+		}
+		transferOut, ok := b.ownedStakeOutput(addr, &out)
+		if !ok {
+			continue
+		}
+
+		var err error
+		staked, err = math.Add(staked, transferOut.Amt)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return staked, nil
+}
+
+// ownedStakeOutput returns the transfer output of [out] if it stakes AVAX owned
+// by [addr] only
+func (b *Backend) ownedStakeOutput(addr ids.ShortID, out *avax.TransferableOutput) (*secp256k1fx.TransferOutput, bool) {
+	if out.AssetID() != b.avaxAssetID {
+		return nil, false
+	}
+	outIntf := out.Out
+	if lockedOut, ok := outIntf.(*stakeable.LockOut); ok {
+		outIntf = lockedOut.TransferableOut
+	}
+	transferOut, ok := outIntf.(*secp256k1fx.TransferOutput)
+	if !ok || len(transferOut.Addrs) != 1 || transferOut.Addrs[0] != addr {
+		return nil, false
+	}
+	return transferOut, true
+}
+
+// validatorStakers returns the stakers of [validators]
+func validatorStakers(validators []platformvm.ClientPermissionlessValidator) []*staker {
+	stakers := make([]*staker, 0, len(validators))
+	for _, validator := range validators {
+		stakers = append(stakers, &staker{
+			ClientStaker:    validator.ClientStaker,
+			stakerType:      pmapper.StakerTypeValidator,
+			rewardOwner:     validator.ValidationRewardOwner,
+			potentialReward: validator.PotentialReward,
+		})
+	}
+	return stakers
+}
+
+// rewardingStakers returns the stakers of [stakers] rewarding [addr] if
+// [rewarding] is set, and the others otherwise
+func rewardingStakers(stakers []*staker, addr ids.ShortID, rewarding bool) []*staker {
+	var filtered []*staker
+	for _, s := range stakers {
+		if s.isRewarding(addr) == rewarding {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// fetchDelegatorStakers returns the delegators of [validators]. Delegators are
+// only listed when validators are queried by node ID, so the validators with
+// delegators are queried at once, with a request of [budget].
+func (b *Backend) fetchDelegatorStakers(
+	ctx context.Context,
+	validators []platformvm.ClientPermissionlessValidator,
+	budget *requestBudget,
+) ([]*staker, error) {
+	var nodeIDs []ids.NodeID
+	for _, validator := range validators {
+		if validator.DelegatorCount != nil && *validator.DelegatorCount > 0 {
+			nodeIDs = append(nodeIDs, validator.NodeID)
+		}
+	}
+	stakers := []*staker{}
+	if len(nodeIDs) == 0 {
+		return stakers, nil
+	}
+	if err := budget.spend(1); err != nil {
+		return nil, err
+	}
+
+	nodeValidators, err := b.pClient.GetCurrentValidators(ctx, avaconstants.PrimaryNetworkID, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeValidator := range nodeValidators {
+		for _, delegator := range nodeValidator.Delegators {
+			stakers = append(stakers, &staker{
+				ClientStaker:    delegator.ClientStaker,
+				stakerType:      pmapper.StakerTypeDelegator,
+				rewardOwner:     delegator.RewardOwner,
+				potentialReward: delegator.PotentialReward,
+			})
+		}
+	}
+	return stakers, nil
+}
+
+// fetchStakedCoins fetches the staking txs of [stakers] and returns the outputs
+// they stake for [addr], along with their total amount
+func (b *Backend) fetchStakedCoins(ctx context.Context, addr ids.ShortID, stakers []*staker) ([]*types.Coin, uint64, error) {
+	stakerCoins := make([][]*types.Coin, len(stakers))
+	stakerAmounts := make([]uint64, len(stakers))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(b.fetchConcurrency)
+	for i, s := range stakers {
+		i, s := i, s
+		eg.Go(func() error {
+			tx, err := b.fetchStakingTx(ctx, s.TxID)
+			if err != nil {
+				return err
+			}
+			stakerCoins[i], stakerAmounts[i], err = b.stakedCoins(addr, s, tx)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		coins []*types.Coin
+		total uint64
+		err   error
+	)
+	for i := range stakers {
+		coins = append(coins, stakerCoins[i]...)
+		total, err = math.Add(total, stakerAmounts[i])
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return coins, total, nil
+}
+
+func (b *Backend) fetchStakingTx(ctx context.Context, txID ids.ID) (*txs.Tx, error) {
+	if dep, ok := b.dependencyCache.Get(txID); ok {
+		return dep.Tx, nil
+	}
+
+	txBytes, err := b.pClient.GetTx(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	return txs.Parse(txs.Codec, txBytes)
+}
+
+// stakedCoins returns the outputs staked by [tx] that are owned by [addr] only, as
+// multisig outputs are not part of the staked balance. They are identified by the
+// utxo they are returned to once the staking period is over.
+func (b *Backend) stakedCoins(addr ids.ShortID, s *staker, tx *txs.Tx) ([]*types.Coin, uint64, error) {
+	stakerTx, ok := tx.Unsigned.(txs.PermissionlessStaker)
+	if !ok {
+		return nil, 0, nil
+	}
+
+	metadata, err := b.stakeMetadata(s)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		coins   []*types.Coin
+		amount  uint64
+		outsLen = len(stakerTx.Outputs())
+	)
+	for i, out := range stakerTx.Stake() {
+		transferOut, ok := b.ownedStakeOutput(addr, out)
+		if !ok {
+			continue
+		}
+
+		amount, err = math.Add(amount, transferOut.Amt)
+		if err != nil {
+			return nil, 0, err
+		}
+		utxoID := avax.UTXOID{
+			TxID:        tx.ID(),
+			OutputIndex: uint32(outsLen + i),
+		}
+		coins = append(coins, &types.Coin{
+			CoinIdentifier: &types.CoinIdentifier{Identifier: utxoID.String()},
+			Amount: &types.Amount{
+				Value:    strconv.FormatUint(transferOut.Amt, 10),
+				Currency: mapper.AtomicAvaxCurrency,
+				Metadata: metadata,
+			},
+		})
+	}
+	return coins, amount, nil
+}
+
+func (b *Backend) stakeMetadata(s *staker) (map[string]interface{}, error) {
+	metadata := map[string]interface{}{
+		pmapper.MetadataStakingTxID:      s.TxID.String(),
+		pmapper.MetadataStakerType:       s.stakerType,
+		pmapper.MetadataValidatorNodeID:  s.NodeID.String(),
+		pmapper.MetadataStakingStartTime: s.StartTime,
+		pmapper.MetadataStakingEndTime:   s.EndTime,
+	}
+	if s.potentialReward != nil {
+		metadata[pmapper.MetadataPotentialReward] = strconv.FormatUint(*s.potentialReward, 10)
+	}
+	if s.rewardOwner != nil {
+		rewardOwner := make([]string, 0, len(s.rewardOwner.Addresses))
+		for _, addr := range s.rewardOwner.Addresses {
+			formatted, err := address.Format(constants.PChain.String(), b.networkHRP, addr[:])
+			if err != nil {
+				return nil, err
+			}
+			rewardOwner = append(rewardOwner, formatted)
+		}
+		metadata[pmapper.MetadataRewardOwner] = rewardOwner
+	}
+	return metadata, nil
+}
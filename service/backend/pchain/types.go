@@ -6,6 +6,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/coinbase/rosetta-sdk-go/types"
 
@@ -108,12 +109,13 @@ type pTxParser struct {
 	hrp         string
 	chainIDs    map[ids.ID]constants.ChainIDAlias
 	avaxAssetID ids.ID
+	gasWeights  gas.Dimensions
 }
 
-func (p pTxParser) ParseTx(tx *common.RosettaTx, inputAddresses map[string]*types.AccountIdentifier) ([]*types.Operation, error) {
+func (p pTxParser) ParseTx(tx *common.RosettaTx, inputAddresses map[string]*types.AccountIdentifier) ([]*types.Operation, map[string]interface{}, error) {
 	pTx, ok := tx.Tx.(*pTx)
 	if !ok {
-		return nil, errInvalidTransaction
+		return nil, nil, errInvalidTransaction
 	}
 
 	parserCfg := pmapper.TxParserConfig{
@@ -122,16 +124,17 @@ func (p pTxParser) ParseTx(tx *common.RosettaTx, inputAddresses map[string]*type
 		ChainIDs:       p.chainIDs,
 		AvaxAssetID:    p.avaxAssetID,
 		PChainClient:   nil,
+		GasWeights:     p.gasWeights,
 	}
 	parser, err := pmapper.NewTxParser(parserCfg, inputAddresses, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	transactions, err := parser.Parse(pTx.Tx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return transactions.Operations, nil
+	return transactions.Operations, transactions.Metadata, nil
 }
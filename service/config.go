@@ -2,6 +2,7 @@ package service
 
 import (
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -9,6 +10,8 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 
 	ethtypes "github.com/ava-labs/coreth/core/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
 
 // Config holds the service configuration
@@ -23,6 +26,31 @@ type Config struct {
 	BridgeTokenList    []string
 	IndexUnknownTokens bool
 
+	// IncludeWhitelistedTokenBalances makes /account/balance return the
+	// balance of every whitelisted token when no currencies are requested
+	IncludeWhitelistedTokenBalances bool
+
+	// Erc721Index, when set, lists the tokens owned by an account for
+	// ERC721 contracts that do not implement ERC721Enumerable
+	Erc721Index Erc721OwnerIndex
+
+	// SyncStatus configures the C-chain sync progress reported by /network/status
+	SyncStatus SyncStatusConfig
+
+	// TokenLists, when set, takes precedence over TokenWhiteList and
+	// BridgeTokenList and may be updated while the server is running
+	TokenLists *TokenLists
+
+	// EVMChain is the blockchain ID or alias of the EVM chain served, when
+	// it is not the C-chain
+	EVMChain string
+	// NativeCurrency is the native currency of the EVM chain, AVAX if nil
+	NativeCurrency *types.Currency
+
+	// MetadataBundles, when set, serves /construction/metadata in offline mode
+	// from the metadata bundles exported by an online instance
+	MetadataBundles *MetadataBundles
+
 	// Upgrade Times
 	AP5Activation uint64
 }
@@ -54,9 +82,38 @@ func (c Config) IsStandardMode() bool {
 	return c.IngestionMode == StandardIngestion
 }
 
+// IsCChain returns true if the EVM chain served is the C-chain
+func (c Config) IsCChain() bool {
+	return c.EVMChain == ""
+}
+
+// Currency returns the native currency of the EVM chain served
+func (c Config) Currency() *types.Currency {
+	if c.NativeCurrency != nil {
+		return c.NativeCurrency
+	}
+	return mapper.AvaxCurrency
+}
+
 // IsTokenListEmpty returns true if the token addresses list is empty
 func (c Config) IsTokenListEmpty() bool {
-	return len(c.TokenWhiteList) == 0
+	return len(c.WhiteListedTokens()) == 0
+}
+
+// WhiteListedTokens returns the current list of whitelisted token addresses
+func (c Config) WhiteListedTokens() []string {
+	if c.TokenLists != nil {
+		return c.TokenLists.WhiteList()
+	}
+	return c.TokenWhiteList
+}
+
+// BridgeTokens returns the current list of supported bridge token addresses
+func (c Config) BridgeTokens() []string {
+	if c.TokenLists != nil {
+		return c.TokenLists.BridgeList()
+	}
+	return c.BridgeTokenList
 }
 
 // Signer returns an eth signer object for a given chain
@@ -74,3 +131,39 @@ func (c Config) Signer() ethtypes.Signer {
 	}
 	return ethtypes.LatestSignerForChainID(c.ChainID)
 }
+
+// TokenLists holds the token whitelist and the bridge token list so that
+// both can be swapped atomically without restarting the server
+type TokenLists struct {
+	lists atomic.Pointer[tokenLists]
+}
+
+type tokenLists struct {
+	whiteList  []string
+	bridgeList []string
+}
+
+// NewTokenLists returns a TokenLists initialized with the given addresses
+func NewTokenLists(whiteList []string, bridgeList []string) *TokenLists {
+	t := &TokenLists{}
+	t.Update(whiteList, bridgeList)
+	return t
+}
+
+// Update atomically replaces both token lists
+func (t *TokenLists) Update(whiteList []string, bridgeList []string) {
+	t.lists.Store(&tokenLists{
+		whiteList:  whiteList,
+		bridgeList: bridgeList,
+	})
+}
+
+// WhiteList returns the current token whitelist
+func (t *TokenLists) WhiteList() []string {
+	return t.lists.Load().whiteList
+}
+
+// BridgeList returns the current bridge token list
+func (t *TokenLists) BridgeList() []string {
+	return t.lists.Load().bridgeList
+}
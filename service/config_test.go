@@ -40,3 +40,29 @@ func TestConfig(t *testing.T) {
 		require.IsType(t, ethtypes.NewLondonSigner(params.AvalancheMainnetChainID), cfg.Signer())
 	})
 }
+
+func TestConfigTokenLists(t *testing.T) {
+	t.Run("static lists", func(t *testing.T) {
+		cfg := Config{
+			TokenWhiteList:  []string{"0x1"},
+			BridgeTokenList: []string{"0x2"},
+		}
+
+		require.Equal(t, []string{"0x1"}, cfg.WhiteListedTokens())
+		require.Equal(t, []string{"0x2"}, cfg.BridgeTokens())
+		require.False(t, cfg.IsTokenListEmpty())
+	})
+
+	t.Run("updatable lists", func(t *testing.T) {
+		cfg := Config{
+			TokenWhiteList: []string{"0x1"},
+			TokenLists:     NewTokenLists(nil, nil),
+		}
+		require.True(t, cfg.IsTokenListEmpty())
+		require.Empty(t, cfg.BridgeTokens())
+
+		cfg.TokenLists.Update([]string{"0x1", "0x2"}, []string{"0x2"})
+		require.Equal(t, []string{"0x1", "0x2"}, cfg.WhiteListedTokens())
+		require.Equal(t, []string{"0x2"}, cfg.BridgeTokens())
+	})
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"math"
 	"math/big"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -19,21 +22,22 @@ import (
 //
 // https://github.com/coinbase/rosetta-geth-sdk/blob/master/services/construction/contract_call_data.go
 
-const (
-	split  = 2
-	base10 = 10
-)
+const base10 = 10
 
 // constructContractCallDataGeneric constructs the data field of a transaction.
 // The methodArgs can be already in ABI encoded format in case of a single string
 // It can also be passed in as a slice of args, which requires further encoding.
-func constructContractCallDataGeneric(methodSig string, methodArgs interface{}) ([]byte, error) {
-	data, err := contractCallMethodID(methodSig)
+//
+// If [contractABI] is provided, [methodSig] is either the name or the signature
+// of one of its methods, otherwise the arguments are typed after [methodSig].
+func constructContractCallDataGeneric(methodSig string, contractABI string, methodArgs interface{}) ([]byte, error) {
+	method, err := contractCallMethod(methodSig, contractABI)
 	if err != nil {
 		return nil, err
 	}
+	data := append([]byte{}, method.ID...)
 
-	// switch on the type of the method args. method args can come in from json as either a string or list of strings
+	// switch on the type of the method args. method args can come in from json as either a string or list of values
 	switch methodArgs := methodArgs.(type) {
 	// case 0: no method arguments, return the selector
 	case nil:
@@ -48,23 +52,17 @@ func constructContractCallDataGeneric(methodSig string, methodArgs interface{})
 		}
 		return append(data, b...), nil
 
-	// case 2: method args are a list of interface{} which will be converted to string before encoding
+	// case 2: method args are a list of values, nested lists for tuples and arrays
 	case []interface{}:
-		var strList []string
-		for i, genericVal := range methodArgs {
-			strVal, isStrVal := genericVal.(string)
-			if !isStrVal {
-				return nil, fmt.Errorf("invalid method_args type at index %d: %T (must be a string)",
-					i, genericVal,
-				)
-			}
-			strList = append(strList, strVal)
-		}
-		return encodeMethodArgsStrings(data, methodSig, strList)
+		return encodeMethodArgs(data, method.Inputs, methodArgs)
 
 	// case 3: method args are encoded as a list of strings, which will be decoded
 	case []string:
-		return encodeMethodArgsStrings(data, methodSig, methodArgs)
+		args := make([]interface{}, len(methodArgs))
+		for i, arg := range methodArgs {
+			args[i] = arg
+		}
+		return encodeMethodArgs(data, method.Inputs, args)
 
 	// case 4: there is no known way to decode the method args
 	default:
@@ -75,100 +73,387 @@ func constructContractCallDataGeneric(methodSig string, methodArgs interface{})
 	}
 }
 
-// encodeMethodArgsStrings constructs the data field of a transaction for a list of string args.
-// It attempts to first convert the string arg to it's corresponding type in the method signature,
-// and then performs abi encoding to the converted args list and construct the data.
-func encodeMethodArgsStrings(methodID []byte, methodSig string, methodArgs []string) ([]byte, error) {
+// encodeMethodArgs constructs the data field of a transaction for a list of args.
+// It converts each arg to the type of the corresponding method argument, and
+// then performs abi encoding to the converted args list and construct the data.
+func encodeMethodArgs(methodID []byte, arguments abi.Arguments, methodArgs []interface{}) ([]byte, error) {
+	if len(arguments) != len(methodArgs) {
+		return nil, errors.New("invalid method arguments")
+	}
+
+	argumentsData := make([]interface{}, 0, len(arguments))
+	for i, argument := range arguments {
+		value, err := abiValue(argument.Type, methodArgs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid method argument %d: %w", i, err)
+		}
+		argumentsData = append(argumentsData, value.Interface())
+	}
+
+	abiEncodeData, err := arguments.Pack(argumentsData...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments: %w", err)
+	}
+
 	var data []byte
 	data = append(data, methodID...)
+	data = append(data, abiEncodeData...)
+	return data, nil
+}
 
-	splitSigByLeadingParenthesis := strings.Split(methodSig, "(")
-	if len(splitSigByLeadingParenthesis) < split {
-		return data, nil
+// decodeMethodArgs returns the args of the call [data] to [methodSig], in the
+// format accepted by [constructContractCallDataGeneric]: scalars are strings,
+// tuples and arrays are lists.
+func decodeMethodArgs(methodSig string, contractABI string, data []byte) ([]interface{}, error) {
+	method, err := contractCallMethod(methodSig, contractABI)
+	if err != nil {
+		return nil, err
 	}
-	splitSigByTrailingParenthesis := strings.Split(splitSigByLeadingParenthesis[1], ")")
-	if len(splitSigByTrailingParenthesis) < 1 {
-		return data, nil
+	if len(data) < len(method.ID) || !bytes.Equal(data[:len(method.ID)], method.ID) {
+		return nil, fmt.Errorf("data is not a call to %s", method.Sig)
 	}
-	splitSigByComma := strings.Split(splitSigByTrailingParenthesis[0], ",")
-	if len(splitSigByComma) != len(methodArgs) {
-		return nil, errors.New("invalid method arguments")
+
+	values, err := method.Inputs.Unpack(data[len(method.ID):])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode arguments: %w", err)
 	}
 
-	arguments := abi.Arguments{}
-	argumentsData := make([]interface{}, 0, len(splitSigByComma))
-	for i, v := range splitSigByComma {
-		typed, _ := abi.NewType(v, v, nil)
-		argument := abi.Arguments{
-			{
-				Type: typed,
-			},
-		}
+	methodArgs := make([]interface{}, len(values))
+	for i, value := range values {
+		methodArgs[i] = abiArg(method.Inputs[i].Type, reflect.ValueOf(value))
+	}
+	return methodArgs, nil
+}
 
-		arguments = append(arguments, argument...)
-		var argData interface{}
-		switch {
-		case v == "address":
-			{
-				argData = common.HexToAddress(methodArgs[i])
+// contractCallMethod returns the method [methodSig] of [contractABI], or the
+// method described by [methodSig] if no ABI is provided
+func contractCallMethod(methodSig string, contractABI string) (*abi.Method, error) {
+	if len(contractABI) > 0 {
+		parsed, err := abi.JSON(strings.NewReader(contractABI))
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract ABI: %w", err)
+		}
+		if method, ok := parsed.Methods[methodSig]; ok {
+			return &method, nil
+		}
+		for _, method := range parsed.Methods {
+			if method.Sig == methodSig {
+				return &method, nil
 			}
-		case v == "uint32":
-			{
-				u64, err := strconv.ParseUint(methodArgs[i], base10, 32)
-				if err != nil {
-					log.Fatal(err)
+		}
+		return nil, fmt.Errorf("method %s is not in the contract ABI", methodSig)
+	}
+
+	if _, err := contractCallMethodID(methodSig); err != nil {
+		return nil, err
+	}
+	open := strings.Index(methodSig, "(")
+	if open < 1 || !strings.HasSuffix(methodSig, ")") {
+		return nil, fmt.Errorf("invalid method signature: %s", methodSig)
+	}
+	components, err := parseArgumentTypes(methodSig[open+1 : len(methodSig)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(abi.Arguments, len(components))
+	for i, component := range components {
+		typ, err := abi.NewType(component.Type, "", component.Components)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument type: %s", component.Type)
+		}
+		inputs[i] = abi.Argument{Type: typ}
+	}
+
+	name := methodSig[:open]
+	method := abi.NewMethod(name, name, abi.Function, "", false, false, inputs, nil)
+	return &method, nil
+}
+
+// parseArgumentTypes parses the comma separated types of a method signature,
+// where tuples are parenthesized lists of types, as in (address,uint256)[]
+func parseArgumentTypes(types string) ([]abi.ArgumentMarshaling, error) {
+	if len(strings.TrimSpace(types)) == 0 {
+		return nil, nil
+	}
+
+	var (
+		components []abi.ArgumentMarshaling
+		depth      int
+		start      int
+	)
+	for i := 0; i <= len(types); i++ {
+		if i < len(types) {
+			switch types[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				if depth < 0 {
+					return nil, fmt.Errorf("invalid argument types: %s", types)
+				}
+				continue
+			case ',':
+				if depth > 0 {
+					continue
 				}
-				argData = uint32(u64)
+			default:
+				continue
+			}
+		}
+		if depth != 0 {
+			return nil, fmt.Errorf("invalid argument types: %s", types)
+		}
+
+		component, err := parseArgumentType(strings.TrimSpace(types[start:i]))
+		if err != nil {
+			return nil, err
+		}
+		component.Name = fmt.Sprintf("field%d", len(components))
+		components = append(components, *component)
+		start = i + 1
+	}
+	return components, nil
+}
+
+func parseArgumentType(typ string) (*abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(typ, "(") {
+		if _, err := abi.NewType(typ, "", nil); err != nil {
+			return nil, fmt.Errorf("invalid argument type: %s", typ)
+		}
+		return &abi.ArgumentMarshaling{Type: typ}, nil
+	}
+
+	// the array suffix of the tuple follows its closing parenthesis
+	end := strings.LastIndex(typ, ")")
+	components, err := parseArgumentTypes(typ[1:end])
+	if err != nil {
+		return nil, err
+	}
+	return &abi.ArgumentMarshaling{
+		Type:       "tuple" + typ[end+1:],
+		Components: components,
+	}, nil
+}
+
+// abiValue converts [arg] to the go type of [typ] expected by abi encoding.
+//
+// Scalars are strings, integers may also be JSON numbers and booleans JSON
+// booleans. Arrays are lists, tuples are lists or objects keyed by the
+// component names of the contract ABI.
+func abiValue(typ abi.Type, arg interface{}) (reflect.Value, error) {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		n, err := abiInteger(typ, arg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value := reflect.New(typ.GetType()).Elem()
+		switch value.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			value.SetUint(n.Uint64())
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value.SetInt(n.Int64())
+		default:
+			value = reflect.ValueOf(n)
+		}
+		return value, nil
+	case abi.BoolTy:
+		switch arg := arg.(type) {
+		case bool:
+			return reflect.ValueOf(arg), nil
+		case string:
+			value, err := strconv.ParseBool(arg)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%s is not a valid bool", arg)
 			}
-		case strings.HasPrefix(v, "uint") || strings.HasPrefix(v, "int"):
-			{
-				value := new(big.Int)
-				value.SetString(methodArgs[i], base10)
-				argData = value
+			return reflect.ValueOf(value), nil
+		}
+	case abi.StringTy:
+		if arg, ok := arg.(string); ok {
+			return reflect.ValueOf(arg), nil
+		}
+	case abi.AddressTy:
+		if arg, ok := arg.(string); ok {
+			if !common.IsHexAddress(arg) {
+				return reflect.Value{}, fmt.Errorf("%s is not a valid address", arg)
 			}
-		case v == "bytes32":
-			{
-				value := [32]byte{}
-				bytes, err := hexutil.Decode(methodArgs[i])
-				if err != nil {
-					log.Fatal(err)
-				}
-				copy(value[:], bytes)
-				argData = value
+			return reflect.ValueOf(common.HexToAddress(arg)), nil
+		}
+	case abi.BytesTy:
+		if arg, ok := arg.(string); ok {
+			b, err := hexutil.Decode(arg)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%s is not valid hex: %w", arg, err)
 			}
-		case strings.HasPrefix(v, "bytes"):
-			{
-				bytes, err := hexutil.Decode(methodArgs[i])
-				if err != nil {
-					log.Fatal(err)
-				}
-				argData = bytes
+			return reflect.ValueOf(b), nil
+		}
+	case abi.FixedBytesTy:
+		if arg, ok := arg.(string); ok {
+			b, err := hexutil.Decode(arg)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%s is not valid hex: %w", arg, err)
 			}
-		case strings.HasPrefix(v, "string"):
-			{
-				argData = methodArgs[i]
+			if len(b) > typ.Size {
+				return reflect.Value{}, fmt.Errorf("%s is longer than %d bytes", arg, typ.Size)
 			}
-		case strings.HasPrefix(v, "bool"):
-			{
-				value, err := strconv.ParseBool(methodArgs[i])
-				if err != nil {
-					log.Fatal(err)
-				}
-				argData = value
+			// shorter values are right padded, as bytesN are left aligned
+			value := reflect.New(typ.GetType()).Elem()
+			reflect.Copy(value, reflect.ValueOf(b))
+			return value, nil
+		}
+	case abi.SliceTy, abi.ArrayTy:
+		elems := reflect.ValueOf(arg)
+		if elems.Kind() != reflect.Slice {
+			break
+		}
+		var value reflect.Value
+		if typ.T == abi.ArrayTy {
+			if elems.Len() != typ.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d elements, got %d", typ.Size, elems.Len())
 			}
-		default:
-			return nil, fmt.Errorf("invalid argument type: %s", v)
+			value = reflect.New(typ.GetType()).Elem()
+		} else {
+			value = reflect.MakeSlice(typ.GetType(), elems.Len(), elems.Len())
+		}
+		for i := 0; i < elems.Len(); i++ {
+			elem, err := abiValue(*typ.Elem, elems.Index(i).Interface())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			value.Index(i).Set(elem)
 		}
-		argumentsData = append(argumentsData, argData)
+		return value, nil
+	case abi.TupleTy:
+		fields, err := tupleFields(typ, arg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value := reflect.New(typ.TupleType).Elem()
+		for i, elemType := range typ.TupleElems {
+			field, err := abiValue(*elemType, fields[i])
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %d: %w", i, err)
+			}
+			value.Field(i).Set(field)
+		}
+		return value, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported argument type: %s", typ)
 	}
+	return reflect.Value{}, fmt.Errorf("invalid %s value: %v (%T)", typ, arg, arg)
+}
 
-	abiEncodeData, err := arguments.PackValues(argumentsData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode arguments: %w", err)
+// abiInteger parses the decimal or 0x-prefixed hex [arg], checking it fits in [typ]
+func abiInteger(typ abi.Type, arg interface{}) (*big.Int, error) {
+	var (
+		n  = new(big.Int)
+		ok bool
+	)
+	switch arg := arg.(type) {
+	case string:
+		if has0xPrefix(arg) {
+			_, ok = n.SetString(arg[2:], 16)
+		} else {
+			_, ok = n.SetString(arg, base10)
+		}
+	case float64:
+		// JSON numbers are only exact up to 2^53
+		ok = arg == math.Trunc(arg) && math.Abs(arg) <= 1<<53
+		n.SetInt64(int64(arg))
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid %s value: %v", typ, arg)
 	}
 
-	data = append(data, abiEncodeData...)
-	return data, nil
+	minValue, maxValue := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(typ.Size))
+	if typ.T == abi.IntTy {
+		maxValue.Rsh(maxValue, 1)
+		minValue.Neg(maxValue)
+	}
+	if n.Cmp(minValue) < 0 || n.Cmp(maxValue) >= 0 {
+		return nil, fmt.Errorf("%s overflows %s", n, typ)
+	}
+	return n, nil
+}
+
+// tupleFields returns the fields of the tuple [arg], either a list of fields
+// or an object keyed by field name
+func tupleFields(typ abi.Type, arg interface{}) ([]interface{}, error) {
+	switch arg := arg.(type) {
+	case []interface{}:
+		if len(arg) != len(typ.TupleElems) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(typ.TupleElems), len(arg))
+		}
+		return arg, nil
+	case map[string]interface{}:
+		if len(arg) != len(typ.TupleRawNames) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(typ.TupleRawNames), len(arg))
+		}
+		fields := make([]interface{}, len(typ.TupleRawNames))
+		for i, name := range typ.TupleRawNames {
+			field, ok := arg[name]
+			if !ok {
+				return nil, fmt.Errorf("field %s is missing", name)
+			}
+			fields[i] = field
+		}
+		return fields, nil
+	default:
+		return nil, fmt.Errorf("invalid %s value: %v (%T)", typ, arg, arg)
+	}
+}
+
+// abiArg converts the decoded [value] of [typ] to its method_args format
+func abiArg(typ abi.Type, value reflect.Value) interface{} {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		return fmt.Sprintf("%d", value.Interface())
+	case abi.BoolTy:
+		return strconv.FormatBool(value.Bool())
+	case abi.StringTy:
+		return value.String()
+	case abi.AddressTy:
+		return value.Interface().(common.Address).Hex()
+	case abi.BytesTy:
+		return hexutil.Encode(value.Bytes())
+	case abi.FixedBytesTy:
+		b := make([]byte, value.Len())
+		reflect.Copy(reflect.ValueOf(b), value)
+		return hexutil.Encode(b)
+	case abi.SliceTy, abi.ArrayTy:
+		elems := make([]interface{}, value.Len())
+		for i := range elems {
+			elems[i] = abiArg(*typ.Elem, value.Index(i))
+		}
+		return elems
+	case abi.TupleTy:
+		fields := make([]interface{}, len(typ.TupleElems))
+		for i, elemType := range typ.TupleElems {
+			fields[i] = abiArg(*elemType, value.Field(i))
+		}
+		return fields
+	default:
+		return fmt.Sprintf("%v", value.Interface())
+	}
+}
+
+// contractABIString returns the contract_abi metadata, either the ABI JSON
+// string or the ABI itself
+func contractABIString(contractABI interface{}) (string, error) {
+	switch contractABI := contractABI.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return contractABI, nil
+	default:
+		b, err := json.Marshal(contractABI)
+		if err != nil {
+			return "", fmt.Errorf("invalid contract ABI: %w", err)
+		}
+		return string(b), nil
+	}
 }
 
 // contractCallMethodID calculates the first 4 bytes of the method
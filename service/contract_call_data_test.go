@@ -3,16 +3,21 @@ package service
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
 func TestConstruction_ContractCallData(t *testing.T) {
 	tests := map[string]struct {
-		methodSig  string
-		methodArgs interface{}
+		methodSig   string
+		contractABI string
+		methodArgs  interface{}
 
 		expectedResponse string
 		expectedError    error
@@ -42,10 +47,36 @@ func TestConstruction_ContractCallData(t *testing.T) {
 			methodArgs:    "!!!",
 			expectedError: errors.New("error decoding method args hex data: encoding/hex: invalid byte: U+0021 '!'"),
 		},
+		"happy path: list of json args": {
+			methodSig:        "register(string,address,bool)",
+			methodArgs:       []interface{}{"bool abc", "0x0000000000000000000000000000000000000000", true},
+			expectedResponse: "0x60d7a2780000000000000000000000000000000000000000000000000000000000000060000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000008626f6f6c20616263000000000000000000000000000000000000000000000000",
+		},
+		"happy path: contract abi method name": {
+			methodSig:        "register",
+			contractABI:      `[{"type":"function","name":"register","inputs":[{"name":"name","type":"string"},{"name":"owner","type":"address"},{"name":"active","type":"bool"}]}]`,
+			methodArgs:       []interface{}{"bool abc", "0x0000000000000000000000000000000000000000", true},
+			expectedResponse: "0x60d7a2780000000000000000000000000000000000000000000000000000000000000060000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000008626f6f6c20616263000000000000000000000000000000000000000000000000",
+		},
 		"error: case []interface: ": {
 			methodSig:     "register(string,address,bool)",
-			methodArgs:    []interface{}{"bool abc", "0x0000000000000000000000000000000000000000", true},
-			expectedError: errors.New("invalid method_args type at index 2: bool (must be a string)"),
+			methodArgs:    []interface{}{"bool abc", true, "true"},
+			expectedError: errors.New("invalid method argument 1: invalid address value: true (bool)"),
+		},
+		"error: integer overflow": {
+			methodSig:     "approve(address,uint8)",
+			methodArgs:    []string{"0x0000000000000000000000000000000000000000", "256"},
+			expectedError: errors.New("invalid method argument 1: 256 overflows uint8"),
+		},
+		"error: fixed array length": {
+			methodSig:     "set(uint256[2])",
+			methodArgs:    []interface{}{[]interface{}{"1"}},
+			expectedError: errors.New("invalid method argument 0: expected 2 elements, got 1"),
+		},
+		"error: method not in contract abi": {
+			methodSig:     "transfer",
+			contractABI:   `[{"type":"function","name":"register","inputs":[]}]`,
+			expectedError: errors.New("method transfer is not in the contract ABI"),
 		},
 		"error: bad argument type": {
 			methodSig:     "attest(bytes32,foo)",
@@ -56,13 +87,91 @@ func TestConstruction_ContractCallData(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			bytes, err := constructContractCallDataGeneric(test.methodSig, test.methodArgs)
+			bytes, err := constructContractCallDataGeneric(test.methodSig, test.contractABI, test.methodArgs)
 			if err != nil {
 				fmt.Println(err)
 				require.EqualError(t, err, test.expectedError.Error())
 			} else {
+				require.Nil(t, test.expectedError)
 				require.Equal(t, test.expectedResponse, hexutil.Encode(bytes))
 			}
 		})
 	}
 }
+
+func TestConstruction_ContractCallDataTypes(t *testing.T) {
+	const contractABI = `[{"type":"function","name":"swap","inputs":[
+		{"name":"route","type":"tuple[]","components":[{"name":"pool","type":"address"},{"name":"amount","type":"uint256"}]},
+		{"name":"tag","type":"bytes4"},
+		{"name":"slippage","type":"int8"},
+		{"name":"hashes","type":"bytes32[2]"}
+	]}]`
+	pool := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	hash := "0x3100000000000000000000000000000000000000000000000000000000000000"
+
+	// expected call data, encoded with typed go values
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	require.NoError(t, err)
+	type route struct {
+		Pool   common.Address
+		Amount *big.Int
+	}
+	expected, err := parsed.Pack(
+		"swap",
+		[]route{{Pool: common.HexToAddress(pool), Amount: big.NewInt(1_000)}},
+		[4]byte{0x12, 0x34, 0x56, 0x78},
+		int8(-5),
+		[2][32]byte{common.HexToHash(hash), {}},
+	)
+	require.NoError(t, err)
+
+	decodedArgs := []interface{}{
+		[]interface{}{[]interface{}{pool, "1000"}},
+		"0x12345678",
+		"-5",
+		[]interface{}{hash, "0x0000000000000000000000000000000000000000000000000000000000000000"},
+	}
+
+	tests := map[string]struct {
+		methodSig   string
+		contractABI string
+		methodArgs  interface{}
+	}{
+		"signature with tuples and arrays": {
+			methodSig: "swap((address,uint256)[],bytes4,int8,bytes32[2])",
+			methodArgs: []interface{}{
+				[]interface{}{[]interface{}{pool, float64(1_000)}},
+				"0x12345678",
+				"-5",
+				[]interface{}{hash, "0x00"},
+			},
+		},
+		"contract abi with named tuple fields": {
+			methodSig:   "swap",
+			contractABI: contractABI,
+			methodArgs: []interface{}{
+				[]interface{}{map[string]interface{}{"pool": pool, "amount": "0x3e8"}},
+				"0x12345678",
+				float64(-5),
+				[]interface{}{hash, "0x"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := constructContractCallDataGeneric(test.methodSig, test.contractABI, test.methodArgs)
+			require.NoError(t, err)
+			require.Equal(t, expected, data)
+
+			methodArgs, err := decodeMethodArgs(test.methodSig, test.contractABI, data)
+			require.NoError(t, err)
+			require.Equal(t, decodedArgs, methodArgs)
+		})
+	}
+
+	t.Run("decoding data of another method", func(t *testing.T) {
+		_, err := decodeMethodArgs("deposit()", "", expected)
+		require.ErrorContains(t, err, "data is not a call to deposit()")
+	})
+}
@@ -0,0 +1,609 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+const (
+	approveFnSignature         = "approve(address,uint256)"
+	noncesFnSignature          = "nonces(address)"
+	nameFnSignature            = "name()"
+	domainSeparatorFnSignature = "DOMAIN_SEPARATOR()"
+
+	// permitKey is the metadata key flagging an ERC20_APPROVE operation as an
+	// EIP-2612 permit, signed off-chain by the owner and relayed by the spender
+	permitKey = "permit"
+	// defaultPermitVersion is the EIP-712 domain version of most EIP-2612 tokens
+	defaultPermitVersion = "1"
+)
+
+var (
+	approveMethodID = hexutil.Encode(getMethodID(approveFnSignature))
+
+	// permitTypes are the EIP-712 types of an EIP-2612 permit
+	permitTypes = apitypes.Types{
+		"EIP712Domain": {
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+			{Name: "verifyingContract", Type: "address"},
+		},
+		"Permit": {
+			{Name: "owner", Type: "address"},
+			{Name: "spender", Type: "address"},
+			{Name: "value", Type: "uint256"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+	}
+
+	errPermitNotSubmittable = errors.New("signed permits are not transactions, they are relayed by calling permit on the token contract")
+)
+
+// erc20Approval is the intent of an ERC20_APPROVE operation
+type erc20Approval struct {
+	Owner    string
+	Spender  string
+	Amount   *big.Int
+	Currency *types.Currency
+	Contract common.Address
+}
+
+// permitOptions are the /construction/preprocess options of a permit
+type permitOptions struct {
+	Deadline uint64 `json:"deadline"`
+	Version  string `json:"version"`
+}
+
+// permitMetadata is the /construction/metadata of a permit
+type permitMetadata struct {
+	Permit   bool   `json:"permit"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Nonce    string `json:"nonce"`
+	Deadline uint64 `json:"deadline"`
+}
+
+// permitPayload is the unsigned, and once combined the signed, permit. Its
+// typed data is signed with eth_signTypedData_v4, and its signature is passed
+// to permit by the relayer.
+type permitPayload struct {
+	TypedData apitypes.TypedData `json:"typed_data"`
+	Currency  *types.Currency    `json:"currency,omitempty"`
+	Signature *permitSignature   `json:"signature,omitempty"`
+}
+
+type permitSignature struct {
+	V uint8  `json:"v"`
+	R string `json:"r"`
+	S string `json:"s"`
+}
+
+func isErc20ApproveRequest(operations []*types.Operation) bool {
+	return len(operations) > 0 && operations[0].Type == mapper.OpErc20Approve
+}
+
+func isPermit(metadata map[string]interface{}) bool {
+	permit, ok := metadata[permitKey].(bool)
+	return ok && permit
+}
+
+// isPermitPayload returns true if [payload] is an unsigned or signed permit
+func isPermitPayload(payload string) bool {
+	var probe struct {
+		TypedData *json.RawMessage `json:"typed_data"`
+	}
+	return json.Unmarshal([]byte(payload), &probe) == nil && probe.TypedData != nil
+}
+
+// matchErc20ApproveOperations returns the approval of [operations], a single
+// ERC20_APPROVE operation of the owner whose amount is the allowance of the
+// spender in its metadata
+func matchErc20ApproveOperations(operations []*types.Operation) (*erc20Approval, *types.Error) {
+	if len(operations) != 1 || operations[0].Amount == nil || operations[0].Amount.Currency == nil {
+		return nil, WrapError(ErrInvalidInput, "approve requires a single operation with an amount")
+	}
+
+	currency := operations[0].Amount.Currency
+	contract, ok := currency.Metadata[mapper.ContractAddressMetadata].(string)
+	if !ok || !common.IsHexAddress(contract) {
+		return nil, WrapError(ErrInvalidInput, "non-native currency must have contractAddress in metadata")
+	}
+
+	descriptions := &parser.Descriptions{
+		OperationDescriptions: []*parser.OperationDescription{
+			{
+				Type:    mapper.OpErc20Approve,
+				Account: &parser.AccountDescription{Exists: true},
+				Amount: &parser.AmountDescription{
+					Exists:   true,
+					Sign:     parser.AnyAmountSign,
+					Currency: currency,
+				},
+				Metadata: []*parser.MetadataDescription{
+					{Key: mapper.SpenderMetadata, ValueKind: reflect.String},
+				},
+			},
+		},
+		ErrUnmatched: true,
+	}
+	matches, err := parser.MatchOperations(descriptions, operations)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, "unclear intent")
+	}
+
+	op, amount := matches[0].First()
+	if amount.Sign() < 0 || amount.BitLen() > 256 {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid allowance", amount))
+	}
+
+	owner, ok := ChecksumAddress(op.Account.Address)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", op.Account.Address))
+	}
+	spenderAddress := op.Metadata[mapper.SpenderMetadata].(string)
+	spender, ok := ChecksumAddress(spenderAddress)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid spender address", spenderAddress))
+	}
+
+	return &erc20Approval{
+		Owner:    owner,
+		Spender:  spender,
+		Amount:   amount,
+		Currency: currency,
+		Contract: common.HexToAddress(contract),
+	}, nil
+}
+
+func createErc20ApprovePreprocessOptions(req *types.ConstructionPreprocessRequest) (*options, *types.Error) {
+	approval, terr := matchErc20ApproveOperations(req.Operations)
+	if terr != nil {
+		return nil, terr
+	}
+
+	if !isPermit(req.Metadata) {
+		return &options{
+			From:                   approval.Owner,
+			To:                     approval.Contract.Hex(),
+			Value:                  big.NewInt(0),
+			SuggestedFeeMultiplier: req.SuggestedFeeMultiplier,
+			Currency:               approval.Currency,
+			ContractAddress:        approval.Contract.Hex(),
+			ContractData:           hexutil.Encode(generateErc20ApproveData(approval.Spender, approval.Amount)),
+		}, nil
+	}
+
+	var deadline uint64
+	switch v := req.Metadata["deadline"].(type) {
+	case string:
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid deadline", v))
+		}
+		deadline = parsed
+	case float64:
+		if v < 0 || v >= 1<<64 || v != float64(uint64(v)) {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%v is not a valid deadline", v))
+		}
+		deadline = uint64(v)
+	default:
+		return nil, WrapError(ErrInvalidInput, "permits require a deadline")
+	}
+
+	version := defaultPermitVersion
+	if v, ok := req.Metadata["permit_version"]; ok {
+		if version, ok = v.(string); !ok {
+			return nil, WrapError(ErrInvalidInput, fmt.Errorf("%v is not a valid permit version string", v))
+		}
+	}
+
+	return &options{
+		From:     approval.Owner,
+		To:       approval.Spender,
+		Value:    approval.Amount,
+		Currency: approval.Currency,
+		Permit: &permitOptions{
+			Deadline: deadline,
+			Version:  version,
+		},
+	}, nil
+}
+
+// createPermitMetadata returns the token nonce of the owner and the EIP-712
+// domain of the token. The domain is checked against the domain separator of
+// the token, as permits signed for another domain are rejected on chain.
+func (s ConstructionService) createPermitMetadata(
+	ctx context.Context,
+	input *options,
+) (*types.ConstructionMetadataResponse, *types.Error) {
+	contractAddress, ok := input.Currency.Metadata[mapper.ContractAddressMetadata].(string)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, "non-native currency must have contractAddress in metadata")
+	}
+	contract := common.HexToAddress(contractAddress)
+
+	call := func(data []byte) ([]byte, error) {
+		return s.client.CallContract(ctx, interfaces.CallMsg{To: &contract, Data: data}, nil)
+	}
+
+	nonceData := getMethodID(noncesFnSignature)
+	nonceData = append(nonceData, common.LeftPadBytes(common.HexToAddress(input.From).Bytes(), requiredPaddingBytes)...)
+	nonceResponse, err := call(nonceData)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+	nonce := new(big.Int).SetBytes(nonceResponse)
+
+	nameResponse, err := call(getMethodID(nameFnSignature))
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+	name, err := decodeStringResponse(nameResponse)
+	if err != nil {
+		return nil, WrapError(ErrClientError, fmt.Errorf("failed to decode token name: %w", err))
+	}
+
+	domainSeparator, err := call(getMethodID(domainSeparatorFnSignature))
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	metadata := &permitMetadata{
+		Permit:   true,
+		Name:     name,
+		Version:  input.Permit.Version,
+		Nonce:    nonce.String(),
+		Deadline: input.Permit.Deadline,
+	}
+	typedData := permitTypedData(s.config.ChainID, contract, input.From, input.To, input.Value, metadata)
+	expected, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	if !bytes.Equal(expected, domainSeparator) {
+		return nil, WrapError(
+			ErrInvalidInput,
+			fmt.Errorf("domain separator of %s does not match version %q, set permit_version", contract, metadata.Version),
+		)
+	}
+
+	metadataMap, err := mapper.MarshalJSONMap(metadata)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	return &types.ConstructionMetadataResponse{Metadata: metadataMap}, nil
+}
+
+// createPermitPayloads returns the EIP-712 typed data of the permit of
+// [req.Operations], whose hash is signed by the owner
+func (s ConstructionService) createPermitPayloads(
+	req *types.ConstructionPayloadsRequest,
+) (*types.ConstructionPayloadsResponse, *types.Error) {
+	approval, terr := matchErc20ApproveOperations(req.Operations)
+	if terr != nil {
+		return nil, terr
+	}
+
+	var metadata permitMetadata
+	if err := mapper.UnmarshalJSONMap(req.Metadata, &metadata); err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+
+	payload := &permitPayload{
+		TypedData: permitTypedData(s.config.ChainID, approval.Contract, approval.Owner, approval.Spender, approval.Amount, &metadata),
+		Currency:  approval.Currency,
+	}
+	hash, _, err := apitypes.TypedDataAndHash(payload.TypedData)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: string(payloadJSON),
+		Payloads: []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: approval.Owner},
+				Bytes:             hash,
+				SignatureType:     types.EcdsaRecovery,
+			},
+		},
+	}, nil
+}
+
+// combinePermit attaches the (v, r, s) signature tuple expected by permit to
+// the unsigned permit, once the owner is verified to be its signer
+func combinePermit(req *types.ConstructionCombineRequest) (*types.ConstructionCombineResponse, *types.Error) {
+	var payload permitPayload
+	if err := json.Unmarshal([]byte(req.UnsignedTransaction), &payload); err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+	approval, terr := payload.approval()
+	if terr != nil {
+		return nil, terr
+	}
+	hash, _, err := apitypes.TypedDataAndHash(payload.TypedData)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+
+	signature := req.Signatures[0]
+	if signature.SigningPayload != nil && len(signature.SigningPayload.Bytes) > 0 &&
+		!bytes.Equal(signature.SigningPayload.Bytes, hash) {
+		return nil, WrapError(ErrSigningPayloadMismatch, "signing payload is not the hash of the permit")
+	}
+	if len(signature.Bytes) != crypto.SignatureLength {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("signature must be %d bytes", crypto.SignatureLength))
+	}
+
+	pubKey, err := crypto.SigToPub(hash, signature.Bytes)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != common.HexToAddress(approval.Owner) {
+		return nil, WrapError(
+			ErrSignerMismatch,
+			fmt.Sprintf("permit is signed by %s instead of the owner %s", signer.Hex(), approval.Owner),
+		)
+	}
+
+	payload.Signature = &permitSignature{
+		V: signature.Bytes[crypto.RecoveryIDOffset] + 27,
+		R: hexutil.Encode(signature.Bytes[:32]),
+		S: hexutil.Encode(signature.Bytes[32:64]),
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	return &types.ConstructionCombineResponse{SignedTransaction: string(payloadJSON)}, nil
+}
+
+func parsePermit(req *types.ConstructionParseRequest) (*types.ConstructionParseResponse, *types.Error) {
+	var payload permitPayload
+	if err := json.Unmarshal([]byte(req.Transaction), &payload); err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+	approval, terr := payload.approval()
+	if terr != nil {
+		return nil, terr
+	}
+
+	resp := &types.ConstructionParseResponse{
+		Operations: []*types.Operation{
+			erc20ApproveOperation(approval),
+		},
+		Metadata: map[string]interface{}{
+			permitKey:  true,
+			"nonce":    payload.TypedData.Message["nonce"],
+			"deadline": payload.TypedData.Message["deadline"],
+		},
+	}
+	if req.Signed {
+		resp.AccountIdentifierSigners = []*types.AccountIdentifier{{Address: approval.Owner}}
+	}
+	return resp, nil
+}
+
+// hashPermit identifies a signed permit by the hash of its typed data
+func hashPermit(req *types.ConstructionHashRequest) (*types.TransactionIdentifierResponse, *types.Error) {
+	var payload permitPayload
+	if err := json.Unmarshal([]byte(req.SignedTransaction), &payload); err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+	hash, _, err := apitypes.TypedDataAndHash(payload.TypedData)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+	return &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: hexutil.Encode(hash)},
+	}, nil
+}
+
+// approval returns the approval signed off by the permit. The currency must be
+// the token of the domain the permit is signed for.
+func (p *permitPayload) approval() (*erc20Approval, *types.Error) {
+	if p.TypedData.PrimaryType != "Permit" || p.Currency == nil {
+		return nil, WrapError(ErrInvalidInput, "typed data is not a permit")
+	}
+
+	verifyingContract := p.TypedData.Domain.VerifyingContract
+	contract, _ := p.Currency.Metadata[mapper.ContractAddressMetadata].(string)
+	if !common.IsHexAddress(contract) || !common.IsHexAddress(verifyingContract) ||
+		common.HexToAddress(contract) != common.HexToAddress(verifyingContract) {
+		return nil, WrapError(
+			ErrInvalidInput,
+			fmt.Errorf("permit of %s is signed for the contract %s", contract, verifyingContract),
+		)
+	}
+
+	owner, _ := p.TypedData.Message["owner"].(string)
+	checkOwner, ok := ChecksumAddress(owner)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid owner address", owner))
+	}
+	spender, _ := p.TypedData.Message["spender"].(string)
+	checkSpender, ok := ChecksumAddress(spender)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid spender address", spender))
+	}
+	value, _ := p.TypedData.Message["value"].(string)
+	amount, ok := math.ParseBig256(value)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid permit value", value))
+	}
+
+	return &erc20Approval{
+		Owner:    checkOwner,
+		Spender:  checkSpender,
+		Amount:   amount,
+		Currency: p.Currency,
+		Contract: common.HexToAddress(verifyingContract),
+	}, nil
+}
+
+// permitTypedData returns the EIP-712 typed data of the permit of [value]
+// tokens of [contract] from [owner] to [spender]
+func permitTypedData(
+	chainID *big.Int,
+	contract common.Address,
+	owner string,
+	spender string,
+	value *big.Int,
+	metadata *permitMetadata,
+) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       permitTypes,
+		PrimaryType: "Permit",
+		Domain: apitypes.TypedDataDomain{
+			Name:              metadata.Name,
+			Version:           metadata.Version,
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: contract.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":    owner,
+			"spender":  spender,
+			"value":    value.String(),
+			"nonce":    metadata.Nonce,
+			"deadline": strconv.FormatUint(metadata.Deadline, 10),
+		},
+	}
+}
+
+func (s ConstructionService) createErc20ApprovePayload(
+	req *types.ConstructionPayloadsRequest,
+) (*ethtypes.Transaction, *transaction, *string, *types.Error) {
+	approval, terr := matchErc20ApproveOperations(req.Operations)
+	if terr != nil {
+		return nil, nil, nil, terr
+	}
+
+	var metadata metadata
+	if err := mapper.UnmarshalJSONMap(req.Metadata, &metadata); err != nil {
+		return nil, nil, nil, WrapError(ErrInvalidInput, err)
+	}
+
+	tx := ethtypes.NewTransaction(
+		metadata.Nonce,
+		approval.Contract,
+		big.NewInt(0),
+		metadata.GasLimit,
+		metadata.GasPrice,
+		generateErc20ApproveData(approval.Spender, approval.Amount),
+	)
+
+	unsignedTx := &transaction{
+		From:     approval.Owner,
+		To:       approval.Contract.Hex(),
+		Value:    big.NewInt(0),
+		Data:     tx.Data(),
+		Nonce:    tx.Nonce(),
+		GasPrice: metadata.GasPrice,
+		GasLimit: tx.Gas(),
+		ChainID:  s.config.ChainID,
+		Currency: approval.Currency,
+	}
+	return tx, unsignedTx, &approval.Owner, nil
+}
+
+func createErc20ApproveOps(tx transaction) ([]*types.Operation, *string, *types.Error) {
+	spender, amount, err := parseErc20ApproveData(tx.Data)
+	if err != nil {
+		return nil, nil, WrapError(ErrInvalidInput, err)
+	}
+
+	checkFrom, ok := ChecksumAddress(tx.From)
+	if !ok {
+		return nil, nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", tx.From))
+	}
+
+	ops := []*types.Operation{
+		erc20ApproveOperation(&erc20Approval{
+			Owner:    checkFrom,
+			Spender:  spender.Hex(),
+			Amount:   amount,
+			Currency: tx.Currency,
+		}),
+	}
+	return ops, &checkFrom, nil
+}
+
+func erc20ApproveOperation(approval *erc20Approval) *types.Operation {
+	return &types.Operation{
+		Type: mapper.OpErc20Approve,
+		OperationIdentifier: &types.OperationIdentifier{
+			Index: 0,
+		},
+		Account: &types.AccountIdentifier{
+			Address: approval.Owner,
+		},
+		Amount: &types.Amount{
+			Value:    approval.Amount.String(),
+			Currency: approval.Currency,
+		},
+		Metadata: map[string]interface{}{
+			mapper.SpenderMetadata: approval.Spender,
+		},
+	}
+}
+
+func generateErc20ApproveData(spenderAddress string, amount *big.Int) []byte {
+	spender := common.HexToAddress(spenderAddress)
+	methodID := getMethodID(approveFnSignature)
+
+	var data []byte
+	data = append(data, methodID...)
+	data = append(data, common.LeftPadBytes(spender.Bytes(), requiredPaddingBytes)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), requiredPaddingBytes)...)
+	return data
+}
+
+func parseErc20ApproveData(data []byte) (*common.Address, *big.Int, error) {
+	if len(data) != genericTransferBytesLength {
+		return nil, nil, errors.New("incorrect length for data array")
+	}
+	if hexutil.Encode(data[:4]) != approveMethodID {
+		return nil, nil, errors.New("incorrect methodID signature")
+	}
+
+	spender := common.BytesToAddress(data[4:36])
+	amount := new(big.Int).SetBytes(data[36:])
+	return &spender, amount, nil
+}
+
+// decodeStringResponse decodes the ABI encoded string returned by a contract call
+func decodeStringResponse(response []byte) (string, error) {
+	stringType, _ := abi.NewType("string", "", nil)
+	values, err := abi.Arguments{{Type: stringType}}.Unpack(response)
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	rosConst "github.com/ava-labs/avalanche-rosetta/constants"
+)
+
+func TestErc20ApproveConstruction(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientMock := client.NewMockClient(ctrl)
+	skippedBackend := NewMockConstructionBackend(ctrl)
+	skippedBackend.EXPECT().ShouldHandleRequest(gomock.Any()).Return(false).AnyTimes()
+	chainID := big.NewInt(rosConst.FujiChainID)
+	service := ConstructionService{
+		config: &Config{
+			Mode:    ModeOnline,
+			ChainID: chainID,
+		},
+		client:                clientMock,
+		pChainBackend:         skippedBackend,
+		cChainAtomicTxBackend: skippedBackend,
+	}
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    rosConst.FujiNetwork,
+		Blockchain: BlockchainName,
+	}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	spender := common.HexToAddress(defaultToAddress)
+	contract := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	currency := mapper.ToCurrency("TEST", 18, contract)
+
+	approveOps := func(amount string) []*types.Operation {
+		return []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                mapper.OpErc20Approve,
+				Account:             &types.AccountIdentifier{Address: owner.Hex()},
+				Amount:              &types.Amount{Value: amount, Currency: currency},
+				Metadata:            map[string]interface{}{mapper.SpenderMetadata: spender.Hex()},
+			},
+		}
+	}
+
+	t.Run("approve builds an approve transaction", func(t *testing.T) {
+		for _, amount := range []string{"1000000", "0"} {
+			ops := approveOps(amount)
+			preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+			})
+			require.Nil(t, terr)
+
+			value, _ := new(big.Int).SetString(amount, 10)
+			data := generateErc20ApproveData(spender.Hex(), value)
+			require.Equal(t, hexutil.Encode(data), preprocessResponse.Options["data"])
+
+			clientMock.EXPECT().NonceAt(ctx, owner, nil).Return(uint64(3), nil)
+			clientMock.EXPECT().SuggestGasPrice(ctx).Return(big.NewInt(25_000_000_000), nil)
+			clientMock.EXPECT().EstimateGas(ctx, interfaces.CallMsg{
+				From: owner,
+				To:   &contract,
+				Data: data,
+			}).Return(uint64(46_000), nil)
+			metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+				NetworkIdentifier: networkIdentifier,
+				Options:           preprocessResponse.Options,
+			})
+			require.Nil(t, terr)
+
+			payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+				Metadata:          metadataResponse.Metadata,
+			})
+			require.Nil(t, terr)
+
+			var unsignedTx transaction
+			require.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+			require.Equal(t, contract.Hex(), unsignedTx.To)
+			require.Zero(t, unsignedTx.Value.Sign())
+			require.Equal(t, data, unsignedTx.Data)
+
+			parseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+				NetworkIdentifier: networkIdentifier,
+				Transaction:       payloadsResponse.UnsignedTransaction,
+			})
+			require.Nil(t, terr)
+			require.Equal(t, ops, parseResponse.Operations)
+		}
+	})
+
+	t.Run("approve requires a spender", func(t *testing.T) {
+		ops := approveOps("1")
+		ops[0].Metadata = nil
+		_, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+
+	// domain separator of the token, computed as in the token contract
+	domainSeparator := func(version string) []byte {
+		return crypto.Keccak256(
+			crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)")),
+			crypto.Keccak256([]byte("Test Token")),
+			crypto.Keccak256([]byte(version)),
+			common.LeftPadBytes(chainID.Bytes(), 32),
+			common.LeftPadBytes(contract.Bytes(), 32),
+		)
+	}
+	nameResponse := hexutil.MustDecode("0x" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000a" +
+		"5465737420546f6b656e00000000000000000000000000000000000000000000",
+	)
+	expectTokenCalls := func(version string) {
+		clientMock.EXPECT().CallContract(ctx, gomock.Any(), nil).DoAndReturn(
+			func(_ context.Context, msg interfaces.CallMsg, _ *big.Int) ([]byte, error) {
+				require.Equal(t, contract, *msg.To)
+				switch hexutil.Encode(msg.Data[:4]) {
+				case hexutil.Encode(getMethodID(noncesFnSignature)):
+					require.Equal(t, common.LeftPadBytes(owner.Bytes(), 32), msg.Data[4:])
+					return common.LeftPadBytes([]byte{2}, 32), nil
+				case hexutil.Encode(getMethodID(nameFnSignature)):
+					return nameResponse, nil
+				default:
+					require.Equal(t, getMethodID(domainSeparatorFnSignature), msg.Data)
+					return domainSeparator(version), nil
+				}
+			},
+		).Times(3)
+	}
+
+	permitRequestMetadata := map[string]interface{}{
+		"permit":   true,
+		"deadline": "1700000000",
+	}
+
+	t.Run("permit returns typed data and combines into a signature tuple", func(t *testing.T) {
+		ops := approveOps("1000000")
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          permitRequestMetadata,
+		})
+		require.Nil(t, terr)
+
+		expectTokenCalls("1")
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, "Test Token", metadataResponse.Metadata["name"])
+		require.Equal(t, "2", metadataResponse.Metadata["nonce"])
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		require.Nil(t, terr)
+
+		// EIP-712 hash of the permit, computed as in the token contract
+		permitTypeHash := crypto.Keccak256([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+		structHash := crypto.Keccak256(
+			permitTypeHash,
+			common.LeftPadBytes(owner.Bytes(), 32),
+			common.LeftPadBytes(spender.Bytes(), 32),
+			common.LeftPadBytes(big.NewInt(1_000_000).Bytes(), 32),
+			common.LeftPadBytes(big.NewInt(2).Bytes(), 32),
+			common.LeftPadBytes(big.NewInt(1_700_000_000).Bytes(), 32),
+		)
+		digest := crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator("1"), structHash)
+		require.Len(t, payloadsResponse.Payloads, 1)
+		payload := payloadsResponse.Payloads[0]
+		require.Equal(t, digest, payload.Bytes)
+		require.Equal(t, owner.Hex(), payload.AccountIdentifier.Address)
+
+		parseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Transaction:       payloadsResponse.UnsignedTransaction,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, ops, parseResponse.Operations)
+
+		// only the owner may sign the permit
+		otherKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		otherSignature, err := crypto.Sign(digest, otherKey)
+		require.NoError(t, err)
+		_, terr = service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			NetworkIdentifier:   networkIdentifier,
+			UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+			Signatures:          []*types.Signature{{SigningPayload: payload, Bytes: otherSignature}},
+		})
+		require.Equal(t, ErrSignerMismatch.Code, terr.Code)
+
+		signature, err := crypto.Sign(digest, key)
+		require.NoError(t, err)
+		combineResponse, terr := service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			NetworkIdentifier:   networkIdentifier,
+			UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+			Signatures:          []*types.Signature{{SigningPayload: payload, Bytes: signature}},
+		})
+		require.Nil(t, terr)
+
+		var signed permitPayload
+		require.NoError(t, json.Unmarshal([]byte(combineResponse.SignedTransaction), &signed))
+		require.Equal(t, signature[64]+27, signed.Signature.V)
+		require.Equal(t, hexutil.Encode(signature[:32]), signed.Signature.R)
+		require.Equal(t, hexutil.Encode(signature[32:64]), signed.Signature.S)
+
+		parseResponse, terr = service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Signed:            true,
+			Transaction:       combineResponse.SignedTransaction,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, ops, parseResponse.Operations)
+		require.Equal(t, []*types.AccountIdentifier{{Address: owner.Hex()}}, parseResponse.AccountIdentifierSigners)
+
+		hashResponse, terr := service.ConstructionHash(ctx, &types.ConstructionHashRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: combineResponse.SignedTransaction,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, hexutil.Encode(digest), hashResponse.TransactionIdentifier.Hash)
+
+		_, terr = service.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: combineResponse.SignedTransaction,
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+
+	t.Run("permit rejects a domain of another version", func(t *testing.T) {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        approveOps("1"),
+			Metadata:          permitRequestMetadata,
+		})
+		require.Nil(t, terr)
+
+		expectTokenCalls("2")
+		_, terr = service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+		require.Contains(t, terr.Details["error"], "permit_version")
+	})
+
+	t.Run("permit requires a deadline", func(t *testing.T) {
+		_, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        approveOps("1"),
+			Metadata:          map[string]interface{}{"permit": true},
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+
+	t.Run("permit rejects negative deadlines", func(t *testing.T) {
+		_, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        approveOps("1"),
+			Metadata:          map[string]interface{}{"permit": true, "deadline": float64(-1)},
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+		require.Contains(t, terr.Details["error"], "not a valid deadline")
+	})
+
+	t.Run("permit rejects a currency of another contract", func(t *testing.T) {
+		otherContract := common.HexToAddress("0x0000000000000000000000000000000000000001")
+		payload, err := json.Marshal(&permitPayload{
+			TypedData: permitTypedData(chainID, otherContract, owner.Hex(), spender.Hex(), big.NewInt(1), &permitMetadata{
+				Permit:   true,
+				Name:     "Test Token",
+				Version:  "1",
+				Nonce:    "2",
+				Deadline: 1_700_000_000,
+			}),
+			Currency: currency,
+		})
+		require.NoError(t, err)
+
+		_, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Transaction:       string(payload),
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+		require.Contains(t, terr.Details["error"], "is signed for the contract")
+	})
+}
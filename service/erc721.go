@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+const (
+	// supportsInterface(bytes4)
+	supportsInterfaceMethodID = "0x01ffc9a7"
+	// tokenOfOwnerByIndex(address,uint256)
+	tokenOfOwnerByIndexMethodID = "0x2f745c59"
+	// ERC165 interface ID of ERC721Enumerable
+	erc721EnumerableInterfaceID = "780e9d63"
+
+	safeTransferFromFnSignature = "safeTransferFrom(address,address,uint256)"
+	// erc721TransferBytesLength is the length of the safeTransferFrom call data
+	erc721TransferBytesLength = 100
+
+	// maxErc721OwnedTokenIDs is the number of owned token IDs listed at most
+	maxErc721OwnedTokenIDs = 1000
+)
+
+var safeTransferFromMethodID = hexutil.Encode(getMethodID(safeTransferFromFnSignature))
+
+// erc721Transfer is the intent of ERC721_SENDER and ERC721_RECEIVE operations
+type erc721Transfer struct {
+	From     string
+	To       string
+	TokenID  *big.Int
+	Currency *types.Currency
+	Contract common.Address
+}
+
+// isErc721Currency returns true if [currency] is flagged as an ERC721 contract
+func isErc721Currency(currency *types.Currency) bool {
+	isErc721, _ := currency.Metadata[mapper.Erc721Metadata].(bool)
+	return isErc721
+}
+
+// erc721OwnedTokenIDs returns the IDs of the [balance] tokens of [contract] owned by [owner].
+// Token IDs are enumerated on chain if [contract] implements ERC721Enumerable. Otherwise
+// they are looked up in [index], if set and complete up to [blockNumber]. The second value
+// is false if the token IDs could not be determined, which is also the case when [owner]
+// holds more than [maxErc721OwnedTokenIDs] tokens.
+func erc721OwnedTokenIDs(
+	ctx context.Context,
+	c client.Client,
+	index Erc721OwnerIndex,
+	contract common.Address,
+	owner common.Address,
+	balance *big.Int,
+	blockNumber *big.Int,
+) ([]string, bool, error) {
+	if !balance.IsUint64() || balance.Uint64() > maxErc721OwnedTokenIDs {
+		return nil, false, nil
+	}
+	count := balance.Uint64()
+
+	if isErc721Enumerable(ctx, c, contract, blockNumber) {
+		calls := make([]interfaces.CallMsg, 0, count)
+		for i := uint64(0); i < count; i++ {
+			data := hexutil.MustDecode(tokenOfOwnerByIndexMethodID)
+			data = append(data, common.LeftPadBytes(owner.Bytes(), 32)...)
+			data = append(data, common.LeftPadBytes(new(big.Int).SetUint64(i).Bytes(), 32)...)
+			calls = append(calls, interfaces.CallMsg{To: &contract, Data: data})
+		}
+
+		responses, err := batchCallContract(ctx, c, calls, blockNumber)
+		if err != nil {
+			return nil, false, err
+		}
+
+		tokenIDs := make([]string, 0, len(responses))
+		for _, response := range responses {
+			tokenIDs = append(tokenIDs, common.BytesToHash(response).String())
+		}
+		return tokenIDs, true, nil
+	}
+
+	if index != nil && blockNumber != nil && blockNumber.IsUint64() {
+		return index.OwnedTokenIDs(contract, owner, blockNumber.Uint64())
+	}
+	return nil, false, nil
+}
+
+// isErc721Enumerable returns true if [contract] reports ERC721Enumerable support
+// through ERC165
+func isErc721Enumerable(ctx context.Context, c client.Client, contract common.Address, blockNumber *big.Int) bool {
+	data := hexutil.MustDecode(supportsInterfaceMethodID + erc721EnumerableInterfaceID + "00000000000000000000000000000000000000000000000000000000")
+	response, err := c.CallContract(ctx, interfaces.CallMsg{To: &contract, Data: data}, blockNumber)
+	if err != nil {
+		return false
+	}
+	return common.BytesToHash(response).Big().Cmp(common.Big1) == 0
+}
+
+// Erc721OwnerIndex lists the ERC721 tokens owned by an account from the
+// transfers it recorded
+type Erc721OwnerIndex interface {
+	// OwnedTokenIDs returns the IDs of the tokens of [contract] owned by [owner] at block
+	// [blockNumber]. The second value is false if the index does not cover every block
+	// up to [blockNumber].
+	OwnedTokenIDs(contract common.Address, owner common.Address, blockNumber uint64) ([]string, bool, error)
+}
+
+func isErc721TransferRequest(operations []*types.Operation) bool {
+	return len(operations) > 0 && operations[0].Type == mapper.OpErc721TransferSender
+}
+
+// matchErc721TransferOperations returns the transfer of [operations]: an
+// ERC721_SENDER operation of -1 token and an ERC721_RECEIVE operation of 1
+// token of an ERC721 currency, whose metadata holds the ID of the token
+func matchErc721TransferOperations(operations []*types.Operation) (*erc721Transfer, *types.Error) {
+	if len(operations) != 2 || operations[0].Amount == nil || operations[0].Amount.Currency == nil {
+		return nil, WrapError(ErrInvalidInput, "ERC721 transfers require a sender and a receiver operation with an amount")
+	}
+
+	currency := operations[0].Amount.Currency
+	contract, ok := currency.Metadata[mapper.ContractAddressMetadata].(string)
+	if !ok || !common.IsHexAddress(contract) || !isErc721Currency(currency) {
+		return nil, WrapError(ErrInvalidInput, "ERC721 currency must have contractAddress and erc721 in metadata")
+	}
+
+	descriptions := &parser.Descriptions{
+		OperationDescriptions: []*parser.OperationDescription{
+			{
+				Type:    mapper.OpErc721TransferSender,
+				Account: &parser.AccountDescription{Exists: true},
+				Amount: &parser.AmountDescription{
+					Exists:   true,
+					Sign:     parser.NegativeAmountSign,
+					Currency: currency,
+				},
+				Metadata: []*parser.MetadataDescription{
+					{Key: mapper.IndexTransferredMetadata, ValueKind: reflect.String},
+				},
+			},
+			{
+				Type:    mapper.OpErc721TransferReceive,
+				Account: &parser.AccountDescription{Exists: true},
+				Amount: &parser.AmountDescription{
+					Exists:   true,
+					Sign:     parser.PositiveAmountSign,
+					Currency: currency,
+				},
+				Metadata: []*parser.MetadataDescription{
+					{Key: mapper.IndexTransferredMetadata, ValueKind: reflect.String},
+				},
+			},
+		},
+		ErrUnmatched: true,
+	}
+	matches, err := parser.MatchOperations(descriptions, operations)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, "unclear intent")
+	}
+
+	fromOp, fromAmount := matches[0].First()
+	toOp, toAmount := matches[1].First()
+	if fromAmount.Cmp(big.NewInt(-1)) != 0 || toAmount.Cmp(common.Big1) != 0 {
+		return nil, WrapError(ErrInvalidInput, "ERC721 transfers move a single token")
+	}
+
+	tokenID, err := erc721TokenID(fromOp.Metadata[mapper.IndexTransferredMetadata].(string))
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+	toTokenID, err := erc721TokenID(toOp.Metadata[mapper.IndexTransferredMetadata].(string))
+	if err != nil || toTokenID.Cmp(tokenID) != 0 {
+		return nil, WrapError(ErrInvalidInput, "sender and receiver operations must transfer the same token")
+	}
+
+	checkFrom, ok := ChecksumAddress(fromOp.Account.Address)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", fromOp.Account.Address))
+	}
+	checkTo, ok := ChecksumAddress(toOp.Account.Address)
+	if !ok {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", toOp.Account.Address))
+	}
+
+	return &erc721Transfer{
+		From:     checkFrom,
+		To:       checkTo,
+		TokenID:  tokenID,
+		Currency: currency,
+		Contract: common.HexToAddress(contract),
+	}, nil
+}
+
+func createErc721TransferPreprocessOptions(req *types.ConstructionPreprocessRequest) (*options, *types.Error) {
+	transfer, terr := matchErc721TransferOperations(req.Operations)
+	if terr != nil {
+		return nil, terr
+	}
+
+	// the gas limit is estimated as for any contract call
+	return &options{
+		From:                   transfer.From,
+		To:                     transfer.Contract.Hex(),
+		Value:                  big.NewInt(0),
+		SuggestedFeeMultiplier: req.SuggestedFeeMultiplier,
+		Currency:               transfer.Currency,
+		ContractAddress:        transfer.Contract.Hex(),
+		ContractData:           hexutil.Encode(generateErc721TransferData(transfer.From, transfer.To, transfer.TokenID)),
+	}, nil
+}
+
+func (s ConstructionService) createErc721TransferPayload(
+	req *types.ConstructionPayloadsRequest,
+) (*ethtypes.Transaction, *transaction, *string, *types.Error) {
+	transfer, terr := matchErc721TransferOperations(req.Operations)
+	if terr != nil {
+		return nil, nil, nil, terr
+	}
+
+	var metadata metadata
+	if err := mapper.UnmarshalJSONMap(req.Metadata, &metadata); err != nil {
+		return nil, nil, nil, WrapError(ErrInvalidInput, err)
+	}
+
+	tx := ethtypes.NewTransaction(
+		metadata.Nonce,
+		transfer.Contract,
+		big.NewInt(0),
+		metadata.GasLimit,
+		metadata.GasPrice,
+		generateErc721TransferData(transfer.From, transfer.To, transfer.TokenID),
+	)
+
+	unsignedTx := &transaction{
+		From:     transfer.From,
+		To:       transfer.Contract.Hex(),
+		Value:    big.NewInt(0),
+		Data:     tx.Data(),
+		Nonce:    tx.Nonce(),
+		GasPrice: metadata.GasPrice,
+		GasLimit: tx.Gas(),
+		ChainID:  s.config.ChainID,
+		Currency: transfer.Currency,
+	}
+	return tx, unsignedTx, &transfer.From, nil
+}
+
+func createErc721TransferOps(tx transaction) ([]*types.Operation, *string, *types.Error) {
+	from, to, tokenID, err := parseErc721TransferData(tx.Data)
+	if err != nil {
+		return nil, nil, WrapError(ErrInvalidInput, err)
+	}
+
+	checkFrom, ok := ChecksumAddress(tx.From)
+	if !ok {
+		return nil, nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", tx.From))
+	}
+
+	metadata := map[string]interface{}{
+		mapper.IndexTransferredMetadata: common.BigToHash(tokenID).String(),
+	}
+	ops := []*types.Operation{
+		{
+			Type: mapper.OpErc721TransferSender,
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 0,
+			},
+			Account: &types.AccountIdentifier{
+				Address: from.Hex(),
+			},
+			Amount: &types.Amount{
+				Value:    "-1",
+				Currency: tx.Currency,
+			},
+			Metadata: metadata,
+		},
+		{
+			Type: mapper.OpErc721TransferReceive,
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 1,
+			},
+			RelatedOperations: []*types.OperationIdentifier{
+				{
+					Index: 0,
+				},
+			},
+			Account: &types.AccountIdentifier{
+				Address: to.Hex(),
+			},
+			Amount: &types.Amount{
+				Value:    "1",
+				Currency: tx.Currency,
+			},
+			Metadata: metadata,
+		},
+	}
+	return ops, &checkFrom, nil
+}
+
+func generateErc721TransferData(fromAddress string, toAddress string, tokenID *big.Int) []byte {
+	from := common.HexToAddress(fromAddress)
+	to := common.HexToAddress(toAddress)
+	methodID := getMethodID(safeTransferFromFnSignature)
+
+	var data []byte
+	data = append(data, methodID...)
+	data = append(data, common.LeftPadBytes(from.Bytes(), requiredPaddingBytes)...)
+	data = append(data, common.LeftPadBytes(to.Bytes(), requiredPaddingBytes)...)
+	data = append(data, common.LeftPadBytes(tokenID.Bytes(), requiredPaddingBytes)...)
+	return data
+}
+
+func parseErc721TransferData(data []byte) (*common.Address, *common.Address, *big.Int, error) {
+	if len(data) != erc721TransferBytesLength {
+		return nil, nil, nil, errors.New("incorrect length for data array")
+	}
+	if hexutil.Encode(data[:4]) != safeTransferFromMethodID {
+		return nil, nil, nil, errors.New("incorrect methodID signature")
+	}
+
+	from := common.BytesToAddress(data[4:36])
+	to := common.BytesToAddress(data[36:68])
+	tokenID := new(big.Int).SetBytes(data[68:])
+	return &from, &to, tokenID, nil
+}
+
+// erc721TokenID parses the decimal or 0x-prefixed hex [tokenID], as reported
+// in the indexTransferred metadata of /block operations
+func erc721TokenID(tokenID string) (*big.Int, error) {
+	var (
+		id = new(big.Int)
+		ok bool
+	)
+	if has0xPrefix(tokenID) {
+		_, ok = id.SetString(tokenID[2:], 16)
+	} else {
+		_, ok = id.SetString(tokenID, base10)
+	}
+	if !ok || id.Sign() < 0 || id.BitLen() > 256 {
+		return nil, fmt.Errorf("%s is not a valid token ID", tokenID)
+	}
+	return id, nil
+}
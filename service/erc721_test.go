@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	rosConst "github.com/ava-labs/avalanche-rosetta/constants"
+)
+
+// erc721OwnerIndex is an Erc721OwnerIndex covering the blocks up to [head]
+type erc721OwnerIndex struct {
+	head     uint64
+	tokenIDs []string
+}
+
+func (i *erc721OwnerIndex) OwnedTokenIDs(_ common.Address, _ common.Address, blockNumber uint64) ([]string, bool, error) {
+	if blockNumber > i.head {
+		return nil, false, nil
+	}
+	return i.tokenIDs, true, nil
+}
+
+func TestErc721OwnedTokenIDs(t *testing.T) {
+	var (
+		contract    = common.HexToAddress("0x9aA7BDC2c5A2b5A2d3E0c0D6b2f1D5B8c8C3b6e1")
+		owner       = common.HexToAddress("0x197E90f9FAD81970bA7976f33CbD77088E5D7cf7")
+		blockNumber = big.NewInt(42)
+		supports    = gomock.Cond(func(x any) bool {
+			return common.Bytes2Hex(x.(interfaces.CallMsg).Data[:4]) == supportsInterfaceMethodID[2:]
+		})
+	)
+
+	t.Run("enumerable contract", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		clientMock := client.NewMockClient(ctrl)
+
+		clientMock.EXPECT().CallContract(gomock.Any(), supports, blockNumber).Return(common.BigToHash(common.Big1).Bytes(), nil)
+		clientMock.EXPECT().CallContract(gomock.Any(), gomock.Any(), blockNumber).Return(common.BigToHash(big.NewInt(7)).Bytes(), nil)
+
+		tokenIDs, ok, err := erc721OwnedTokenIDs(context.Background(), clientMock, nil, contract, owner, big.NewInt(1), blockNumber)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []string{common.BigToHash(big.NewInt(7)).String()}, tokenIDs)
+	})
+
+	t.Run("non enumerable contract without index", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		clientMock := client.NewMockClient(ctrl)
+
+		clientMock.EXPECT().CallContract(gomock.Any(), supports, blockNumber).Return(common.BigToHash(common.Big0).Bytes(), nil)
+
+		tokenIDs, ok, err := erc721OwnedTokenIDs(context.Background(), clientMock, nil, contract, owner, big.NewInt(1), blockNumber)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Nil(t, tokenIDs)
+	})
+
+	t.Run("non enumerable contract with index", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		clientMock := client.NewMockClient(ctrl)
+
+		clientMock.EXPECT().CallContract(gomock.Any(), supports, blockNumber).Return(nil, nil)
+
+		index := &erc721OwnerIndex{head: blockNumber.Uint64(), tokenIDs: []string{common.BigToHash(big.NewInt(7)).String()}}
+
+		tokenIDs, ok, err := erc721OwnedTokenIDs(context.Background(), clientMock, index, contract, owner, big.NewInt(1), blockNumber)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []string{common.BigToHash(big.NewInt(7)).String()}, tokenIDs)
+	})
+
+	t.Run("non enumerable contract with incomplete index", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		clientMock := client.NewMockClient(ctrl)
+
+		clientMock.EXPECT().CallContract(gomock.Any(), supports, blockNumber).Return(nil, nil)
+
+		tokenIDs, ok, err := erc721OwnedTokenIDs(context.Background(), clientMock, &erc721OwnerIndex{}, contract, owner, big.NewInt(0), blockNumber)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Nil(t, tokenIDs)
+	})
+
+	t.Run("too many owned tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		clientMock := client.NewMockClient(ctrl)
+
+		tokenIDs, ok, err := erc721OwnedTokenIDs(context.Background(), clientMock, nil, contract, owner, big.NewInt(maxErc721OwnedTokenIDs+1), blockNumber)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Nil(t, tokenIDs)
+	})
+}
+
+func TestErc721TransferConstruction(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientMock := client.NewMockClient(ctrl)
+	skippedBackend := NewMockConstructionBackend(ctrl)
+	skippedBackend.EXPECT().ShouldHandleRequest(gomock.Any()).Return(false).AnyTimes()
+	service := ConstructionService{
+		config: &Config{
+			Mode:    ModeOnline,
+			ChainID: big.NewInt(rosConst.FujiChainID),
+		},
+		client:                clientMock,
+		pChainBackend:         skippedBackend,
+		cChainAtomicTxBackend: skippedBackend,
+	}
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    rosConst.FujiNetwork,
+		Blockchain: BlockchainName,
+	}
+
+	var (
+		contract = common.HexToAddress("0x9aA7BDC2c5A2b5A2d3E0c0D6b2f1D5B8c8C3b6e1")
+		alice    = common.HexToAddress("0x197E90f9FAD81970bA7976f33CbD77088E5D7cf7")
+		bob      = common.HexToAddress("0x3a4c2ef4a7f9d0a6e7f3b2c1d0e9f8a7b6c5d4e3")
+		tokenID  = common.BigToHash(big.NewInt(42)).String()
+		currency = &types.Currency{
+			Symbol: "NFT",
+			Metadata: map[string]interface{}{
+				mapper.ContractAddressMetadata: contract.Hex(),
+				mapper.Erc721Metadata:          true,
+			},
+		}
+	)
+	transferOps := func(currency *types.Currency, fromValue string, toTokenID string) []*types.Operation {
+		return []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                mapper.OpErc721TransferSender,
+				Account:             &types.AccountIdentifier{Address: alice.Hex()},
+				Amount:              &types.Amount{Value: fromValue, Currency: currency},
+				Metadata:            map[string]interface{}{mapper.IndexTransferredMetadata: tokenID},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+				Type:                mapper.OpErc721TransferReceive,
+				Account:             &types.AccountIdentifier{Address: bob.Hex()},
+				Amount:              &types.Amount{Value: "1", Currency: currency},
+				Metadata:            map[string]interface{}{mapper.IndexTransferredMetadata: toTokenID},
+			},
+		}
+	}
+
+	t.Run("safe transfer flow", func(t *testing.T) {
+		ops := transferOps(currency, "-1", tokenID)
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+		})
+		require.Nil(t, terr)
+
+		data := generateErc721TransferData(alice.Hex(), bob.Hex(), big.NewInt(42))
+		require.Equal(t, safeTransferFromMethodID, hexutil.Encode(data[:4]))
+		clientMock.EXPECT().NonceAt(ctx, alice, nil).Return(uint64(1), nil)
+		clientMock.EXPECT().SuggestGasPrice(ctx).Return(big.NewInt(25_000_000_000), nil)
+		clientMock.EXPECT().EstimateGas(ctx, interfaces.CallMsg{
+			From: alice,
+			To:   &contract,
+			Data: data,
+		}).Return(uint64(60_000), nil)
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		require.Nil(t, terr)
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata:          metadataResponse.Metadata,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, alice.Hex(), payloadsResponse.Payloads[0].AccountIdentifier.Address)
+
+		var unsignedTx transaction
+		require.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+		require.Equal(t, contract.Hex(), unsignedTx.To)
+		require.Equal(t, uint64(60_000), unsignedTx.GasLimit)
+		require.Equal(t, data, unsignedTx.Data)
+
+		parseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Transaction:       payloadsResponse.UnsignedTransaction,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, ops, parseResponse.Operations)
+	})
+
+	t.Run("decimal token IDs", func(t *testing.T) {
+		_, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps(currency, "-1", "42"),
+		})
+		require.Nil(t, terr)
+	})
+
+	t.Run("invalid transfers", func(t *testing.T) {
+		tests := map[string][]*types.Operation{
+			"more than one token": transferOps(currency, "-2", tokenID),
+			"different tokens":    transferOps(currency, "-1", "43"),
+			"not an ERC721":       transferOps(mapper.ToCurrency("TEST", 18, contract), "-1", tokenID),
+		}
+		for name, ops := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+					NetworkIdentifier: networkIdentifier,
+					Operations:        ops,
+				})
+				require.Equal(t, ErrInvalidInput.Code, terr.Code)
+			})
+		}
+	})
+}
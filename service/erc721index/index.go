@@ -0,0 +1,315 @@
+package erc721index
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service/follower"
+)
+
+var (
+	_ follower.Handler = &Index{}
+
+	errNonContiguousBlock = errors.New("block does not extend the indexed chain")
+	errNotHead            = errors.New("block is not the indexed head")
+
+	headKey  = []byte("head")
+	startKey = []byte("start")
+)
+
+// Key prefixes
+const (
+	// ownerPrefix + contract + token ID + inverted block index -> owner,
+	// the zero address once the token is burned
+	ownerPrefix byte = iota
+	// acquiredPrefix + contract + owner + token ID + inverted block index,
+	// for every block in which the owner acquired the token
+	acquiredPrefix
+	// blockPrefix + block index -> block identifier
+	blockPrefix
+	// changesPrefix + block index -> ownership changes of the block
+	changesPrefix
+)
+
+// changeLen is the length of an encoded ownership change: contract, token ID
+// and new owner
+const changeLen = common.AddressLength + common.HashLength + common.AddressLength
+
+type change struct {
+	contract common.Address
+	tokenID  common.Hash
+	owner    common.Address
+}
+
+// Index records the owners of the ERC721 tokens transferred in the blocks of
+// a network. It is used to list the tokens owned by an account for contracts
+// that do not implement ERC721Enumerable.
+type Index struct {
+	db database.Database
+}
+
+// New returns the Index of [network] stored in [db]
+func New(db database.Database, network *types.NetworkIdentifier) *Index {
+	return &Index{
+		db: prefixdb.New([]byte(types.Hash(network)), db),
+	}
+}
+
+// Head returns the last indexed block, or nil if no block was indexed yet
+func (i *Index) Head() (*types.BlockIdentifier, error) {
+	b, err := i.db.Get(headKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	head := &types.BlockIdentifier{}
+	if err := json.Unmarshal(b, head); err != nil {
+		return nil, err
+	}
+	return head, nil
+}
+
+// AddBlock records the ERC721 transfers of [block] and makes it the head
+func (i *Index) AddBlock(block *types.Block) error {
+	head, err := i.Head()
+	if err != nil {
+		return err
+	}
+	if head != nil && block.BlockIdentifier.Index != head.Index+1 {
+		return errNonContiguousBlock
+	}
+
+	changes := blockChanges(block)
+	ref := blockRef(block.BlockIdentifier.Index)
+	batch := i.db.NewBatch()
+	encoded := make([]byte, 0, len(changes)*changeLen)
+	for _, c := range changes {
+		if err := batch.Put(ownerKey(c.contract, c.tokenID, ref), c.owner.Bytes()); err != nil {
+			return err
+		}
+		if c.owner != (common.Address{}) {
+			if err := batch.Put(acquiredKey(c.contract, c.owner, c.tokenID, ref), nil); err != nil {
+				return err
+			}
+		}
+		encoded = append(encoded, c.contract.Bytes()...)
+		encoded = append(encoded, c.tokenID.Bytes()...)
+		encoded = append(encoded, c.owner.Bytes()...)
+	}
+	if err := batch.Put(indexKey(changesPrefix, block.BlockIdentifier.Index), encoded); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(block.BlockIdentifier)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(indexKey(blockPrefix, block.BlockIdentifier.Index), b); err != nil {
+		return err
+	}
+	if head == nil {
+		if err := batch.Put(startKey, ref); err != nil {
+			return err
+		}
+	}
+	if err := batch.Put(headKey, b); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// RemoveBlock removes the ERC721 transfers of [block], the head, from the
+// index and makes its parent the head
+func (i *Index) RemoveBlock(block *types.BlockIdentifier) error {
+	head, err := i.Head()
+	if err != nil {
+		return err
+	}
+	if head == nil || types.Hash(head) != types.Hash(block) {
+		return errNotHead
+	}
+
+	encoded, err := i.db.Get(indexKey(changesPrefix, block.Index))
+	if err != nil {
+		return err
+	}
+	ref := blockRef(block.Index)
+	batch := i.db.NewBatch()
+	for len(encoded) >= changeLen {
+		c := change{
+			contract: common.BytesToAddress(encoded[:common.AddressLength]),
+			tokenID:  common.BytesToHash(encoded[common.AddressLength : common.AddressLength+common.HashLength]),
+			owner:    common.BytesToAddress(encoded[common.AddressLength+common.HashLength : changeLen]),
+		}
+		encoded = encoded[changeLen:]
+
+		if err := batch.Delete(ownerKey(c.contract, c.tokenID, ref)); err != nil {
+			return err
+		}
+		if err := batch.Delete(acquiredKey(c.contract, c.owner, c.tokenID, ref)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Delete(indexKey(changesPrefix, block.Index)); err != nil {
+		return err
+	}
+
+	if err := batch.Delete(indexKey(blockPrefix, block.Index)); err != nil {
+		return err
+	}
+	parent, err := i.db.Get(indexKey(blockPrefix, block.Index-1))
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		if err = batch.Delete(headKey); err == nil {
+			err = batch.Delete(startKey)
+		}
+	case err == nil:
+		err = batch.Put(headKey, parent)
+	}
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// OwnedTokenIDs returns the IDs of the tokens of [contract] owned by [owner] at
+// block [blockNumber]. The second value is false if the index does not cover
+// every block from genesis up to [blockNumber].
+func (i *Index) OwnedTokenIDs(contract common.Address, owner common.Address, blockNumber uint64) ([]string, bool, error) {
+	head, err := i.Head()
+	if err != nil || head == nil || uint64(head.Index) < blockNumber {
+		return nil, false, err
+	}
+	start, err := i.db.Get(startKey)
+	if err != nil {
+		return nil, false, err
+	}
+	// the genesis block transfers no token, so the index is complete when it
+	// starts at genesis or at the block following it
+	if parseBlockRef(start) > 1 {
+		return nil, false, nil
+	}
+
+	prefix := acquiredKey(contract, owner, common.Hash{}, nil)[:1+2*common.AddressLength]
+	it := i.db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	tokenIDs := []string{}
+	seen := map[common.Hash]struct{}{}
+	for it.Next() {
+		key := it.Key()[len(prefix):]
+		tokenID := common.BytesToHash(key[:common.HashLength])
+		if parseBlockRef(key[common.HashLength:]) > int64(blockNumber) {
+			continue
+		}
+		if _, ok := seen[tokenID]; ok {
+			continue
+		}
+		seen[tokenID] = struct{}{}
+
+		current, err := i.ownerAt(contract, tokenID, blockNumber)
+		if err != nil {
+			return nil, false, err
+		}
+		if current == owner {
+			tokenIDs = append(tokenIDs, tokenID.String())
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, false, err
+	}
+	sort.Strings(tokenIDs)
+	return tokenIDs, true, nil
+}
+
+// ownerAt returns the owner of [tokenID] of [contract] at block [blockNumber]
+func (i *Index) ownerAt(contract common.Address, tokenID common.Hash, blockNumber uint64) (common.Address, error) {
+	prefix := ownerKey(contract, tokenID, nil)
+	it := i.db.NewIteratorWithStartAndPrefix(ownerKey(contract, tokenID, blockRef(int64(blockNumber))), prefix)
+	defer it.Release()
+
+	if !it.Next() {
+		return common.Address{}, it.Error()
+	}
+	return common.BytesToAddress(it.Value()), nil
+}
+
+// blockChanges returns the ownership changes of the ERC721 tokens transferred
+// in [block]. Only the last owner of a token within the block is recorded.
+func blockChanges(block *types.Block) []change {
+	type token struct {
+		contract common.Address
+		tokenID  common.Hash
+	}
+
+	owners := map[token]common.Address{}
+	tokens := []token{}
+	for _, tx := range block.Transactions {
+		for _, op := range tx.Operations {
+			var owner common.Address
+			switch op.Type {
+			case mapper.OpErc721Mint, mapper.OpErc721TransferReceive:
+				owner = common.HexToAddress(op.Account.Address)
+			case mapper.OpErc721Burn:
+			default:
+				continue
+			}
+
+			contract, _ := op.Metadata[mapper.ContractAddressMetadata].(string)
+			tokenID, _ := op.Metadata[mapper.IndexTransferredMetadata].(string)
+			t := token{
+				contract: common.HexToAddress(contract),
+				tokenID:  common.HexToHash(tokenID),
+			}
+			if _, ok := owners[t]; !ok {
+				tokens = append(tokens, t)
+			}
+			owners[t] = owner
+		}
+	}
+
+	changes := make([]change, 0, len(tokens))
+	for _, t := range tokens {
+		changes = append(changes, change{contract: t.contract, tokenID: t.tokenID, owner: owners[t]})
+	}
+	return changes
+}
+
+// blockRef returns the inverted block [index], so that iterating over the
+// keys suffixed by it yields the most recent blocks first
+func blockRef(index int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, ^uint64(index))
+	return b
+}
+
+func parseBlockRef(b []byte) int64 {
+	return int64(^binary.BigEndian.Uint64(b))
+}
+
+func indexKey(prefix byte, index int64) []byte {
+	key := make([]byte, 1+8)
+	key[0] = prefix
+	binary.BigEndian.PutUint64(key[1:], uint64(index))
+	return key
+}
+
+func ownerKey(contract common.Address, tokenID common.Hash, ref []byte) []byte {
+	return bytes.Join([][]byte{{ownerPrefix}, contract.Bytes(), tokenID.Bytes(), ref}, nil)
+}
+
+func acquiredKey(contract common.Address, owner common.Address, tokenID common.Hash, ref []byte) []byte {
+	return bytes.Join([][]byte{{acquiredPrefix}, contract.Bytes(), owner.Bytes(), tokenID.Bytes(), ref}, nil)
+}
@@ -0,0 +1,117 @@
+package erc721index
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+func TestIndex(t *testing.T) {
+	var (
+		network  = &types.NetworkIdentifier{Blockchain: "Avalanche", Network: "Fuji"}
+		contract = common.HexToAddress("0x9aA7BDC2c5A2b5A2d3E0c0D6b2f1D5B8c8C3b6e1")
+		alice    = common.HexToAddress("0x197E90f9FAD81970bA7976f33CbD77088E5D7cf7")
+		bob      = common.HexToAddress("0x3a4c2ef4a7f9d0a6e7f3b2c1d0e9f8a7b6c5d4e3")
+		token1   = common.BigToHash(big.NewInt(1))
+		token2   = common.BigToHash(big.NewInt(2))
+	)
+
+	op := func(opType string, account common.Address, tokenID common.Hash) *types.Operation {
+		return &types.Operation{
+			Type:    opType,
+			Account: &types.AccountIdentifier{Address: account.Hex()},
+			Metadata: map[string]interface{}{
+				mapper.ContractAddressMetadata:  contract.String(),
+				mapper.IndexTransferredMetadata: tokenID.String(),
+			},
+		}
+	}
+	blocks := map[int64][]*types.Operation{
+		10: {
+			op(mapper.OpErc721Mint, alice, token1),
+			op(mapper.OpErc721Mint, alice, token2),
+		},
+		20: {
+			op(mapper.OpErc721TransferSender, alice, token1),
+			op(mapper.OpErc721TransferReceive, bob, token1),
+		},
+		30: {
+			op(mapper.OpErc721Burn, alice, token2),
+		},
+	}
+	block := func(index int64) *types.Block {
+		return &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{Index: index, Hash: big.NewInt(index).String()},
+			Transactions:    []*types.Transaction{{Operations: blocks[index]}},
+		}
+	}
+	addBlocks := func(t *testing.T, index *Index, from int64, to int64) {
+		for height := from; height <= to; height++ {
+			require.NoError(t, index.AddBlock(block(height)))
+		}
+	}
+
+	t.Run("ownership history", func(t *testing.T) {
+		require := require.New(t)
+
+		index := New(memdb.New(), network)
+		addBlocks(t, index, 0, 30)
+		require.ErrorIs(index.AddBlock(block(20)), errNonContiguousBlock)
+
+		owned := func(owner common.Address, blockNumber uint64) []string {
+			tokenIDs, ok, err := index.OwnedTokenIDs(contract, owner, blockNumber)
+			require.NoError(err)
+			require.True(ok)
+			return tokenIDs
+		}
+		require.Empty(owned(alice, 9))
+		require.Equal([]string{token1.String(), token2.String()}, owned(alice, 10))
+		require.Equal([]string{token2.String()}, owned(alice, 25))
+		require.Equal([]string{token1.String()}, owned(bob, 25))
+		require.Empty(owned(alice, 30))
+		require.Empty(owned(common.Address{}, 30))
+
+		// blocks not recorded yet are not covered
+		_, ok, err := index.OwnedTokenIDs(contract, alice, 31)
+		require.NoError(err)
+		require.False(ok)
+
+		// removed blocks are reverted
+		for height := int64(30); height >= 20; height-- {
+			require.NoError(index.RemoveBlock(block(height).BlockIdentifier))
+		}
+		require.Equal([]string{token1.String(), token2.String()}, owned(alice, 19))
+		require.Empty(owned(bob, 19))
+		addBlocks(t, index, 20, 30)
+		require.Equal([]string{token1.String()}, owned(bob, 30))
+	})
+
+	t.Run("index not started from genesis is not used", func(t *testing.T) {
+		require := require.New(t)
+
+		index := New(memdb.New(), network)
+		addBlocks(t, index, 10, 30)
+
+		_, ok, err := index.OwnedTokenIDs(contract, alice, 10)
+		require.NoError(err)
+		require.False(ok)
+	})
+
+	t.Run("index is persisted", func(t *testing.T) {
+		require := require.New(t)
+
+		db := memdb.New()
+		addBlocks(t, New(db, network), 1, 30)
+
+		tokenIDs, ok, err := New(db, network).OwnedTokenIDs(contract, bob, 30)
+		require.NoError(err)
+		require.True(ok)
+		require.Equal([]string{token1.String()}, tokenIDs)
+	})
+}
@@ -19,6 +19,8 @@ var (
 		ErrCallInvalidMethod,
 		ErrCallInvalidParams,
 		ErrTransactionNotFound,
+		ErrSignerMismatch,
+		ErrSigningPayloadMismatch,
 	}
 
 	// General errors
@@ -34,6 +36,10 @@ var (
 	ErrCallInvalidMethod   = makeError(10, "Invalid call method", false)
 	ErrCallInvalidParams   = makeError(11, "invalid call params", false)
 	ErrTransactionNotFound = makeError(12, "Transaction was not found", true)
+
+	// Construction errors
+	ErrSignerMismatch         = makeError(13, "Signature was not produced by the expected signer", false)
+	ErrSigningPayloadMismatch = makeError(14, "Signature was produced for another payload or chain", false)
 )
 
 func makeError(code int32, message string, retriable bool) *types.Error {
@@ -0,0 +1,194 @@
+package events
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/service/follower"
+)
+
+var (
+	_ follower.Handler = &Log{}
+
+	errNonContiguousBlock = errors.New("block does not extend the logged chain")
+	errNotHead            = errors.New("block is not the logged head")
+
+	headKey         = []byte("head")
+	nextSequenceKey = []byte("next")
+)
+
+// Key prefixes
+const (
+	eventPrefix byte = iota
+	blockPrefix
+)
+
+// Log is the sequence-numbered log of the blocks added to, and removed from,
+// the canonical chain of a network
+type Log struct {
+	db database.Database
+
+	// lock serializes the writes, which read the head and the next sequence
+	lock sync.Mutex
+}
+
+// New returns the Log of [network] stored in [db]
+func New(db database.Database, network *types.NetworkIdentifier) *Log {
+	return &Log{
+		db: prefixdb.New([]byte(types.Hash(network)), db),
+	}
+}
+
+func eventKey(sequence int64) []byte {
+	return indexedKey(eventPrefix, sequence)
+}
+
+func blockKey(index int64) []byte {
+	return indexedKey(blockPrefix, index)
+}
+
+func indexedKey(prefix byte, index int64) []byte {
+	key := make([]byte, 1+8)
+	key[0] = prefix
+	binary.BigEndian.PutUint64(key[1:], uint64(index))
+	return key
+}
+
+// Head returns the last block added, or nil if the log is empty
+func (l *Log) Head() (*types.BlockIdentifier, error) {
+	b, err := l.db.Get(headKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	head := &types.BlockIdentifier{}
+	if err := json.Unmarshal(b, head); err != nil {
+		return nil, err
+	}
+	return head, nil
+}
+
+// AddBlock appends a block_added event for [block] and makes it the head
+func (l *Log) AddBlock(block *types.Block) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	head, err := l.Head()
+	if err != nil {
+		return err
+	}
+	if head != nil && block.BlockIdentifier.Index != head.Index+1 {
+		return errNonContiguousBlock
+	}
+
+	b, err := json.Marshal(block.BlockIdentifier)
+	if err != nil {
+		return err
+	}
+
+	batch := l.db.NewBatch()
+	if err := batch.Put(blockKey(block.BlockIdentifier.Index), b); err != nil {
+		return err
+	}
+	if err := batch.Put(headKey, b); err != nil {
+		return err
+	}
+	if err := l.appendEvent(batch, block.BlockIdentifier, types.ADDED); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// RemoveBlock appends a block_removed event for [block], the head, and makes
+// its parent the head
+func (l *Log) RemoveBlock(block *types.BlockIdentifier) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	head, err := l.Head()
+	if err != nil {
+		return err
+	}
+	if head == nil || types.Hash(head) != types.Hash(block) {
+		return errNotHead
+	}
+
+	batch := l.db.NewBatch()
+	if err := batch.Delete(blockKey(block.Index)); err != nil {
+		return err
+	}
+	parent, err := l.db.Get(blockKey(block.Index - 1))
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		err = batch.Delete(headKey)
+	case err == nil:
+		err = batch.Put(headKey, parent)
+	}
+	if err != nil {
+		return err
+	}
+	if err := l.appendEvent(batch, block, types.REMOVED); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (l *Log) appendEvent(batch database.Batch, block *types.BlockIdentifier, eventType types.BlockEventType) error {
+	sequence, err := l.nextSequence()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(&types.BlockEvent{
+		Sequence:        sequence,
+		BlockIdentifier: block,
+		Type:            eventType,
+	})
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(eventKey(sequence), b); err != nil {
+		return err
+	}
+	return database.PutUInt64(batch, nextSequenceKey, uint64(sequence+1))
+}
+
+// nextSequence returns the sequence number of the next event
+func (l *Log) nextSequence() (int64, error) {
+	next, err := database.GetUInt64(l.db, nextSequenceKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	return int64(next), err
+}
+
+// Events returns up to [limit] events starting from sequence [offset], and the
+// sequence of the last event. The last sequence is -1 if the log is empty.
+func (l *Log) Events(offset int64, limit int64) ([]*types.BlockEvent, int64, error) {
+	next, err := l.nextSequence()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	it := l.db.NewIteratorWithStartAndPrefix(eventKey(offset), []byte{eventPrefix})
+	defer it.Release()
+
+	events := []*types.BlockEvent{}
+	for int64(len(events)) < limit && it.Next() {
+		event := &types.BlockEvent{}
+		if err := json.Unmarshal(it.Value(), event); err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+	return events, next - 1, it.Error()
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	var (
+		network = &types.NetworkIdentifier{Blockchain: "Avalanche", Network: "Fuji"}
+		block0  = &types.BlockIdentifier{Index: 0, Hash: "0"}
+		block1  = &types.BlockIdentifier{Index: 1, Hash: "1"}
+		block1b = &types.BlockIdentifier{Index: 1, Hash: "1b"}
+		block2  = &types.BlockIdentifier{Index: 2, Hash: "2"}
+	)
+
+	db := memdb.New()
+	log := New(db, network)
+
+	events, maxSequence, err := log.Events(0, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+	require.Equal(t, int64(-1), maxSequence)
+
+	require.NoError(t, log.AddBlock(&types.Block{BlockIdentifier: block0}))
+	require.NoError(t, log.AddBlock(&types.Block{BlockIdentifier: block1}))
+	require.ErrorIs(t, log.AddBlock(&types.Block{BlockIdentifier: &types.BlockIdentifier{Index: 3}}), errNonContiguousBlock)
+	require.ErrorIs(t, log.RemoveBlock(block0), errNotHead)
+	require.NoError(t, log.RemoveBlock(block1))
+
+	head, err := log.Head()
+	require.NoError(t, err)
+	require.Equal(t, block0, head)
+
+	require.NoError(t, log.AddBlock(&types.Block{BlockIdentifier: block1b}))
+	require.NoError(t, log.AddBlock(&types.Block{BlockIdentifier: block2}))
+
+	expected := []*types.BlockEvent{
+		{Sequence: 0, BlockIdentifier: block0, Type: types.ADDED},
+		{Sequence: 1, BlockIdentifier: block1, Type: types.ADDED},
+		{Sequence: 2, BlockIdentifier: block1, Type: types.REMOVED},
+		{Sequence: 3, BlockIdentifier: block1b, Type: types.ADDED},
+		{Sequence: 4, BlockIdentifier: block2, Type: types.ADDED},
+	}
+
+	events, maxSequence, err = log.Events(0, 10)
+	require.NoError(t, err)
+	require.Equal(t, expected, events)
+	require.Equal(t, int64(4), maxSequence)
+
+	events, maxSequence, err = log.Events(1, 2)
+	require.NoError(t, err)
+	require.Equal(t, expected[1:3], events)
+	require.Equal(t, int64(4), maxSequence)
+
+	// the log is durable
+	events, _, err = New(db, network).Events(4, 10)
+	require.NoError(t, err)
+	require.Equal(t, expected[4:], events)
+
+	// logs of other networks are independent
+	_, maxSequence, err = New(db, &types.NetworkIdentifier{Blockchain: "Avalanche", Network: "Mainnet"}).Events(0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), maxSequence)
+}
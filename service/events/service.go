@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+const (
+	// DefaultLimit is the number of events returned by a request without limit
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of events returned by a request
+	MaxLimit = 1000
+)
+
+var (
+	_ server.EventsAPIServicer = &Service{}
+
+	errEmptyLog = errors.New("no block is logged yet")
+)
+
+// Service implements the /events/blocks endpoint from the logs of the networks
+// it serves
+type Service struct {
+	logs map[string]*Log
+}
+
+// NewService returns a new Service without any network
+func NewService() *Service {
+	return &Service{
+		logs: map[string]*Log{},
+	}
+}
+
+// AddNetwork serves the events of [network] from [log]
+func (s *Service) AddNetwork(network *types.NetworkIdentifier, log *Log) {
+	s.logs[types.Hash(network)] = log
+}
+
+// EventsBlocks implements the /events/blocks endpoint
+func (s *Service) EventsBlocks(
+	_ context.Context,
+	request *types.EventsBlocksRequest,
+) (*types.EventsBlocksResponse, *types.Error) {
+	log, ok := s.logs[types.Hash(request.NetworkIdentifier)]
+	if !ok {
+		return nil, service.WrapError(service.ErrNotSupported, "block events of this network are not logged")
+	}
+
+	offset := int64(0)
+	if request.Offset != nil {
+		offset = *request.Offset
+	}
+	limit := int64(DefaultLimit)
+	if request.Limit != nil && *request.Limit > 0 {
+		limit = *request.Limit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	events, maxSequence, err := log.Events(offset, limit)
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+	if maxSequence < 0 {
+		return nil, service.WrapError(service.ErrNotReady, errEmptyLog)
+	}
+
+	return &types.EventsBlocksResponse{
+		MaxSequence: maxSequence,
+		Events:      events,
+	}, nil
+}
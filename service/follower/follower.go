@@ -0,0 +1,171 @@
+package follower
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// DefaultPollInterval is how often the chain tip is polled once a Follower has
+// caught up with it
+const DefaultPollInterval = 2 * time.Second
+
+// Handler processes the blocks of a network in height order
+type Handler interface {
+	// Head returns the last block processed, or nil if no block was processed yet
+	Head() (*types.BlockIdentifier, error)
+	// AddBlock processes [block], whose parent is the current head
+	AddBlock(block *types.Block) error
+	// RemoveBlock reverts the processing of [block], the current head, which
+	// is no longer part of the canonical chain. Its parent becomes the head.
+	RemoveBlock(block *types.BlockIdentifier) error
+}
+
+// Follower feeds the blocks of a network, as served by /block, to Handlers as
+// they are accepted. Each block is fetched once for all the handlers expecting
+// it. If a block does not extend the last block processed by a handler, the
+// latter is removed and the chain is followed again from its parent.
+type Follower struct {
+	network      *types.NetworkIdentifier
+	networkAPI   server.NetworkAPIServicer
+	blockAPI     server.BlockAPIServicer
+	handlers     []Handler
+	startIndex   int64
+	pollInterval time.Duration
+}
+
+// New returns a Follower of [network] feeding [handlers] with the blocks from
+// height [startIndex] onwards, or from genesis if [startIndex] is lower
+func New(
+	network *types.NetworkIdentifier,
+	networkAPI server.NetworkAPIServicer,
+	blockAPI server.BlockAPIServicer,
+	startIndex int64,
+	handlers ...Handler,
+) *Follower {
+	return &Follower{
+		network:      network,
+		networkAPI:   networkAPI,
+		blockAPI:     blockAPI,
+		handlers:     handlers,
+		startIndex:   startIndex,
+		pollInterval: DefaultPollInterval,
+	}
+}
+
+// Run follows the network until [ctx] is cancelled. Errors are logged and
+// retried after the poll interval.
+func (f *Follower) Run(ctx context.Context) {
+	for {
+		if err := f.Sync(ctx); err != nil {
+			log.Printf("failed to follow %s: %v\n", networkName(f.network), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.pollInterval):
+		}
+	}
+}
+
+// Sync feeds the handlers with every block up to the current chain tip
+func (f *Follower) Sync(ctx context.Context) error {
+	status, terr := f.networkAPI.NetworkStatus(ctx, &types.NetworkRequest{NetworkIdentifier: f.network})
+	if terr != nil {
+		return newError(terr)
+	}
+
+	start := f.startIndex
+	if status.GenesisBlockIdentifier != nil && start < status.GenesisBlockIdentifier.Index {
+		start = status.GenesisBlockIdentifier.Index
+	}
+
+	heads := make([]*types.BlockIdentifier, len(f.handlers))
+	for i, handler := range f.handlers {
+		head, err := handler.Head()
+		if err != nil {
+			return err
+		}
+		heads[i] = head
+	}
+	nextIndex := func(i int) int64 {
+		if heads[i] == nil {
+			return start
+		}
+		return heads[i].Index + 1
+	}
+
+	for {
+		// the block expected by the handler the most behind is fetched
+		next := int64(-1)
+		for i := range f.handlers {
+			if index := nextIndex(i); next == -1 || index < next {
+				next = index
+			}
+		}
+		if next == -1 || next > status.CurrentBlockIdentifier.Index {
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, terr := f.blockAPI.Block(ctx, &types.BlockRequest{
+			NetworkIdentifier: f.network,
+			BlockIdentifier:   &types.PartialBlockIdentifier{Index: types.Int64(next)},
+		})
+		if terr != nil {
+			return newError(terr)
+		}
+		block := resp.Block
+
+		for i, handler := range f.handlers {
+			if nextIndex(i) != next {
+				continue
+			}
+
+			head := heads[i]
+			if head != nil && block.ParentBlockIdentifier.Hash != head.Hash {
+				log.Printf(
+					"block %d of %s is no longer canonical, removing it\n",
+					head.Index,
+					networkName(f.network),
+				)
+				if err := handler.RemoveBlock(head); err != nil {
+					return err
+				}
+				var err error
+				if heads[i], err = handler.Head(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := handler.AddBlock(block); err != nil {
+				return err
+			}
+			heads[i] = block.BlockIdentifier
+		}
+	}
+}
+
+// newError converts a service error into an error
+func newError(err *types.Error) error {
+	if details, ok := err.Details["error"]; ok {
+		return fmt.Errorf("%s: %v", err.Message, details)
+	}
+	return fmt.Errorf("%s", err.Message)
+}
+
+func networkName(network *types.NetworkIdentifier) string {
+	if network.SubNetworkIdentifier != nil {
+		return network.Network + "/" + network.SubNetworkIdentifier.Network
+	}
+	return network.Network
+}
@@ -0,0 +1,170 @@
+package follower
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+// chain serves the blocks whose hashes are listed in [hashes], by height
+type chain struct {
+	server.NetworkAPIServicer
+	server.BlockAPIServicer
+
+	hashes  []string
+	fetched int
+}
+
+func newChain(height int) *chain {
+	c := &chain{}
+	for i := 0; i <= height; i++ {
+		c.hashes = append(c.hashes, strconv.Itoa(i))
+	}
+	return c
+}
+
+func (c *chain) identifier(index int64) *types.BlockIdentifier {
+	return &types.BlockIdentifier{Index: index, Hash: c.hashes[index]}
+}
+
+func (c *chain) NetworkStatus(context.Context, *types.NetworkRequest) (*types.NetworkStatusResponse, *types.Error) {
+	return &types.NetworkStatusResponse{
+		GenesisBlockIdentifier: c.identifier(0),
+		CurrentBlockIdentifier: c.identifier(int64(len(c.hashes) - 1)),
+	}, nil
+}
+
+func (c *chain) Block(_ context.Context, request *types.BlockRequest) (*types.BlockResponse, *types.Error) {
+	index := *request.BlockIdentifier.Index
+	c.fetched++
+	if index >= int64(len(c.hashes)) {
+		return nil, &types.Error{Message: "block not found"}
+	}
+
+	parent := index - 1
+	if parent < 0 {
+		parent = 0
+	}
+	return &types.BlockResponse{
+		Block: &types.Block{
+			BlockIdentifier:       c.identifier(index),
+			ParentBlockIdentifier: c.identifier(parent),
+		},
+	}, nil
+}
+
+type handler struct {
+	blocks  []*types.BlockIdentifier
+	removed []*types.BlockIdentifier
+}
+
+func (h *handler) Head() (*types.BlockIdentifier, error) {
+	if len(h.blocks) == 0 {
+		return nil, nil
+	}
+	return h.blocks[len(h.blocks)-1], nil
+}
+
+func (h *handler) AddBlock(block *types.Block) error {
+	h.blocks = append(h.blocks, block.BlockIdentifier)
+	return nil
+}
+
+func (h *handler) RemoveBlock(block *types.BlockIdentifier) error {
+	h.blocks = h.blocks[:len(h.blocks)-1]
+	h.removed = append(h.removed, block)
+	return nil
+}
+
+func TestFollowerSync(t *testing.T) {
+	network := &types.NetworkIdentifier{Blockchain: "Avalanche", Network: "Fuji"}
+
+	t.Run("from genesis", func(t *testing.T) {
+		c := newChain(2)
+		h := &handler{}
+		f := New(network, c, c, 0, h)
+
+		require.NoError(t, f.Sync(context.Background()))
+		require.Len(t, h.blocks, 3)
+
+		c.hashes = append(c.hashes, "3", "4")
+		require.NoError(t, f.Sync(context.Background()))
+		require.Len(t, h.blocks, 5)
+		for i, block := range h.blocks {
+			require.Equal(t, c.identifier(int64(i)), block)
+		}
+		require.Empty(t, h.removed)
+	})
+
+	t.Run("from start index", func(t *testing.T) {
+		c := newChain(4)
+		h := &handler{}
+		f := New(network, c, c, 3, h)
+
+		require.NoError(t, f.Sync(context.Background()))
+		require.Len(t, h.blocks, 2)
+		require.Equal(t, int64(3), h.blocks[0].Index)
+	})
+
+	t.Run("non canonical blocks are removed", func(t *testing.T) {
+		c := newChain(3)
+		h := &handler{}
+		f := New(network, c, c, 0, h)
+		require.NoError(t, f.Sync(context.Background()))
+
+		c.hashes = []string{"0", "1", "2b", "3b", "4b"}
+		require.NoError(t, f.Sync(context.Background()))
+		require.Equal(t, []*types.BlockIdentifier{
+			{Index: 3, Hash: "3"},
+			{Index: 2, Hash: "2"},
+		}, h.removed)
+		for i, block := range h.blocks {
+			require.Equal(t, c.identifier(int64(i)), block)
+		}
+		require.Len(t, h.blocks, 5)
+	})
+	t.Run("handlers share the fetched blocks", func(t *testing.T) {
+		c := newChain(3)
+		behind := &handler{}
+		ahead := &handler{}
+		require.NoError(t, New(network, c, c, 0, ahead).Sync(context.Background()))
+		c.fetched = 0
+
+		c.hashes = append(c.hashes, "4", "5")
+		f := New(network, c, c, 0, behind, ahead)
+		require.NoError(t, f.Sync(context.Background()))
+		require.Equal(t, 6, c.fetched)
+		require.Len(t, behind.blocks, 6)
+		require.Len(t, ahead.blocks, 6)
+		for i := range behind.blocks {
+			require.Equal(t, c.identifier(int64(i)), behind.blocks[i])
+			require.Equal(t, c.identifier(int64(i)), ahead.blocks[i])
+		}
+	})
+
+	t.Run("non canonical blocks are removed from each handler", func(t *testing.T) {
+		c := newChain(3)
+		behind := &handler{}
+		ahead := &handler{}
+		require.NoError(t, New(network, c, c, 0, ahead).Sync(context.Background()))
+
+		c.hashes = []string{"0", "1b", "2b", "3b", "4b"}
+		f := New(network, c, c, 0, behind, ahead)
+		require.NoError(t, f.Sync(context.Background()))
+		require.Empty(t, behind.removed)
+		require.Equal(t, []*types.BlockIdentifier{
+			{Index: 3, Hash: "3"},
+			{Index: 2, Hash: "2"},
+			{Index: 1, Hash: "1"},
+		}, ahead.removed)
+		for i := range behind.blocks {
+			require.Equal(t, c.identifier(int64(i)), behind.blocks[i])
+			require.Equal(t, c.identifier(int64(i)), ahead.blocks[i])
+		}
+		require.Len(t, ahead.blocks, 5)
+	})
+}
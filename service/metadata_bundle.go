@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	// DefaultMetadataBundleTTL is how long a metadata bundle is valid for when
+	// no TTL is requested
+	DefaultMetadataBundleTTL = time.Hour
+	// MaxMetadataBundleTTL is the longest a metadata bundle can be valid for
+	MaxMetadataBundleTTL = 7 * 24 * time.Hour
+)
+
+var (
+	errMetadataBundleChecksum = errors.New("metadata bundle checksum mismatch")
+	errMetadataBundleNoKey    = errors.New("metadata bundles require a key")
+	errMetadataBundleExpired  = errors.New("metadata bundle expired")
+	errMetadataBundleNetwork  = errors.New("metadata bundle created for another network")
+	errMetadataNotBundled     = errors.New("no valid metadata bundle holds the metadata of this request")
+)
+
+// MetadataBundle holds the /construction/metadata responses computed by an online
+// instance for a set of requests, so that an offline instance can serve them
+// without access to a node
+type MetadataBundle struct {
+	NetworkIdentifier *types.NetworkIdentifier `json:"network_identifier"`
+	// CreatedAt and ExpiresAt are unix timestamps, in seconds
+	CreatedAt int64                  `json:"created_at"`
+	ExpiresAt int64                  `json:"expires_at"`
+	Entries   []*MetadataBundleEntry `json:"entries"`
+	// Checksum is the HMAC of the other fields, signing off the bundle with the
+	// key shared by the online and offline instances
+	Checksum string `json:"checksum"`
+}
+
+// MetadataBundleEntry is the metadata response for a metadata request
+type MetadataBundleEntry struct {
+	Options    map[string]interface{}              `json:"options,omitempty"`
+	PublicKeys []*types.PublicKey                  `json:"public_keys,omitempty"`
+	Metadata   *types.ConstructionMetadataResponse `json:"metadata"`
+}
+
+// MetadataBundleParams are the parameters of the metadata bundle /call method
+type MetadataBundleParams struct {
+	Requests   []*types.ConstructionMetadataRequest `json:"requests"`
+	TTLSeconds int64                                `json:"ttl_seconds,omitempty"`
+}
+
+// MetadataBundles creates metadata bundles and serves the metadata they hold
+type MetadataBundles struct {
+	// dir holds the bundles served offline
+	dir string
+	// key signs off the bundles with an HMAC. Bundles are neither created
+	// nor served without it.
+	key []byte
+	now func() time.Time
+}
+
+// NewMetadataBundles returns MetadataBundles serving the bundles stored in [dir]
+// and signing them off with [key]
+func NewMetadataBundles(dir string, key []byte) *MetadataBundles {
+	return &MetadataBundles{
+		dir: dir,
+		key: key,
+		now: time.Now,
+	}
+}
+
+// entryKey identifies the entry for a metadata request with [options] and [publicKeys]
+func entryKey(options map[string]interface{}, publicKeys []*types.PublicKey) string {
+	return types.Hash(&MetadataBundleEntry{Options: options, PublicKeys: publicKeys})
+}
+
+func (m *MetadataBundles) checksum(bundle *MetadataBundle) string {
+	unsigned := *bundle
+	unsigned.Checksum = ""
+	hash := types.Hash(&unsigned)
+
+	mac := hmac.New(sha256.New, m.key)
+	mac.Write([]byte(hash))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Create returns a bundle of the metadata computed by [construction] for
+// [params], on network [network]
+func (m *MetadataBundles) Create(
+	ctx context.Context,
+	construction server.ConstructionAPIServicer,
+	network *types.NetworkIdentifier,
+	params *MetadataBundleParams,
+) (*MetadataBundle, *types.Error) {
+	if len(m.key) == 0 {
+		return nil, WrapError(ErrCallInvalidMethod, errMetadataBundleNoKey)
+	}
+
+	ttl := DefaultMetadataBundleTTL
+	if params.TTLSeconds > 0 {
+		ttl = time.Duration(params.TTLSeconds) * time.Second
+	}
+	if ttl > MaxMetadataBundleTTL {
+		return nil, WrapError(ErrCallInvalidParams, fmt.Sprintf("ttl exceeds %s", MaxMetadataBundleTTL))
+	}
+
+	now := m.now()
+	bundle := &MetadataBundle{
+		NetworkIdentifier: network,
+		CreatedAt:         now.Unix(),
+		ExpiresAt:         now.Add(ttl).Unix(),
+		Entries:           make([]*MetadataBundleEntry, 0, len(params.Requests)),
+	}
+
+	for _, req := range params.Requests {
+		resp, err := construction.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: network,
+			Options:           req.Options,
+			PublicKeys:        req.PublicKeys,
+		})
+		if err != nil {
+			return nil, err
+		}
+		bundle.Entries = append(bundle.Entries, &MetadataBundleEntry{
+			Options:    req.Options,
+			PublicKeys: req.PublicKeys,
+			Metadata:   resp,
+		})
+	}
+
+	bundle.Checksum = m.checksum(bundle)
+	return bundle, nil
+}
+
+// Metadata returns the metadata held for [req] by a bundle of the bundle directory.
+// Bundles that are corrupted, not signed off with the configured key, created for
+// another network or expired are ignored.
+func (m *MetadataBundles) Metadata(req *types.ConstructionMetadataRequest) (*types.ConstructionMetadataResponse, error) {
+	if len(m.key) == 0 {
+		return nil, errMetadataBundleNoKey
+	}
+
+	paths, err := filepath.Glob(filepath.Join(m.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	key := entryKey(req.Options, req.PublicKeys)
+	// the reason the last matching bundle is unusable, if any
+	notBundled := errMetadataNotBundled
+	for _, path := range paths {
+		bundle, err := readMetadataBundle(path)
+		if err != nil {
+			notBundled = err
+			continue
+		}
+
+		for _, entry := range bundle.Entries {
+			if entryKey(entry.Options, entry.PublicKeys) != key {
+				continue
+			}
+
+			switch {
+			case !hmac.Equal([]byte(bundle.Checksum), []byte(m.checksum(bundle))):
+				notBundled = fmt.Errorf("%w: %s", errMetadataBundleChecksum, filepath.Base(path))
+			case types.Hash(bundle.NetworkIdentifier) != types.Hash(req.NetworkIdentifier):
+				notBundled = fmt.Errorf("%w: %s", errMetadataBundleNetwork, filepath.Base(path))
+			case m.now().Unix() >= bundle.ExpiresAt:
+				notBundled = fmt.Errorf("%w: %s", errMetadataBundleExpired, filepath.Base(path))
+			default:
+				return entry.Metadata, nil
+			}
+		}
+	}
+	return nil, notBundled
+}
+
+func readMetadataBundle(path string) (*MetadataBundle, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &MetadataBundle{}
+	if err := json.Unmarshal(b, bundle); err != nil {
+		return nil, fmt.Errorf("invalid metadata bundle %s: %w", filepath.Base(path), err)
+	}
+	return bundle, nil
+}
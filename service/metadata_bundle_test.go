@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+type metadataConstruction struct {
+	server.ConstructionAPIServicer
+}
+
+func (metadataConstruction) ConstructionMetadata(
+	_ context.Context,
+	req *types.ConstructionMetadataRequest,
+) (*types.ConstructionMetadataResponse, *types.Error) {
+	return &types.ConstructionMetadataResponse{
+		Metadata: map[string]interface{}{"nonce": req.Options["from"]},
+	}, nil
+}
+
+func TestMetadataBundles(t *testing.T) {
+	var (
+		network = &types.NetworkIdentifier{Blockchain: "Avalanche", Network: "Fuji"}
+		other   = &types.NetworkIdentifier{Blockchain: "Avalanche", Network: "Mainnet"}
+		now     = time.Unix(1_700_000_000, 0)
+		request = func(from string) *types.ConstructionMetadataRequest {
+			return &types.ConstructionMetadataRequest{
+				NetworkIdentifier: network,
+				Options:           map[string]interface{}{"from": from},
+			}
+		}
+	)
+
+	export := func(t *testing.T, dir string, key []byte, ttl int64, from ...string) {
+		bundles := NewMetadataBundles("", key)
+		bundles.now = func() time.Time { return now }
+
+		params := &MetadataBundleParams{TTLSeconds: ttl}
+		for _, f := range from {
+			params.Requests = append(params.Requests, request(f))
+		}
+		bundle, err := bundles.Create(context.Background(), metadataConstruction{}, network, params)
+		require.Nil(t, err)
+
+		b, merr := json.Marshal(bundle)
+		require.NoError(t, merr)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.json"), b, 0o600))
+	}
+
+	t.Run("valid bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		export(t, dir, []byte("key"), 60, "0xa", "0xb")
+
+		bundles := NewMetadataBundles(dir, []byte("key"))
+		bundles.now = func() time.Time { return now.Add(59 * time.Second) }
+
+		resp, err := bundles.Metadata(request("0xb"))
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"nonce": "0xb"}, resp.Metadata)
+
+		_, err = bundles.Metadata(request("0xc"))
+		require.ErrorIs(t, err, errMetadataNotBundled)
+
+		_, err = bundles.Metadata(&types.ConstructionMetadataRequest{
+			NetworkIdentifier: other,
+			Options:           map[string]interface{}{"from": "0xa"},
+		})
+		require.ErrorIs(t, err, errMetadataBundleNetwork)
+	})
+
+	t.Run("expired bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		export(t, dir, []byte("key"), 60, "0xa")
+
+		bundles := NewMetadataBundles(dir, []byte("key"))
+		bundles.now = func() time.Time { return now.Add(time.Minute) }
+
+		_, err := bundles.Metadata(request("0xa"))
+		require.ErrorIs(t, err, errMetadataBundleExpired)
+	})
+
+	t.Run("bundle signed off with another key", func(t *testing.T) {
+		dir := t.TempDir()
+		export(t, dir, []byte("key"), 60, "0xa")
+
+		bundles := NewMetadataBundles(dir, []byte("other key"))
+		bundles.now = func() time.Time { return now }
+
+		_, err := bundles.Metadata(request("0xa"))
+		require.ErrorIs(t, err, errMetadataBundleChecksum)
+	})
+
+	t.Run("tampered bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		export(t, dir, []byte("key"), 60, "0xa")
+
+		path := filepath.Join(dir, "bundle.json")
+		bundle, err := readMetadataBundle(path)
+		require.NoError(t, err)
+		bundle.ExpiresAt += 3600
+		b, err := json.Marshal(bundle)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+
+		bundles := NewMetadataBundles(dir, []byte("key"))
+		bundles.now = func() time.Time { return now }
+
+		_, err = bundles.Metadata(request("0xa"))
+		require.ErrorIs(t, err, errMetadataBundleChecksum)
+	})
+
+	t.Run("ttl too long", func(t *testing.T) {
+		_, err := NewMetadataBundles("", []byte("key")).Create(
+			context.Background(),
+			metadataConstruction{},
+			network,
+			&MetadataBundleParams{
+				Requests:   []*types.ConstructionMetadataRequest{request("0xa")},
+				TTLSeconds: int64(MaxMetadataBundleTTL/time.Second) + 1,
+			},
+		)
+		require.Equal(t, ErrCallInvalidParams.Code, err.Code)
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		dir := t.TempDir()
+		export(t, dir, []byte("key"), 60, "0xa")
+
+		bundles := NewMetadataBundles(dir, nil)
+		bundles.now = func() time.Time { return now }
+
+		_, err := bundles.Metadata(request("0xa"))
+		require.ErrorIs(t, err, errMetadataBundleNoKey)
+
+		_, terr := bundles.Create(
+			context.Background(),
+			metadataConstruction{},
+			network,
+			&MetadataBundleParams{Requests: []*types.ConstructionMetadataRequest{request("0xa")}},
+		)
+		require.Equal(t, ErrCallInvalidMethod.Code, terr.Code)
+	})
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ava-labs/avalanche-rosetta/service (interfaces: AccountBackend,ConstructionBackend)
+// Source: github.com/ava-labs/avalanche-rosetta/service (interfaces: AccountBackend,BlockBackend,ConstructionBackend,NetworkBackend)
 //
 // Generated by this command:
 //
-//	mockgen -package=service -destination=service/mock_service.go github.com/ava-labs/avalanche-rosetta/service AccountBackend,ConstructionBackend
+//	mockgen -package=service -destination=service/mock_service.go github.com/ava-labs/avalanche-rosetta/service AccountBackend,BlockBackend,ConstructionBackend,NetworkBackend
 //
 
 // Package service is a generated GoMock package.
@@ -84,6 +84,73 @@ func (mr *MockAccountBackendMockRecorder) ShouldHandleRequest(arg0 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldHandleRequest", reflect.TypeOf((*MockAccountBackend)(nil).ShouldHandleRequest), arg0)
 }
 
+// MockBlockBackend is a mock of BlockBackend interface.
+type MockBlockBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockBackendMockRecorder
+}
+
+// MockBlockBackendMockRecorder is the mock recorder for MockBlockBackend.
+type MockBlockBackendMockRecorder struct {
+	mock *MockBlockBackend
+}
+
+// NewMockBlockBackend creates a new mock instance.
+func NewMockBlockBackend(ctrl *gomock.Controller) *MockBlockBackend {
+	mock := &MockBlockBackend{ctrl: ctrl}
+	mock.recorder = &MockBlockBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockBackend) EXPECT() *MockBlockBackendMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockBlockBackend) Block(arg0 context.Context, arg1 *types.BlockRequest) (*types.BlockResponse, *types.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", arg0, arg1)
+	ret0, _ := ret[0].(*types.BlockResponse)
+	ret1, _ := ret[1].(*types.Error)
+	return ret0, ret1
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockBlockBackendMockRecorder) Block(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockBlockBackend)(nil).Block), arg0, arg1)
+}
+
+// BlockTransaction mocks base method.
+func (m *MockBlockBackend) BlockTransaction(arg0 context.Context, arg1 *types.BlockTransactionRequest) (*types.BlockTransactionResponse, *types.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*types.BlockTransactionResponse)
+	ret1, _ := ret[1].(*types.Error)
+	return ret0, ret1
+}
+
+// BlockTransaction indicates an expected call of BlockTransaction.
+func (mr *MockBlockBackendMockRecorder) BlockTransaction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockTransaction", reflect.TypeOf((*MockBlockBackend)(nil).BlockTransaction), arg0, arg1)
+}
+
+// ShouldHandleRequest mocks base method.
+func (m *MockBlockBackend) ShouldHandleRequest(arg0 any) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldHandleRequest", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ShouldHandleRequest indicates an expected call of ShouldHandleRequest.
+func (mr *MockBlockBackendMockRecorder) ShouldHandleRequest(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldHandleRequest", reflect.TypeOf((*MockBlockBackend)(nil).ShouldHandleRequest), arg0)
+}
+
 // MockConstructionBackend is a mock of ConstructionBackend interface.
 type MockConstructionBackend struct {
 	ctrl     *gomock.Controller
@@ -212,6 +279,21 @@ func (mr *MockConstructionBackendMockRecorder) ConstructionPreprocess(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConstructionPreprocess", reflect.TypeOf((*MockConstructionBackend)(nil).ConstructionPreprocess), arg0, arg1)
 }
 
+// ConstructionSimulate mocks base method.
+func (m *MockConstructionBackend) ConstructionSimulate(arg0 context.Context, arg1 *types.ConstructionParseRequest) (*SimulationResult, *types.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConstructionSimulate", arg0, arg1)
+	ret0, _ := ret[0].(*SimulationResult)
+	ret1, _ := ret[1].(*types.Error)
+	return ret0, ret1
+}
+
+// ConstructionSimulate indicates an expected call of ConstructionSimulate.
+func (mr *MockConstructionBackendMockRecorder) ConstructionSimulate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConstructionSimulate", reflect.TypeOf((*MockConstructionBackend)(nil).ConstructionSimulate), arg0, arg1)
+}
+
 // ConstructionSubmit mocks base method.
 func (m *MockConstructionBackend) ConstructionSubmit(arg0 context.Context, arg1 *types.ConstructionSubmitRequest) (*types.TransactionIdentifierResponse, *types.Error) {
 	m.ctrl.T.Helper()
@@ -227,6 +309,21 @@ func (mr *MockConstructionBackendMockRecorder) ConstructionSubmit(arg0, arg1 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConstructionSubmit", reflect.TypeOf((*MockConstructionBackend)(nil).ConstructionSubmit), arg0, arg1)
 }
 
+// ConstructionTxStatus mocks base method.
+func (m *MockConstructionBackend) ConstructionTxStatus(arg0 context.Context, arg1 *TxStatusRequest) (*TxStatus, *types.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConstructionTxStatus", arg0, arg1)
+	ret0, _ := ret[0].(*TxStatus)
+	ret1, _ := ret[1].(*types.Error)
+	return ret0, ret1
+}
+
+// ConstructionTxStatus indicates an expected call of ConstructionTxStatus.
+func (mr *MockConstructionBackendMockRecorder) ConstructionTxStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConstructionTxStatus", reflect.TypeOf((*MockConstructionBackend)(nil).ConstructionTxStatus), arg0, arg1)
+}
+
 // ShouldHandleRequest mocks base method.
 func (m *MockConstructionBackend) ShouldHandleRequest(arg0 any) bool {
 	m.ctrl.T.Helper()
@@ -240,3 +337,84 @@ func (mr *MockConstructionBackendMockRecorder) ShouldHandleRequest(arg0 any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldHandleRequest", reflect.TypeOf((*MockConstructionBackend)(nil).ShouldHandleRequest), arg0)
 }
+
+// MockNetworkBackend is a mock of NetworkBackend interface.
+type MockNetworkBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkBackendMockRecorder
+}
+
+// MockNetworkBackendMockRecorder is the mock recorder for MockNetworkBackend.
+type MockNetworkBackendMockRecorder struct {
+	mock *MockNetworkBackend
+}
+
+// NewMockNetworkBackend creates a new mock instance.
+func NewMockNetworkBackend(ctrl *gomock.Controller) *MockNetworkBackend {
+	mock := &MockNetworkBackend{ctrl: ctrl}
+	mock.recorder = &MockNetworkBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkBackend) EXPECT() *MockNetworkBackendMockRecorder {
+	return m.recorder
+}
+
+// NetworkIdentifier mocks base method.
+func (m *MockNetworkBackend) NetworkIdentifier() *types.NetworkIdentifier {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkIdentifier")
+	ret0, _ := ret[0].(*types.NetworkIdentifier)
+	return ret0
+}
+
+// NetworkIdentifier indicates an expected call of NetworkIdentifier.
+func (mr *MockNetworkBackendMockRecorder) NetworkIdentifier() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkIdentifier", reflect.TypeOf((*MockNetworkBackend)(nil).NetworkIdentifier))
+}
+
+// NetworkOptions mocks base method.
+func (m *MockNetworkBackend) NetworkOptions(arg0 context.Context, arg1 *types.NetworkRequest) (*types.NetworkOptionsResponse, *types.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkOptions", arg0, arg1)
+	ret0, _ := ret[0].(*types.NetworkOptionsResponse)
+	ret1, _ := ret[1].(*types.Error)
+	return ret0, ret1
+}
+
+// NetworkOptions indicates an expected call of NetworkOptions.
+func (mr *MockNetworkBackendMockRecorder) NetworkOptions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkOptions", reflect.TypeOf((*MockNetworkBackend)(nil).NetworkOptions), arg0, arg1)
+}
+
+// NetworkStatus mocks base method.
+func (m *MockNetworkBackend) NetworkStatus(arg0 context.Context, arg1 *types.NetworkRequest) (*types.NetworkStatusResponse, *types.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkStatus", arg0, arg1)
+	ret0, _ := ret[0].(*types.NetworkStatusResponse)
+	ret1, _ := ret[1].(*types.Error)
+	return ret0, ret1
+}
+
+// NetworkStatus indicates an expected call of NetworkStatus.
+func (mr *MockNetworkBackendMockRecorder) NetworkStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkStatus", reflect.TypeOf((*MockNetworkBackend)(nil).NetworkStatus), arg0, arg1)
+}
+
+// ShouldHandleRequest mocks base method.
+func (m *MockNetworkBackend) ShouldHandleRequest(arg0 any) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldHandleRequest", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ShouldHandleRequest indicates an expected call of ShouldHandleRequest.
+func (mr *MockNetworkBackendMockRecorder) ShouldHandleRequest(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldHandleRequest", reflect.TypeOf((*MockNetworkBackend)(nil).ShouldHandleRequest), arg0)
+}
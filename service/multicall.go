@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+)
+
+// Multicall3Address is the address Multicall3 is deployed at on the C-chain
+// (https://github.com/mds1/multicall)
+const Multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+const multicall3ABI = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var (
+	errMulticallNotDeployed   = errors.New("multicall contract is not deployed")
+	errMulticallResultsLength = errors.New("unexpected number of multicall results")
+
+	multicall3 = mustParseABI(multicall3ABI)
+)
+
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+func mustParseABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// batchCallContract executes [calls] at [blockNumber] and returns their
+// return data in order. Calls are batched in a single Multicall3 aggregate3
+// call. If the batch fails, e.g. because Multicall3 is not deployed at
+// [blockNumber] or because one of the calls reverted, each call is
+// issued separately so that the caller gets the same result and error
+// it would have gotten without batching.
+func batchCallContract(
+	ctx context.Context,
+	c client.Client,
+	calls []interfaces.CallMsg,
+	blockNumber *big.Int,
+) ([][]byte, error) {
+	if len(calls) > 1 {
+		results, err := multicall(ctx, c, calls, blockNumber)
+		if err == nil {
+			return results, nil
+		}
+	}
+
+	results := make([][]byte, 0, len(calls))
+	for _, call := range calls {
+		response, err := c.CallContract(ctx, call, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, response)
+	}
+	return results, nil
+}
+
+func multicall(
+	ctx context.Context,
+	c client.Client,
+	calls []interfaces.CallMsg,
+	blockNumber *big.Int,
+) ([][]byte, error) {
+	args := make([]multicall3Call, 0, len(calls))
+	for _, call := range calls {
+		args = append(args, multicall3Call{
+			Target:       *call.To,
+			AllowFailure: false,
+			CallData:     call.Data,
+		})
+	}
+
+	data, err := multicall3.Pack("aggregate3", args)
+	if err != nil {
+		return nil, err
+	}
+
+	to := common.HexToAddress(Multicall3Address)
+	response, err := c.CallContract(ctx, interfaces.CallMsg{To: &to, Data: data}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	// calls to an address without code succeed with no return data
+	if len(response) == 0 {
+		return nil, errMulticallNotDeployed
+	}
+
+	var results []multicall3Result
+	if err := multicall3.UnpackIntoInterface(&results, "aggregate3", response); err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, errMulticallResultsLength
+	}
+
+	returnData := make([][]byte, 0, len(results))
+	for _, result := range results {
+		returnData = append(returnData, result.ReturnData)
+	}
+	return returnData, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+const (
+	// speedUpTxHashKey is the /construction/preprocess metadata key of the hash
+	// of a pending transaction replaced by the constructed one, with a higher
+	// gas price
+	speedUpTxHashKey = "speed_up_tx_hash"
+	// cancelTxHashKey is the /construction/preprocess metadata key of the hash
+	// of a pending transaction cancelled by a 0-value self-transfer
+	cancelTxHashKey = "cancel_tx_hash"
+
+	// replacementPriceBump is the percentage by which the gas price of a
+	// pending transaction must be bumped to replace it, as enforced by the
+	// coreth transaction pool
+	replacementPriceBump = 10
+)
+
+var errInvalidCancelOperations = errors.New("cancel requires a 0-value transfer of the native currency from an account to itself")
+
+func isCancelRequest(metadata map[string]interface{}) bool {
+	_, ok := metadata[cancelTxHashKey]
+	return ok
+}
+
+func isCancelPayload(metadata map[string]interface{}) bool {
+	cancel, ok := metadata["cancel"].(bool)
+	return ok && cancel
+}
+
+// replacedTxHash returns the hash of the transaction [key] of [metadata], if any
+func replacedTxHash(metadata map[string]interface{}, key string) (string, *types.Error) {
+	v, ok := metadata[key]
+	if !ok {
+		return "", nil
+	}
+	hash, ok := v.(string)
+	if !ok {
+		return "", WrapError(ErrInvalidInput, fmt.Errorf("%v is not a valid %s string", v, key))
+	}
+	if b, err := hexutil.Decode(hash); err != nil || len(b) != common.HashLength {
+		return "", WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid transaction hash", hash))
+	}
+	return hash, nil
+}
+
+// createCancelOperationDescription describes the operations of a 0-value
+// self-transfer, whose amounts are zero
+func createCancelOperationDescription(nativeCurrency *types.Currency) []*parser.OperationDescription {
+	return []*parser.OperationDescription{
+		{
+			Type:    mapper.OpCall,
+			Account: &parser.AccountDescription{Exists: true},
+			Amount: &parser.AmountDescription{
+				Exists:   true,
+				Sign:     parser.AnyAmountSign,
+				Currency: nativeCurrency,
+			},
+		},
+		{
+			Type:    mapper.OpCall,
+			Account: &parser.AccountDescription{Exists: true},
+			Amount: &parser.AmountDescription{
+				Exists:   true,
+				Sign:     parser.AnyAmountSign,
+				Currency: nativeCurrency,
+			},
+		},
+	}
+}
+
+// matchCancelOperations returns the checksummed address of the account
+// transferring 0 to itself in [operations]
+func matchCancelOperations(operations []*types.Operation, nativeCurrency *types.Currency) (string, *types.Error) {
+	descriptions := &parser.Descriptions{
+		OperationDescriptions: createCancelOperationDescription(nativeCurrency),
+		ErrUnmatched:          true,
+	}
+	matches, err := parser.MatchOperations(descriptions, operations)
+	if err != nil {
+		return "", WrapError(ErrInvalidInput, "unclear intent")
+	}
+
+	fromOp, fromAmount := matches[0].First()
+	toOp, toAmount := matches[1].First()
+	if fromAmount.Sign() != 0 || toAmount.Sign() != 0 {
+		return "", WrapError(ErrInvalidInput, errInvalidCancelOperations)
+	}
+
+	checkFrom, ok := ChecksumAddress(fromOp.Account.Address)
+	if !ok {
+		return "", WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid address", fromOp.Account.Address))
+	}
+	checkTo, ok := ChecksumAddress(toOp.Account.Address)
+	if !ok || checkTo != checkFrom {
+		return "", WrapError(ErrInvalidInput, errInvalidCancelOperations)
+	}
+	return checkFrom, nil
+}
+
+func createCancelPreprocessOptions(
+	req *types.ConstructionPreprocessRequest,
+	nativeCurrency *types.Currency,
+) (*options, *types.Error) {
+	checkFrom, terr := matchCancelOperations(req.Operations, nativeCurrency)
+	if terr != nil {
+		return nil, terr
+	}
+
+	cancelTxHash, terr := replacedTxHash(req.Metadata, cancelTxHashKey)
+	if terr != nil {
+		return nil, terr
+	}
+
+	return &options{
+		From:                   checkFrom,
+		To:                     checkFrom,
+		Value:                  big.NewInt(0),
+		SuggestedFeeMultiplier: req.SuggestedFeeMultiplier,
+		Currency:               nativeCurrency,
+		CancelTxHash:           cancelTxHash,
+	}, nil
+}
+
+func (s ConstructionService) createCancelPayload(
+	req *types.ConstructionPayloadsRequest,
+) (*ethtypes.Transaction, *transaction, *string, *types.Error) {
+	checkFrom, terr := matchCancelOperations(req.Operations, s.config.Currency())
+	if terr != nil {
+		return nil, nil, nil, terr
+	}
+
+	var metadata metadata
+	if err := mapper.UnmarshalJSONMap(req.Metadata, &metadata); err != nil {
+		return nil, nil, nil, WrapError(ErrInvalidInput, err)
+	}
+
+	from := common.HexToAddress(checkFrom)
+	tx := ethtypes.NewTransaction(
+		metadata.Nonce,
+		from,
+		big.NewInt(0),
+		metadata.GasLimit,
+		metadata.GasPrice,
+		[]byte{},
+	)
+
+	unsignedTx := &transaction{
+		From:     checkFrom,
+		To:       checkFrom,
+		Value:    big.NewInt(0),
+		Data:     tx.Data(),
+		Nonce:    tx.Nonce(),
+		GasPrice: metadata.GasPrice,
+		GasLimit: tx.Gas(),
+		ChainID:  s.config.ChainID,
+		Currency: s.config.Currency(),
+	}
+	return tx, unsignedTx, &checkFrom, nil
+}
+
+// replacedTx returns the pending transaction [hash] sent by [from], to be
+// replaced by the constructed transaction
+func (s ConstructionService) replacedTx(
+	ctx context.Context,
+	from string,
+	hash string,
+) (*ethtypes.Transaction, *types.Error) {
+	tx, isPending, err := s.client.TransactionByHash(ctx, common.HexToHash(hash))
+	if errors.Is(err, interfaces.NotFound) {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("transaction %s is not known by the node", hash))
+	}
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+	if !isPending {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("transaction %s is no longer pending", hash))
+	}
+
+	sender, err := ethtypes.Sender(s.config.Signer(), tx)
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err)
+	}
+	if sender != common.HexToAddress(from) {
+		return nil, WrapError(ErrInvalidInput, fmt.Errorf("transaction %s is not sent by %s", hash, from))
+	}
+	return tx, nil
+}
+
+// minReplacementGasPrice returns the lowest gas price of a legacy transaction
+// replacing [tx] in the transaction pool: it must exceed the fee cap and tip
+// of [tx] by [replacementPriceBump] percent
+func minReplacementGasPrice(tx *ethtypes.Transaction) *big.Int {
+	price := tx.GasFeeCap()
+	if tx.GasTipCap().Cmp(price) > 0 {
+		price = tx.GasTipCap()
+	}
+
+	minPrice := new(big.Int).Mul(price, big.NewInt(100+replacementPriceBump))
+	minPrice.Div(minPrice, big.NewInt(100))
+
+	// the gas price must be strictly higher, even for wei-level prices
+	if minPrice.Cmp(price) <= 0 {
+		minPrice.Add(price, big.NewInt(1))
+	}
+	return minPrice
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	rosConst "github.com/ava-labs/avalanche-rosetta/constants"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+func TestMinReplacementGasPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       ethtypes.TxData
+		expected int64
+	}{
+		{
+			name:     "legacy transaction",
+			tx:       &ethtypes.LegacyTx{GasPrice: big.NewInt(25_000_000_000)},
+			expected: 27_500_000_000,
+		},
+		{
+			name:     "dynamic fee transaction",
+			tx:       &ethtypes.DynamicFeeTx{GasFeeCap: big.NewInt(30_000_000_000), GasTipCap: big.NewInt(1_000_000_000)},
+			expected: 33_000_000_000,
+		},
+		{
+			name:     "wei-level gas price",
+			tx:       &ethtypes.LegacyTx{GasPrice: big.NewInt(5)},
+			expected: 6,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, big.NewInt(test.expected), minReplacementGasPrice(ethtypes.NewTx(test.tx)))
+		})
+	}
+}
+
+func TestReplacementConstruction(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientMock := client.NewMockClient(ctrl)
+	skippedBackend := NewMockConstructionBackend(ctrl)
+	skippedBackend.EXPECT().ShouldHandleRequest(gomock.Any()).Return(false).AnyTimes()
+	service := ConstructionService{
+		config: &Config{
+			Mode:    ModeOnline,
+			ChainID: big.NewInt(rosConst.FujiChainID),
+		},
+		client:                clientMock,
+		pChainBackend:         skippedBackend,
+		cChainAtomicTxBackend: skippedBackend,
+	}
+	networkIdentifier := &types.NetworkIdentifier{
+		Network:    rosConst.FujiNetwork,
+		Blockchain: BlockchainName,
+	}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress(defaultToAddress)
+	pendingTx, err := ethtypes.SignTx(
+		ethtypes.NewTransaction(7, to, big.NewInt(1_000), 21_000, big.NewInt(25_000_000_000), nil),
+		service.config.Signer(),
+		key,
+	)
+	require.NoError(t, err)
+	pendingTxHash := pendingTx.Hash().String()
+
+	transferOps := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: sender.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(-1_000)),
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: to.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(1_000)),
+		},
+	}
+	cancelOps := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: sender.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(0)),
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                mapper.OpCall,
+			Account:             &types.AccountIdentifier{Address: sender.Hex()},
+			Amount:              mapper.AvaxAmount(big.NewInt(0)),
+		},
+	}
+
+	t.Run("speed up reuses the nonce with a bumped gas price", func(t *testing.T) {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps,
+			Metadata:          map[string]interface{}{"speed_up_tx_hash": pendingTxHash},
+		})
+		require.Nil(t, terr)
+		require.Equal(t, pendingTxHash, preprocessResponse.Options["speed_up_tx_hash"])
+
+		clientMock.EXPECT().TransactionByHash(ctx, pendingTx.Hash()).Return(pendingTx, true, nil)
+		clientMock.EXPECT().SuggestGasPrice(ctx).Return(big.NewInt(25_000_000_000), nil)
+		clientMock.EXPECT().EstimateGas(ctx, interfaces.CallMsg{
+			From:  sender,
+			To:    &to,
+			Value: big.NewInt(1_000),
+		}).Return(uint64(21_000), nil)
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		require.Nil(t, terr)
+
+		var metadata metadata
+		require.NoError(t, mapper.UnmarshalJSONMap(metadataResponse.Metadata, &metadata))
+		require.Equal(t, uint64(7), metadata.Nonce)
+		require.Equal(t, big.NewInt(27_500_000_000), metadata.GasPrice)
+		require.Equal(t, pendingTxHash, metadata.ReplacedTxHash)
+		require.False(t, metadata.Cancel)
+	})
+
+	t.Run("speed up rejects a gas price below the replacement minimum", func(t *testing.T) {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps,
+			Metadata: map[string]interface{}{
+				"speed_up_tx_hash": pendingTxHash,
+				"gas_price":        "26000000000",
+			},
+		})
+		require.Nil(t, terr)
+
+		clientMock.EXPECT().TransactionByHash(ctx, pendingTx.Hash()).Return(pendingTx, true, nil)
+		_, terr = service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+		require.Contains(t, terr.Details["error"], "27500000000")
+	})
+
+	t.Run("speed up requires a pending transaction", func(t *testing.T) {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps,
+			Metadata:          map[string]interface{}{"speed_up_tx_hash": pendingTxHash},
+		})
+		require.Nil(t, terr)
+
+		clientMock.EXPECT().TransactionByHash(ctx, pendingTx.Hash()).Return(pendingTx, false, nil)
+		_, terr = service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+		require.Contains(t, terr.Details["error"], "no longer pending")
+	})
+
+	t.Run("cancel builds a 0-value self-transfer", func(t *testing.T) {
+		preprocessResponse, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        cancelOps,
+			Metadata:          map[string]interface{}{"cancel_tx_hash": pendingTxHash},
+		})
+		require.Nil(t, terr)
+
+		clientMock.EXPECT().TransactionByHash(ctx, pendingTx.Hash()).Return(pendingTx, true, nil)
+		clientMock.EXPECT().SuggestGasPrice(ctx).Return(big.NewInt(30_000_000_000), nil)
+		clientMock.EXPECT().EstimateGas(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, msg interfaces.CallMsg) (uint64, error) {
+				require.Equal(t, sender, *msg.To)
+				require.Zero(t, msg.Value.Sign())
+				return 21_000, nil
+			},
+		)
+
+		metadataResponse, terr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           preprocessResponse.Options,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, true, metadataResponse.Metadata["cancel"])
+
+		payloadsResponse, terr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        cancelOps,
+			Metadata:          metadataResponse.Metadata,
+		})
+		require.Nil(t, terr)
+
+		var unsignedTx transaction
+		require.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+		require.Equal(t, sender.Hex(), unsignedTx.To)
+		require.Zero(t, unsignedTx.Value.Sign())
+		require.Equal(t, uint64(7), unsignedTx.Nonce)
+		// the suggested gas price exceeds the replacement minimum
+		require.Equal(t, big.NewInt(30_000_000_000), unsignedTx.GasPrice)
+
+		parseResponse, terr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: networkIdentifier,
+			Transaction:       payloadsResponse.UnsignedTransaction,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, cancelOps, parseResponse.Operations)
+	})
+
+	t.Run("cancel requires a 0-value self-transfer", func(t *testing.T) {
+		_, terr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps,
+			Metadata:          map[string]interface{}{"cancel_tx_hash": pendingTxHash},
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+var (
+	_ server.NetworkAPIServicer      = &Router{}
+	_ server.BlockAPIServicer        = &Router{}
+	_ server.AccountAPIServicer      = &Router{}
+	_ server.MempoolAPIServicer      = &Router{}
+	_ server.ConstructionAPIServicer = &Router{}
+	_ server.CallAPIServicer         = &Router{}
+	_ TxStatusFetcher                = &Router{}
+)
+
+// Services groups the servicers implementing the endpoints for a set of networks
+type Services struct {
+	Network      server.NetworkAPIServicer
+	Block        server.BlockAPIServicer
+	Account      server.AccountAPIServicer
+	Mempool      server.MempoolAPIServicer
+	Construction server.ConstructionAPIServicer
+	Call         server.CallAPIServicer
+}
+
+// Router implements all endpoints by dispatching requests to the services of
+// the network they target. Requests for networks without dedicated services,
+// namely the C-chain and the P-chain, are handled by the primary services.
+type Router struct {
+	primary  *Services
+	networks []*types.NetworkIdentifier
+	services map[string]*Services
+
+	metadataBundles *MetadataBundles
+	txTracker       *TxTracker
+}
+
+// NewRouter returns a new Router
+func NewRouter(primary *Services) *Router {
+	return &Router{
+		primary:  primary,
+		services: map[string]*Services{},
+	}
+}
+
+// AddNetwork registers the services handling the requests for [networkIdentifier]
+func (r *Router) AddNetwork(networkIdentifier *types.NetworkIdentifier, services *Services) {
+	r.networks = append(r.networks, networkIdentifier)
+	r.services[types.Hash(networkIdentifier)] = services
+}
+
+// SetMetadataBundles enables the export of metadata bundles through /call
+func (r *Router) SetMetadataBundles(metadataBundles *MetadataBundles) {
+	r.metadataBundles = metadataBundles
+}
+
+// SetTxTracker enables the tracking of the transactions submitted through
+// /construction/submit, whose status is served through /call
+func (r *Router) SetTxTracker(txTracker *TxTracker) {
+	r.txTracker = txTracker
+}
+
+func (r *Router) route(networkIdentifier *types.NetworkIdentifier) *Services {
+	if services, ok := r.services[types.Hash(networkIdentifier)]; ok {
+		return services
+	}
+	return r.primary
+}
+
+// NetworkList implements the /network/list endpoint
+func (r *Router) NetworkList(
+	ctx context.Context,
+	request *types.MetadataRequest,
+) (*types.NetworkListResponse, *types.Error) {
+	resp, err := r.primary.Network.NetworkList(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.NetworkIdentifiers = append(resp.NetworkIdentifiers, r.networks...)
+	return resp, nil
+}
+
+// NetworkOptions implements the /network/options endpoint
+func (r *Router) NetworkOptions(
+	ctx context.Context,
+	request *types.NetworkRequest,
+) (*types.NetworkOptionsResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Network.NetworkOptions(ctx, request)
+}
+
+// NetworkStatus implements the /network/status endpoint
+func (r *Router) NetworkStatus(
+	ctx context.Context,
+	request *types.NetworkRequest,
+) (*types.NetworkStatusResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Network.NetworkStatus(ctx, request)
+}
+
+// Block implements the /block endpoint
+func (r *Router) Block(
+	ctx context.Context,
+	request *types.BlockRequest,
+) (*types.BlockResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Block.Block(ctx, request)
+}
+
+// BlockTransaction implements the /block/transaction endpoint
+func (r *Router) BlockTransaction(
+	ctx context.Context,
+	request *types.BlockTransactionRequest,
+) (*types.BlockTransactionResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Block.BlockTransaction(ctx, request)
+}
+
+// AccountBalance implements the /account/balance endpoint
+func (r *Router) AccountBalance(
+	ctx context.Context,
+	request *types.AccountBalanceRequest,
+) (*types.AccountBalanceResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Account.AccountBalance(ctx, request)
+}
+
+// AccountCoins implements the /account/coins endpoint
+func (r *Router) AccountCoins(
+	ctx context.Context,
+	request *types.AccountCoinsRequest,
+) (*types.AccountCoinsResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Account.AccountCoins(ctx, request)
+}
+
+// Mempool implements the /mempool endpoint
+func (r *Router) Mempool(
+	ctx context.Context,
+	request *types.NetworkRequest,
+) (*types.MempoolResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Mempool.Mempool(ctx, request)
+}
+
+// MempoolTransaction implements the /mempool/transaction endpoint
+func (r *Router) MempoolTransaction(
+	ctx context.Context,
+	request *types.MempoolTransactionRequest,
+) (*types.MempoolTransactionResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Mempool.MempoolTransaction(ctx, request)
+}
+
+// ConstructionCombine implements the /construction/combine endpoint
+func (r *Router) ConstructionCombine(
+	ctx context.Context,
+	request *types.ConstructionCombineRequest,
+) (*types.ConstructionCombineResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Construction.ConstructionCombine(ctx, request)
+}
+
+// ConstructionDerive implements the /construction/derive endpoint
+func (r *Router) ConstructionDerive(
+	ctx context.Context,
+	request *types.ConstructionDeriveRequest,
+) (*types.ConstructionDeriveResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Construction.ConstructionDerive(ctx, request)
+}
+
+// ConstructionHash implements the /construction/hash endpoint
+func (r *Router) ConstructionHash(
+	ctx context.Context,
+	request *types.ConstructionHashRequest,
+) (*types.TransactionIdentifierResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Construction.ConstructionHash(ctx, request)
+}
+
+// ConstructionMetadata implements the /construction/metadata endpoint
+func (r *Router) ConstructionMetadata(
+	ctx context.Context,
+	request *types.ConstructionMetadataRequest,
+) (*types.ConstructionMetadataResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Construction.ConstructionMetadata(ctx, request)
+}
+
+// ConstructionParse implements the /construction/parse endpoint
+func (r *Router) ConstructionParse(
+	ctx context.Context,
+	request *types.ConstructionParseRequest,
+) (*types.ConstructionParseResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Construction.ConstructionParse(ctx, request)
+}
+
+// ConstructionPayloads implements the /construction/payloads endpoint
+func (r *Router) ConstructionPayloads(
+	ctx context.Context,
+	request *types.ConstructionPayloadsRequest,
+) (*types.ConstructionPayloadsResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Construction.ConstructionPayloads(ctx, request)
+}
+
+// ConstructionPreprocess implements the /construction/preprocess endpoint
+func (r *Router) ConstructionPreprocess(
+	ctx context.Context,
+	request *types.ConstructionPreprocessRequest,
+) (*types.ConstructionPreprocessResponse, *types.Error) {
+	return r.route(request.NetworkIdentifier).Construction.ConstructionPreprocess(ctx, request)
+}
+
+// ConstructionSubmit implements the /construction/submit endpoint
+func (r *Router) ConstructionSubmit(
+	ctx context.Context,
+	request *types.ConstructionSubmitRequest,
+) (*types.TransactionIdentifierResponse, *types.Error) {
+	resp, err := r.route(request.NetworkIdentifier).Construction.ConstructionSubmit(ctx, request)
+	if err != nil || r.txTracker == nil {
+		return resp, err
+	}
+
+	status := r.txTracker.Track(ctx, &TxStatusRequest{
+		NetworkIdentifier:     request.NetworkIdentifier,
+		TransactionIdentifier: resp.TransactionIdentifier,
+	})
+	if status != nil {
+		metadata, err := types.MarshalMap(status)
+		if err != nil {
+			return nil, WrapError(ErrInternalError, err)
+		}
+		resp.Metadata = metadata
+	}
+	return resp, nil
+}
+
+// ConstructionTxStatus returns the status of a transaction submitted to the
+// node of the requested network
+func (r *Router) ConstructionTxStatus(
+	ctx context.Context,
+	request *TxStatusRequest,
+) (*TxStatus, *types.Error) {
+	fetcher, ok := r.route(request.NetworkIdentifier).Construction.(TxStatusFetcher)
+	if !ok {
+		return nil, WrapError(ErrNotSupported, "transaction status is not supported by this network")
+	}
+	return fetcher.ConstructionTxStatus(ctx, request)
+}
+
+// Call implements the /call endpoint
+func (r *Router) Call(
+	ctx context.Context,
+	request *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	switch request.Method {
+	case mapper.MetadataBundleCallMethod:
+		return r.callMetadataBundle(ctx, request)
+	case mapper.SimulateCallMethod:
+		return r.callSimulate(ctx, request)
+	case mapper.TxStatusCallMethod:
+		return r.callTxStatus(ctx, request)
+	}
+	return r.route(request.NetworkIdentifier).Call.Call(ctx, request)
+}
+
+// callSimulate dry-runs a transaction built by the construction API of the
+// requested network
+func (r *Router) callSimulate(
+	ctx context.Context,
+	request *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	simulator, ok := r.route(request.NetworkIdentifier).Construction.(Simulator)
+	if !ok {
+		return nil, ErrCallInvalidMethod
+	}
+
+	var params SimulateParams
+	if err := types.UnmarshalMap(request.Parameters, &params); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+	if len(params.Transaction) == 0 {
+		return nil, WrapError(ErrCallInvalidParams, "transaction missing from params")
+	}
+
+	simulation, terr := simulator.ConstructionSimulate(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: request.NetworkIdentifier,
+		Signed:            params.Signed,
+		Transaction:       params.Transaction,
+	})
+	if terr != nil {
+		return nil, terr
+	}
+
+	result, err := types.MarshalMap(simulation)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	return &types.CallResponse{Result: result}, nil
+}
+
+// callTxStatus returns the status of a transaction submitted to the requested
+// network, from the tracked transactions if enabled
+func (r *Router) callTxStatus(
+	ctx context.Context,
+	request *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	var params TxStatusParams
+	if err := types.UnmarshalMap(request.Parameters, &params); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+	if len(params.TxHash) == 0 {
+		return nil, WrapError(ErrCallInvalidParams, "tx_hash missing from params")
+	}
+
+	statusRequest := &TxStatusRequest{
+		NetworkIdentifier:     request.NetworkIdentifier,
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: params.TxHash},
+	}
+
+	var (
+		status *TxStatus
+		terr   *types.Error
+	)
+	if r.txTracker != nil {
+		status, terr = r.txTracker.Status(ctx, statusRequest)
+	} else {
+		status, terr = r.ConstructionTxStatus(ctx, statusRequest)
+	}
+	if terr != nil {
+		return nil, terr
+	}
+
+	result, err := types.MarshalMap(status)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	return &types.CallResponse{Result: result}, nil
+}
+
+// callMetadataBundle returns a metadata bundle holding the /construction/metadata
+// responses for the requested options, to be served by an offline instance
+func (r *Router) callMetadataBundle(
+	ctx context.Context,
+	request *types.CallRequest,
+) (*types.CallResponse, *types.Error) {
+	if r.metadataBundles == nil {
+		return nil, ErrCallInvalidMethod
+	}
+
+	var params MetadataBundleParams
+	if err := types.UnmarshalMap(request.Parameters, &params); err != nil {
+		return nil, WrapError(ErrCallInvalidParams, err)
+	}
+	if len(params.Requests) == 0 {
+		return nil, WrapError(ErrCallInvalidParams, "requests missing from params")
+	}
+
+	bundle, terr := r.metadataBundles.Create(
+		ctx,
+		r.route(request.NetworkIdentifier).Construction,
+		request.NetworkIdentifier,
+		&params,
+	)
+	if terr != nil {
+		return nil, terr
+	}
+
+	result, err := types.MarshalMap(bundle)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	return &types.CallResponse{Result: result}, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+func TestRouter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	primaryBackend := NewMockAccountBackend(ctrl)
+	l1Backend := NewMockAccountBackend(ctrl)
+	pNetworkBackend := NewMockNetworkBackend(ctrl)
+	networkP := &types.NetworkIdentifier{
+		Blockchain: BlockchainName,
+		Network:    constants.FujiNetwork,
+		SubNetworkIdentifier: &types.SubNetworkIdentifier{
+			Network: constants.PChain.String(),
+		},
+	}
+	pNetworkBackend.EXPECT().NetworkIdentifier().Return(networkP).AnyTimes()
+
+	networkC := &types.NetworkIdentifier{
+		Blockchain: BlockchainName,
+		Network:    constants.FujiNetwork,
+	}
+	networkL1 := &types.NetworkIdentifier{
+		Blockchain: BlockchainName,
+		Network:    constants.FujiNetwork,
+		SubNetworkIdentifier: &types.SubNetworkIdentifier{
+			Network: "dfk",
+		},
+	}
+
+	primary := &Services{
+		Network: &NetworkService{
+			config:        &Config{NetworkID: networkC},
+			pChainBackend: pNetworkBackend,
+		},
+		Account: &AccountService{
+			config:        &Config{Mode: ModeOnline},
+			pChainBackend: primaryBackend,
+		},
+	}
+	l1 := &Services{
+		Account: &AccountService{
+			config:        &Config{Mode: ModeOnline},
+			pChainBackend: l1Backend,
+		},
+	}
+
+	router := NewRouter(primary)
+	router.AddNetwork(networkL1, l1)
+
+	t.Run("network list includes added networks", func(t *testing.T) {
+		resp, err := router.NetworkList(context.Background(), &types.MetadataRequest{})
+		require.Nil(t, err)
+		require.Equal(t, []*types.NetworkIdentifier{
+			networkC,
+			networkP,
+			networkL1,
+		}, resp.NetworkIdentifiers)
+	})
+
+	t.Run("requests are routed by network", func(t *testing.T) {
+		expectedResp := &types.AccountBalanceResponse{}
+
+		req := &types.AccountBalanceRequest{NetworkIdentifier: networkL1}
+		l1Backend.EXPECT().ShouldHandleRequest(req).Return(true)
+		l1Backend.EXPECT().AccountBalance(gomock.Any(), req).Return(expectedResp, nil)
+		resp, err := router.AccountBalance(context.Background(), req)
+		require.Nil(t, err)
+		require.Equal(t, expectedResp, resp)
+
+		req = &types.AccountBalanceRequest{NetworkIdentifier: networkC}
+		primaryBackend.EXPECT().ShouldHandleRequest(req).Return(true)
+		primaryBackend.EXPECT().AccountBalance(gomock.Any(), req).Return(expectedResp, nil)
+		resp, err = router.AccountBalance(context.Background(), req)
+		require.Nil(t, err)
+		require.Equal(t, expectedResp, resp)
+	})
+
+	t.Run("metadata bundles are exported through call", func(t *testing.T) {
+		req := &types.CallRequest{
+			NetworkIdentifier: networkL1,
+			Method:            mapper.MetadataBundleCallMethod,
+			Parameters: map[string]interface{}{
+				"requests": []interface{}{
+					map[string]interface{}{"options": map[string]interface{}{"from": "0xa"}},
+				},
+			},
+		}
+
+		_, err := router.Call(context.Background(), req)
+		require.Equal(t, ErrCallInvalidMethod, err)
+
+		l1.Construction = metadataConstruction{}
+		router.SetMetadataBundles(NewMetadataBundles("", []byte("key")))
+		resp, err := router.Call(context.Background(), req)
+		require.Nil(t, err)
+
+		var bundle MetadataBundle
+		require.NoError(t, types.UnmarshalMap(resp.Result, &bundle))
+		require.Equal(t, networkL1, bundle.NetworkIdentifier)
+		require.Len(t, bundle.Entries, 1)
+		require.Equal(t, map[string]interface{}{"nonce": "0xa"}, bundle.Entries[0].Metadata.Metadata)
+	})
+}
@@ -76,6 +76,10 @@ func (s AccountService) AccountBalance(
 		return s.cChainAtomicTxBackend.AccountBalance(ctx, req)
 	}
 
+	if !common.IsHexAddress(req.AccountIdentifier.Address) {
+		return nil, WrapError(ErrInvalidInput, "account address is not a valid hex address")
+	}
+
 	header, terr := blockHeaderFromInput(ctx, s.client, req.BlockIdentifier)
 	if terr != nil {
 		return nil, terr
@@ -102,53 +106,101 @@ func (s AccountService) AccountBalance(
 		return nil, WrapError(ErrClientError, err)
 	}
 
-	balances := []*types.Amount{}
-	if len(req.Currencies) == 0 {
-		balances = append(balances, mapper.AvaxAmount(avaxBalance))
+	currencies := req.Currencies
+	if len(currencies) == 0 {
+		currencies = []*types.Currency{s.config.Currency()}
+		if s.config.IncludeWhitelistedTokenBalances {
+			tokenCurrencies, err := s.whitelistedTokenCurrencies()
+			if err != nil {
+				return nil, WrapError(ErrClientError, err)
+			}
+			currencies = append(currencies, tokenCurrencies...)
+		}
 	}
 
-	for _, currency := range req.Currencies {
+	// token balances are fetched in a single batch and filled in afterwards
+	balances := make([]*types.Amount, len(currencies))
+	tokenIndices := []int{}
+	balanceOfCalls := []interfaces.CallMsg{}
+	for i, currency := range currencies {
 		value, ok := currency.Metadata[mapper.ContractAddressMetadata]
 		if !ok {
-			if utils.Equal(currency, mapper.AvaxCurrency) {
-				balances = append(balances, mapper.AvaxAmount(avaxBalance))
+			if utils.Equal(currency, s.config.Currency()) {
+				balances[i] = mapper.Amount(avaxBalance, currency)
 				continue
 			}
-			return nil, WrapError(ErrCallInvalidParams, errors.New("non-avax currencies must specify contractAddress in metadata"))
-		}
-
-		identifierAddress := req.AccountIdentifier.Address
-		if has0xPrefix(identifierAddress) {
-			identifierAddress = identifierAddress[2:42]
+			return nil, WrapError(ErrCallInvalidParams, errors.New("non-native currencies must specify contractAddress in metadata"))
 		}
 
-		data, err := hexutil.Decode(BalanceOfMethodPrefix + identifierAddress)
+		data, err := hexutil.Decode(BalanceOfMethodPrefix + address.Hex()[2:])
 		if err != nil {
 			return nil, WrapError(ErrCallInvalidParams, fmt.Errorf("%w: marshalling balanceOf call msg data failed", err))
 		}
 
 		contractAddress := common.HexToAddress(value.(string))
-		callMsg := interfaces.CallMsg{To: &contractAddress, Data: data}
-		response, err := s.client.CallContract(ctx, callMsg, header.Number)
-		if err != nil {
-			return nil, WrapError(ErrInternalError, err)
-		}
+		tokenIndices = append(tokenIndices, i)
+		balanceOfCalls = append(balanceOfCalls, interfaces.CallMsg{To: &contractAddress, Data: data})
+	}
 
-		amount := mapper.Erc20Amount(response, currency, false)
+	responses, err := batchCallContract(ctx, s.client, balanceOfCalls, header.Number)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+
+	for i, response := range responses {
+		index := tokenIndices[i]
+		amount := mapper.Erc20Amount(response, currencies[index], false)
+
+		// ERC721 balanceOf returns the number of owned tokens, whose IDs
+		// are listed in the amount metadata when they can be determined
+		if isErc721Currency(currencies[index]) {
+			tokenIDs, ok, err := erc721OwnedTokenIDs(
+				ctx,
+				s.client,
+				s.config.Erc721Index,
+				*balanceOfCalls[i].To,
+				address,
+				common.BytesToHash(response).Big(),
+				header.Number,
+			)
+			if err != nil {
+				return nil, WrapError(ErrInternalError, err)
+			}
+			if ok {
+				amount.Metadata = map[string]interface{}{
+					mapper.OwnedTokenIDsMetadata: tokenIDs,
+				}
+			}
+		}
 
-		balances = append(balances, amount)
+		balances[index] = amount
 	}
 
 	return &types.AccountBalanceResponse{
 		BlockIdentifier: &types.BlockIdentifier{
 			Index: header.Number.Int64(),
-			Hash:  header.Hash().String(),
+			Hash:  s.client.HeaderHash(header).String(),
 		},
 		Balances: balances,
 		Metadata: metadataMap,
 	}, nil
 }
 
+// whitelistedTokenCurrencies returns the currencies of all whitelisted tokens
+func (s AccountService) whitelistedTokenCurrencies() ([]*types.Currency, error) {
+	tokens := s.config.WhiteListedTokens()
+	currencies := make([]*types.Currency, 0, len(tokens))
+	for _, token := range tokens {
+		contractAddress := common.HexToAddress(token)
+		symbol, decimals, err := s.client.GetContractInfo(contractAddress, true)
+		if err != nil {
+			return nil, err
+		}
+		currencies = append(currencies, mapper.ToCurrency(symbol, decimals, contractAddress))
+	}
+	return currencies, nil
+}
+
 // AccountCoins implements the /account/coins endpoint
 func (s AccountService) AccountCoins(
 	ctx context.Context,
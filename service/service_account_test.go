@@ -2,13 +2,21 @@ package service
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/ava-labs/coreth/interfaces"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	ethtypes "github.com/ava-labs/coreth/core/types"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
 	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
 )
 
 func TestAccountBalance(t *testing.T) {
@@ -66,6 +74,141 @@ func TestAccountBalance(t *testing.T) {
 	})
 }
 
+func TestAccountBalanceErc20(t *testing.T) {
+	var (
+		address  = common.HexToAddress("0x197E90f9FAD81970bA7976f33CbD77088E5D7cf7")
+		token1   = common.HexToAddress("0xb31f66aa3c1e785363f0875a1b74e27b85fd66c7")
+		token2   = common.HexToAddress("0x49d5c2bdffac6ce2bfdb6640f4f80f226bc10bab")
+		multi    = common.HexToAddress(Multicall3Address)
+		header   = &ethtypes.Header{Number: big.NewInt(42)}
+		currency = func(token common.Address) *types.Currency {
+			return mapper.ToCurrency("TKN", 18, token)
+		}
+		balanceOf = func(token common.Address) gomock.Matcher {
+			return gomock.Cond(func(x any) bool {
+				msg := x.(interfaces.CallMsg)
+				return *msg.To == token
+			})
+		}
+		req = &types.AccountBalanceRequest{
+			NetworkIdentifier: &types.NetworkIdentifier{
+				Network: constants.FujiNetwork,
+			},
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: address.Hex(),
+			},
+			Currencies: []*types.Currency{currency(token1), mapper.AvaxCurrency, currency(token2)},
+		}
+	)
+
+	setup := func(t *testing.T) (*client.MockClient, *AccountService) {
+		ctrl := gomock.NewController(t)
+		backendMock := NewMockAccountBackend(ctrl)
+		backendMock.EXPECT().ShouldHandleRequest(gomock.Any()).Return(false).AnyTimes()
+
+		clientMock := client.NewMockClient(ctrl)
+		clientMock.EXPECT().HeaderByNumber(gomock.Any(), gomock.Nil()).Return(header, nil)
+		clientMock.EXPECT().NonceAt(gomock.Any(), address, header.Number).Return(uint64(1), nil)
+		clientMock.EXPECT().BalanceAt(gomock.Any(), address, header.Number).Return(big.NewInt(100), nil)
+		clientMock.EXPECT().HeaderHash(header).Return(header.Hash()).AnyTimes()
+
+		return clientMock, &AccountService{
+			config:                &Config{Mode: ModeOnline},
+			client:                clientMock,
+			pChainBackend:         backendMock,
+			cChainAtomicTxBackend: backendMock,
+		}
+	}
+
+	t.Run("balances are batched through multicall", func(t *testing.T) {
+		clientMock, service := setup(t)
+
+		response, err := multicall3.Methods["aggregate3"].Outputs.Pack([]multicall3Result{
+			{Success: true, ReturnData: common.BigToHash(big.NewInt(10)).Bytes()},
+			{Success: true, ReturnData: common.BigToHash(big.NewInt(20)).Bytes()},
+		})
+		require.NoError(t, err)
+		clientMock.EXPECT().CallContract(gomock.Any(), balanceOf(multi), header.Number).Return(response, nil)
+
+		resp, terr := service.AccountBalance(context.Background(), req)
+		require.Nil(t, terr)
+		require.Equal(t, []*types.Amount{
+			mapper.Amount(big.NewInt(10), currency(token1)),
+			mapper.AvaxAmount(big.NewInt(100)),
+			mapper.Amount(big.NewInt(20), currency(token2)),
+		}, resp.Balances)
+	})
+
+	t.Run("balances fall back to single calls without multicall", func(t *testing.T) {
+		clientMock, service := setup(t)
+
+		clientMock.EXPECT().CallContract(gomock.Any(), balanceOf(multi), header.Number).Return(nil, nil)
+		clientMock.EXPECT().CallContract(gomock.Any(), balanceOf(token1), header.Number).
+			Return(common.BigToHash(big.NewInt(10)).Bytes(), nil)
+		clientMock.EXPECT().CallContract(gomock.Any(), balanceOf(token2), header.Number).
+			Return(common.BigToHash(big.NewInt(20)).Bytes(), nil)
+
+		resp, terr := service.AccountBalance(context.Background(), req)
+		require.Nil(t, terr)
+		require.Equal(t, []*types.Amount{
+			mapper.Amount(big.NewInt(10), currency(token1)),
+			mapper.AvaxAmount(big.NewInt(100)),
+			mapper.Amount(big.NewInt(20), currency(token2)),
+		}, resp.Balances)
+	})
+
+	t.Run("short hex address is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		backendMock := NewMockAccountBackend(ctrl)
+		backendMock.EXPECT().ShouldHandleRequest(gomock.Any()).Return(false).AnyTimes()
+		service := &AccountService{
+			config:                &Config{Mode: ModeOnline},
+			client:                client.NewMockClient(ctrl),
+			pChainBackend:         backendMock,
+			cChainAtomicTxBackend: backendMock,
+		}
+
+		resp, terr := service.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: req.NetworkIdentifier,
+			AccountIdentifier: &types.AccountIdentifier{Address: "0x197E90f9"},
+			Currencies:        []*types.Currency{mapper.AvaxCurrency},
+		})
+		require.Nil(t, resp)
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+
+	t.Run("failing single call is reported", func(t *testing.T) {
+		clientMock, service := setup(t)
+
+		clientMock.EXPECT().CallContract(gomock.Any(), balanceOf(multi), header.Number).Return(nil, errors.New("execution reverted"))
+		clientMock.EXPECT().CallContract(gomock.Any(), balanceOf(token1), header.Number).Return(nil, errors.New("execution reverted"))
+
+		resp, terr := service.AccountBalance(context.Background(), req)
+		require.Nil(t, resp)
+		require.Equal(t, ErrInternalError.Code, terr.Code)
+	})
+
+	t.Run("whitelisted token balances are returned when no currency is requested", func(t *testing.T) {
+		clientMock, service := setup(t)
+		service.config.IncludeWhitelistedTokenBalances = true
+		service.config.TokenWhiteList = []string{token1.Hex()}
+
+		clientMock.EXPECT().GetContractInfo(token1, true).Return("TKN", uint8(18), nil)
+		clientMock.EXPECT().CallContract(gomock.Any(), balanceOf(token1), header.Number).
+			Return(common.BigToHash(big.NewInt(10)).Bytes(), nil)
+
+		resp, terr := service.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+			NetworkIdentifier: req.NetworkIdentifier,
+			AccountIdentifier: req.AccountIdentifier,
+		})
+		require.Nil(t, terr)
+		require.Equal(t, []*types.Amount{
+			mapper.AvaxAmount(big.NewInt(100)),
+			mapper.Amount(big.NewInt(10), currency(token1)),
+		}, resp.Balances)
+	})
+}
+
 func TestAccountCoins(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	pBackendMock := NewMockAccountBackend(ctrl)
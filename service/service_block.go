@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/coreth/core"
@@ -41,6 +42,11 @@ type BlockService struct {
 	pChainBackend BlockBackend
 
 	genesisBlock *types.Block
+
+	// chainIDs maps the blockchain ids atomic transactions may be exported to
+	// to their aliases. It is lazily initialized from the node.
+	chainIDs     map[ids.ID]constants.ChainIDAlias
+	chainIDsLock sync.Mutex
 }
 
 // NewBlockService returns a new block servicer
@@ -104,7 +110,7 @@ func (s *BlockService) Block(
 
 	blockIdentifier = &types.BlockIdentifier{
 		Index: block.Number().Int64(),
-		Hash:  block.Hash().String(),
+		Hash:  s.client.HeaderHash(block.Header()).String(),
 	}
 
 	if block.ParentHash().String() != s.config.GenesisBlockHash {
@@ -115,7 +121,7 @@ func (s *BlockService) Block(
 
 		parentBlockIdentifier = &types.BlockIdentifier{
 			Index: parentBlock.Number.Int64(),
-			Hash:  parentBlock.Hash().String(),
+			Hash:  s.client.HeaderHash(parentBlock).String(),
 		}
 	} else {
 		parentBlockIdentifier = s.genesisBlock.BlockIdentifier
@@ -126,7 +132,7 @@ func (s *BlockService) Block(
 		return nil, terr
 	}
 
-	crosstx, terr := s.parseCrossChainTransactions(request.NetworkIdentifier, block)
+	crosstx, terr := s.parseCrossChainTransactions(ctx, request.NetworkIdentifier, block)
 	if terr != nil {
 		return nil, terr
 	}
@@ -194,7 +200,7 @@ func (s *BlockService) fetchTransactions(
 ) ([]*types.Transaction, *types.Error) {
 	transactions := []*types.Transaction{}
 
-	trace, flattened, err := s.client.TraceBlockByHash(ctx, block.Hash().String())
+	trace, flattened, err := s.client.TraceBlockByHash(ctx, s.client.HeaderHash(block.Header()).String())
 	if err != nil {
 		return nil, WrapError(ErrClientError, err)
 	}
@@ -228,7 +234,7 @@ func (s *BlockService) fetchTransaction(
 		return nil, WrapError(ErrClientError, err)
 	}
 
-	transaction, err := mapper.Transaction(header, tx, msg, receipt, trace, flattened, s.client, s.config.IsAnalyticsMode(), s.config.TokenWhiteList, s.config.IndexUnknownTokens)
+	transaction, err := mapper.Transaction(header, tx, msg, receipt, trace, flattened, s.client, s.config.Currency(), s.config.IsAnalyticsMode(), s.config.WhiteListedTokens(), s.config.IndexUnknownTokens)
 	if err != nil {
 		return nil, WrapError(ErrInternalError, err)
 	}
@@ -237,14 +243,21 @@ func (s *BlockService) fetchTransaction(
 }
 
 func (s *BlockService) parseCrossChainTransactions(
+	ctx context.Context,
 	networkIdentifier *types.NetworkIdentifier,
 	block *ethtypes.Block,
 ) ([]*types.Transaction, *types.Error) {
 	result := []*types.Transaction{}
 
+	// atomic transactions only exist on the C-chain
+	if !s.config.IsCChain() {
+		return result, nil
+	}
+
 	// This map is used to create addresses for cross chain export outputs
-	chainIDToAliasMapping := map[ids.ID]constants.ChainIDAlias{
-		ids.Empty: constants.PChain,
+	chainIDToAliasMapping, err := s.exportChainIDs(ctx)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
 	}
 	crossTxs, err := mapper.CrossChainTransactions(networkIdentifier, chainIDToAliasMapping, s.config.AvaxAssetID, block, s.config.AP5Activation)
 	if err != nil {
@@ -263,6 +276,27 @@ func (s *BlockService) parseCrossChainTransactions(
 	return result, nil
 }
 
+// exportChainIDs returns the aliases of the chains C-chain atomic transactions
+// can be exported to, fetching the X-chain id from the node on first use.
+func (s *BlockService) exportChainIDs(ctx context.Context) (map[ids.ID]constants.ChainIDAlias, error) {
+	s.chainIDsLock.Lock()
+	defer s.chainIDsLock.Unlock()
+
+	if s.chainIDs != nil {
+		return s.chainIDs, nil
+	}
+
+	xChainID, err := s.client.GetBlockchainID(ctx, constants.XChain.String())
+	if err != nil {
+		return nil, err
+	}
+	s.chainIDs = map[ids.ID]constants.ChainIDAlias{
+		ids.Empty: constants.PChain,
+		xChainID:  constants.XChain,
+	}
+	return s.chainIDs, nil
+}
+
 func (s *BlockService) isGenesisBlockRequest(id *types.PartialBlockIdentifier) bool {
 	if number := id.Index; number != nil {
 		return *number == s.genesisBlock.BlockIdentifier.Index
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	avaconstants "github.com/ava-labs/avalanchego/utils/constants"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+func TestBlockAtomicExport(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		avaxAssetID = ids.GenerateTestID()
+		cChainID    = ids.GenerateTestID()
+		xChainID    = ids.GenerateTestID()
+		parentHash  = common.HexToHash("0x8c8c3a3ad3d5a3b2d5f1f1c3e5e3f0f3e4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9")
+		fromAddress = common.HexToAddress("0x3158e80abD5A1e1aa716003C9Db096792C379621")
+		toAddress   = "X-fuji1wmd9dfrqpud6daq0cde47u0r7pkrr46ep60399"
+	)
+
+	to, err := address.ParseToID(toAddress)
+	require.NoError(t, err)
+
+	exportTx := &evm.Tx{UnsignedAtomicTx: &evm.UnsignedExportTx{
+		NetworkID:        avaconstants.FujiID,
+		BlockchainID:     cChainID,
+		DestinationChain: xChainID,
+		Ins: []evm.EVMInput{{
+			Address: fromAddress,
+			Amount:  10_000_000,
+			AssetID: avaxAssetID,
+		}},
+		ExportedOutputs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 9_000_000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{to},
+				},
+			},
+		}},
+	}}
+	require.NoError(t, exportTx.Sign(evm.Codec, nil))
+	extData, err := evm.Codec.Marshal(0, []*evm.Tx{exportTx})
+	require.NoError(t, err)
+
+	block := ethtypes.NewBlockWithExtData(
+		&ethtypes.Header{
+			ParentHash: parentHash,
+			Number:     big.NewInt(10),
+			Time:       1,
+		},
+		nil,
+		nil,
+		nil,
+		nil,
+		extData,
+		true,
+	)
+
+	ctrl := gomock.NewController(t)
+	clientMock := client.NewMockClient(ctrl)
+	pBackendMock := NewMockBlockBackend(ctrl)
+	service := NewBlockService(
+		&Config{
+			Mode:             ModeOnline,
+			AvaxAssetID:      avaxAssetID.String(),
+			GenesisBlockHash: parentHash.String(),
+		},
+		clientMock,
+		pBackendMock,
+	)
+
+	req := &types.BlockRequest{
+		NetworkIdentifier: &types.NetworkIdentifier{
+			Network: constants.FujiNetwork,
+		},
+		BlockIdentifier: &types.PartialBlockIdentifier{
+			Index: types.Int64(10),
+		},
+	}
+
+	pBackendMock.EXPECT().ShouldHandleRequest(req).Return(false).Times(2)
+	clientMock.EXPECT().BlockByNumber(ctx, big.NewInt(10)).Return(block, nil).Times(2)
+	clientMock.EXPECT().HeaderHash(gomock.Any()).Return(block.Hash()).AnyTimes()
+	clientMock.EXPECT().TraceBlockByHash(ctx, block.Hash().String()).Return(nil, nil, nil).Times(2)
+	// the X-chain id is only fetched once
+	clientMock.EXPECT().GetBlockchainID(ctx, constants.XChain.String()).Return(xChainID, nil)
+
+	for i := 0; i < 2; i++ {
+		resp, terr := service.Block(ctx, req)
+		require.Nil(t, terr)
+		require.Len(t, resp.Block.Transactions, 1)
+
+		tx := resp.Block.Transactions[0]
+		require.Equal(t, exportTx.ID().String(), tx.TransactionIdentifier.Hash)
+		require.Equal(t, mapper.OpExport, tx.Operations[0].Type)
+		require.Equal(t, fromAddress.Hex(), tx.Operations[0].Account.Address)
+		require.Equal(t, xChainID.String(), tx.Operations[0].Metadata["destination_chain"])
+
+		exportedOutputs, ok := tx.Metadata[mapper.MetadataExportedOutputs].([]*types.Operation)
+		require.True(t, ok)
+		require.Len(t, exportedOutputs, 1)
+		require.Equal(t, toAddress, exportedOutputs[0].Account.Address)
+		require.Equal(t, "9000000", exportedOutputs[0].Amount.Value)
+	}
+}
@@ -67,6 +67,10 @@ type ConstructionBackend interface {
 	ConstructionHash(ctx context.Context, req *types.ConstructionHashRequest) (*types.TransactionIdentifierResponse, *types.Error)
 	// ConstructionSubmit implements /construction/submit endpoint for this backend
 	ConstructionSubmit(ctx context.Context, req *types.ConstructionSubmitRequest) (*types.TransactionIdentifierResponse, *types.Error)
+	// ConstructionSimulate dry-runs a transaction of this backend against the latest state
+	ConstructionSimulate(ctx context.Context, req *types.ConstructionParseRequest) (*SimulationResult, *types.Error)
+	// ConstructionTxStatus returns the status of a transaction of this backend submitted to the node
+	ConstructionTxStatus(ctx context.Context, req *TxStatusRequest) (*TxStatus, *types.Error)
 }
 
 // ConstructionService implements /construction/* endpoints
@@ -103,7 +107,15 @@ func (s ConstructionService) ConstructionMetadata(
 	req *types.ConstructionMetadataRequest,
 ) (*types.ConstructionMetadataResponse, *types.Error) {
 	if s.config.IsOfflineMode() {
-		return nil, ErrUnavailableOffline
+		if s.config.MetadataBundles == nil {
+			return nil, ErrUnavailableOffline
+		}
+
+		resp, err := s.config.MetadataBundles.Metadata(req)
+		if err != nil {
+			return nil, WrapError(ErrUnavailableOffline, err)
+		}
+		return resp, nil
 	}
 
 	if s.pChainBackend.ShouldHandleRequest(req) {
@@ -123,6 +135,27 @@ func (s ConstructionService) ConstructionMetadata(
 		return nil, WrapError(ErrInvalidInput, "from address is not provided")
 	}
 
+	if input.Permit != nil {
+		return s.createPermitMetadata(ctx, &input)
+	}
+
+	// a pending transaction is replaced by reusing its nonce with a higher gas price
+	var replacedTx *ethtypes.Transaction
+	replacedHash := input.SpeedUpTxHash
+	if len(input.CancelTxHash) > 0 {
+		replacedHash = input.CancelTxHash
+	}
+	if len(replacedHash) > 0 {
+		var terr *types.Error
+		if replacedTx, terr = s.replacedTx(ctx, input.From, replacedHash); terr != nil {
+			return nil, terr
+		}
+		if input.Nonce != nil && input.Nonce.Uint64() != replacedTx.Nonce() {
+			return nil, WrapError(ErrInvalidInput, "nonce does not match the nonce of the replaced transaction")
+		}
+		input.Nonce = new(big.Int).SetUint64(replacedTx.Nonce())
+	}
+
 	var nonce uint64
 	var err error
 	if input.Nonce == nil {
@@ -151,9 +184,22 @@ func (s ConstructionService) ConstructionMetadata(
 		gasPrice = input.GasPrice
 	}
 
+	if replacedTx != nil {
+		minGasPrice := minReplacementGasPrice(replacedTx)
+		switch {
+		case input.GasPrice != nil && input.GasPrice.Cmp(minGasPrice) < 0:
+			return nil, WrapError(
+				ErrInvalidInput,
+				fmt.Errorf("gas price %s is lower than the minimum replacement gas price %s", input.GasPrice, minGasPrice),
+			)
+		case gasPrice.Cmp(minGasPrice) < 0:
+			gasPrice = minGasPrice
+		}
+	}
+
 	var gasLimit uint64
 	if input.GasLimit == nil {
-		if input.Currency == nil || types.Hash(input.Currency) == types.Hash(mapper.AvaxCurrency) {
+		if input.Currency == nil || types.Hash(input.Currency) == types.Hash(s.config.Currency()) {
 			gasLimit, err = s.getNativeTransferGasLimit(ctx, input.To, input.From, input.Value)
 			if err != nil {
 				return nil, WrapError(ErrClientError, err)
@@ -188,9 +234,12 @@ func (s ConstructionService) ConstructionMetadata(
 		Nonce:           nonce,
 		GasPrice:        gasPrice,
 		GasLimit:        gasLimit,
+		ReplacedTxHash:  replacedHash,
+		Cancel:          len(input.CancelTxHash) > 0,
 		ContractData:    input.ContractData,
 		MethodSignature: input.MethodSignature,
 		MethodArgs:      input.MethodArgs,
+		ContractABI:     input.ContractABI,
 	}
 
 	if input.Metadata != nil {
@@ -208,7 +257,7 @@ func (s ConstructionService) ConstructionMetadata(
 	return &types.ConstructionMetadataResponse{
 		Metadata: metadataMap,
 		SuggestedFee: []*types.Amount{
-			mapper.AvaxAmount(big.NewInt(suggestedFee)),
+			mapper.Amount(big.NewInt(suggestedFee), s.config.Currency()),
 		},
 	}, nil
 }
@@ -232,6 +281,10 @@ func (s ConstructionService) ConstructionHash(
 		return s.cChainAtomicTxBackend.ConstructionHash(ctx, req)
 	}
 
+	if isPermitPayload(req.SignedTransaction) {
+		return hashPermit(req)
+	}
+
 	var wrappedTx signedTransactionWrapper
 	if err := json.Unmarshal([]byte(req.SignedTransaction), &wrappedTx); err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
@@ -273,6 +326,10 @@ func (s ConstructionService) ConstructionCombine(
 		return s.cChainAtomicTxBackend.ConstructionCombine(ctx, req)
 	}
 
+	if isPermitPayload(req.UnsignedTransaction) {
+		return combinePermit(req)
+	}
+
 	var unsignedTx transaction
 	if err := json.Unmarshal([]byte(req.UnsignedTransaction), &unsignedTx); err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
@@ -293,6 +350,10 @@ func (s ConstructionService) ConstructionCombine(
 		return nil, WrapError(ErrInvalidInput, err)
 	}
 
+	if err := verifySigner(signer, signedTx, unsignedTx.From, req.Signatures[0]); err != nil {
+		return nil, err
+	}
+
 	signedTxJSON, err := signedTx.MarshalJSON()
 	if err != nil {
 		return nil, WrapError(ErrInternalError, err)
@@ -301,6 +362,8 @@ func (s ConstructionService) ConstructionCombine(
 	wrappedSignedTx := signedTransactionWrapper{
 		SignedTransaction: signedTxJSON,
 		Currency:          unsignedTx.Currency,
+		MethodSignature:   unsignedTx.MethodSignature,
+		ContractABI:       unsignedTx.ContractABI,
 	}
 
 	wrappedSignedTxJSON, err := json.Marshal(wrappedSignedTx)
@@ -362,6 +425,10 @@ func (s ConstructionService) ConstructionParse(
 		return s.cChainAtomicTxBackend.ConstructionParse(ctx, req)
 	}
 
+	if isPermitPayload(req.Transaction) {
+		return parsePermit(req)
+	}
+
 	var tx transaction
 
 	if !req.Signed {
@@ -387,6 +454,8 @@ func (s ConstructionService) ConstructionParse(
 		tx.GasLimit = t.Gas()
 		tx.ChainID = s.config.ChainID
 		tx.Currency = wrappedTx.Currency
+		tx.MethodSignature = wrappedTx.MethodSignature
+		tx.ContractABI = wrappedTx.ContractABI
 
 		msg, err := core.TransactionToMessage(&t, s.config.Signer(), nil)
 		if err != nil {
@@ -417,6 +486,10 @@ func (s ConstructionService) ConstructionParse(
 			ops, checkFrom, wrappedErr = createTransferOps(tx)
 		case unwrapMethodID:
 			ops, checkFrom, wrappedErr = createUnwrapOps(tx)
+		case approveMethodID:
+			ops, checkFrom, wrappedErr = createErc20ApproveOps(tx)
+		case safeTransferFromMethodID:
+			ops, checkFrom, wrappedErr = createErc721TransferOps(tx)
 		default:
 			ops, checkFrom, wrappedErr = createGenericContractCallOps(tx)
 		}
@@ -599,6 +672,18 @@ func createGenericContractCallOps(tx transaction) ([]*types.Operation, *string,
 			},
 		},
 	}
+
+	// Decode the call so that signers can review its arguments
+	if len(tx.MethodSignature) > 0 {
+		methodArgs, err := decodeMethodArgs(tx.MethodSignature, tx.ContractABI, tx.Data)
+		if err != nil {
+			return nil, nil, WrapError(ErrInvalidInput, err)
+		}
+		ops[0].Metadata = map[string]interface{}{
+			"method_signature": tx.MethodSignature,
+			"method_args":      methodArgs,
+		}
+	}
 	return ops, &checkFrom, nil
 }
 
@@ -625,6 +710,10 @@ func (s ConstructionService) ConstructionPayloads(
 		return s.cChainAtomicTxBackend.ConstructionPayloads(ctx, req)
 	}
 
+	if isErc20ApproveRequest(req.Operations) && isPermit(req.Metadata) {
+		return s.createPermitPayloads(req)
+	}
+
 	var (
 		tx         *ethtypes.Transaction
 		unsignedTx *transaction
@@ -633,6 +722,12 @@ func (s ConstructionService) ConstructionPayloads(
 	)
 
 	switch {
+	case isCancelPayload(req.Metadata):
+		tx, unsignedTx, checkFrom, wrappedErr = s.createCancelPayload(req)
+	case isErc20ApproveRequest(req.Operations):
+		tx, unsignedTx, checkFrom, wrappedErr = s.createErc20ApprovePayload(req)
+	case isErc721TransferRequest(req.Operations):
+		tx, unsignedTx, checkFrom, wrappedErr = s.createErc721TransferPayload(req)
 	case isUnwrapRequest(req.Metadata):
 		tx, unsignedTx, checkFrom, wrappedErr = s.createUnwrapPayload(req)
 	case isGenericContractCall(req.Metadata):
@@ -667,7 +762,7 @@ func (s ConstructionService) ConstructionPayloads(
 func (s ConstructionService) createTransferPayload(
 	req *types.ConstructionPayloadsRequest,
 ) (*ethtypes.Transaction, *transaction, *string, *types.Error) {
-	operationDescriptions, err := createTransferOperationDescription(req.Operations)
+	operationDescriptions, err := createTransferOperationDescription(req.Operations, s.config.Currency())
 	if err != nil {
 		return nil, nil, nil, WrapError(ErrInvalidInput, err.Error())
 	}
@@ -706,7 +801,7 @@ func (s ConstructionService) createTransferPayload(
 	}
 	var transferData []byte
 	var sendToAddress common.Address
-	if types.Hash(fromCurrency) == types.Hash(mapper.AvaxCurrency) {
+	if types.Hash(fromCurrency) == types.Hash(s.config.Currency()) {
 		transferData = []byte{}
 		sendToAddress = common.HexToAddress(checkTo)
 	} else {
@@ -798,7 +893,7 @@ func (s ConstructionService) createUnwrapPayload(
 		)
 	}
 
-	if !mapper.EqualFoldContains(s.config.BridgeTokenList, contract) {
+	if !mapper.EqualFoldContains(s.config.BridgeTokens(), contract) {
 		return nil, nil, nil, WrapError(
 			ErrInvalidInput,
 			fmt.Errorf(
@@ -922,6 +1017,9 @@ func (s ConstructionService) createGenericContractCallPayload(req *types.Constru
 		GasLimit: tx.Gas(),
 		ChainID:  chainID,
 		Currency: fromCurrency,
+
+		MethodSignature: metadata.MethodSignature,
+		ContractABI:     metadata.ContractABI,
 	}
 	return tx, unsignedTx, &checkFrom, nil
 }
@@ -949,6 +1047,21 @@ func (s ConstructionService) ConstructionPreprocess(
 	)
 
 	switch {
+	case isCancelRequest(req.Metadata):
+		preprocessOptions, terr = createCancelPreprocessOptions(req, s.config.Currency())
+		if terr != nil {
+			return nil, terr
+		}
+	case isErc20ApproveRequest(req.Operations):
+		preprocessOptions, terr = createErc20ApprovePreprocessOptions(req)
+		if terr != nil {
+			return nil, terr
+		}
+	case isErc721TransferRequest(req.Operations):
+		preprocessOptions, terr = createErc721TransferPreprocessOptions(req)
+		if terr != nil {
+			return nil, terr
+		}
 	case isUnwrapRequest(req.Metadata):
 		operationDescriptions, err = s.CreateUnwrapOperationDescription(req.Operations)
 		if err != nil {
@@ -970,7 +1083,7 @@ func (s ConstructionService) ConstructionPreprocess(
 			return nil, terr
 		}
 	default:
-		operationDescriptions, err = createTransferOperationDescription(req.Operations)
+		operationDescriptions, err = createTransferOperationDescription(req.Operations, s.config.Currency())
 		if err != nil {
 			return nil, WrapError(ErrInvalidInput, err.Error())
 		}
@@ -1019,6 +1132,12 @@ func (s ConstructionService) ConstructionPreprocess(
 		}
 		preprocessOptions.Nonce = bigObj
 	}
+	if !isCancelRequest(req.Metadata) {
+		preprocessOptions.SpeedUpTxHash, terr = replacedTxHash(req.Metadata, speedUpTxHashKey)
+		if terr != nil {
+			return nil, terr
+		}
+	}
 
 	marshaled, err := mapper.MarshalJSONMap(preprocessOptions)
 	if err != nil {
@@ -1053,6 +1172,10 @@ func (s ConstructionService) ConstructionSubmit(
 		return s.cChainAtomicTxBackend.ConstructionSubmit(ctx, req)
 	}
 
+	if isPermitPayload(req.SignedTransaction) {
+		return nil, WrapError(ErrInvalidInput, errPermitNotSubmittable)
+	}
+
 	var wrappedTx signedTransactionWrapper
 	if err := json.Unmarshal([]byte(req.SignedTransaction), &wrappedTx); err != nil {
 		return nil, WrapError(ErrInvalidInput, err)
@@ -1076,6 +1199,7 @@ func (s ConstructionService) ConstructionSubmit(
 
 func createTransferOperationDescription(
 	operations []*types.Operation,
+	nativeCurrency *types.Currency,
 ) ([]*parser.OperationDescription, error) {
 	if len(operations) != 2 {
 		return nil, errors.New("invalid number of operations")
@@ -1092,8 +1216,8 @@ func createTransferOperationDescription(
 		return nil, errors.New("currency info doesn't match between the operations")
 	}
 
-	if types.Hash(firstCurrency) == types.Hash(mapper.AvaxCurrency) {
-		return createOperationDescriptionTransfer(mapper.AvaxCurrency, mapper.OpCall), nil
+	if types.Hash(firstCurrency) == types.Hash(nativeCurrency) {
+		return createOperationDescriptionTransfer(nativeCurrency, mapper.OpCall), nil
 	}
 
 	// Not Native Avax, we require contractInfo in metadata.
@@ -1113,7 +1237,7 @@ func (s ConstructionService) CreateUnwrapOperationDescription(
 
 	firstCurrency := operations[0].Amount.Currency
 
-	if types.Hash(firstCurrency) == types.Hash(mapper.AvaxCurrency) {
+	if types.Hash(firstCurrency) == types.Hash(s.config.Currency()) {
 		return nil, errors.New("cannot unwrap native avax")
 	}
 	tokenAddress, firstOk := firstCurrency.Metadata[mapper.ContractAddressMetadata].(string)
@@ -1123,7 +1247,7 @@ func (s ConstructionService) CreateUnwrapOperationDescription(
 		return nil, errors.New("non-native currency must have contractAddress in metadata")
 	}
 
-	if !mapper.EqualFoldContains(s.config.BridgeTokenList, tokenAddress) {
+	if !mapper.EqualFoldContains(s.config.BridgeTokens(), tokenAddress) {
 		return nil, errors.New("only configured bridge tokens may use try to use unwrap function")
 	}
 
@@ -1374,7 +1498,11 @@ func createGenericContractCallPreprocessOptions(
 	if !ok {
 		return nil, WrapError(ErrInvalidInput, fmt.Errorf("%s is not a valid method signature string", v))
 	}
-	data, err := constructContractCallDataGeneric(methodSigStringObj, req.Metadata["method_args"])
+	contractABI, err := contractABIString(req.Metadata["contract_abi"])
+	if err != nil {
+		return nil, WrapError(ErrInvalidInput, err.Error())
+	}
+	data, err := constructContractCallDataGeneric(methodSigStringObj, contractABI, req.Metadata["method_args"])
 	if err != nil {
 		return nil, WrapError(ErrInvalidInput, err.Error())
 	}
@@ -1389,6 +1517,7 @@ func createGenericContractCallPreprocessOptions(
 		ContractData:           hexutil.Encode(data),
 		MethodSignature:        methodSigStringObj,
 		MethodArgs:             req.Metadata["method_args"],
+		ContractABI:            contractABI,
 	}, nil
 }
 
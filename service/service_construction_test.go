@@ -1048,7 +1048,10 @@ func TestPreprocessMetadata(t *testing.T) {
 	t.Run("arbitrary contract call flow", func(t *testing.T) {
 		contractCallIntent := `[{"operation_identifier":{"index":0},"type":"CALL","account":{"address":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309"},"amount":{"value":"0","currency":{"symbol":"TEST","decimals":18}}},{"operation_identifier":{"index":1},"type":"CALL","account":{"address":"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"},"amount":{"value":"0","currency":{"symbol":"TEST","decimals":18}}}]`
 		service := ConstructionService{
-			config:                &Config{Mode: ModeOnline},
+			config: &Config{
+				Mode:    ModeOnline,
+				ChainID: big.NewInt(rosConst.FujiChainID),
+			},
 			client:                client,
 			pChainBackend:         skippedBackend,
 			cChainAtomicTxBackend: skippedBackend,
@@ -1136,6 +1139,36 @@ func TestPreprocessMetadata(t *testing.T) {
 				},
 			},
 		}, metadataResponse)
+
+		// the call arguments are decoded by parse
+		payloadsResponse, err := service.ConstructionPayloads(
+			ctx,
+			&types.ConstructionPayloadsRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+				Metadata:          metadataResponse.Metadata,
+			},
+		)
+		require.Nil(t, err)
+
+		parseResponse, err := service.ConstructionParse(
+			ctx,
+			&types.ConstructionParseRequest{
+				NetworkIdentifier: networkIdentifier,
+				Transaction:       payloadsResponse.UnsignedTransaction,
+			},
+		)
+		require.Nil(t, err)
+		require.Equal(t, map[string]interface{}{
+			"method_signature": "deploy(bytes32,address,address,address,address)",
+			"method_args": []interface{}{
+				"0x3100000000000000000000000000000000000000000000000000000000000000",
+				"0x323E3ab04A3795ad79cc92378FCDb0a0Aec51ba5",
+				"0x14e37C2E9cd255404Bd35B4542fD9CCaa070Aed6",
+				"0x323E3ab04A3795ad79cc92378FCDb0a0Aec51ba5",
+				"0x14e37C2E9cd255404Bd35B4542fD9CCaa070Aed6",
+			},
+		}, parseResponse.Operations[0].Metadata)
 	})
 }
 
@@ -37,6 +37,7 @@ type NetworkService struct {
 	client        client.Client
 	pChainBackend NetworkBackend
 	genesisBlock  *types.Block
+	syncStatus    *SyncStatusTracker
 }
 
 // NewNetworkService returns a new network servicer
@@ -52,6 +53,7 @@ func NewNetworkService(
 		client:        client,
 		pChainBackend: pChainBackend,
 		genesisBlock:  genesisBlock,
+		syncStatus:    NewSyncStatusTracker(config.SyncStatus),
 	}
 }
 
@@ -89,21 +91,26 @@ func (s *NetworkService) NetworkStatus(
 	peers := mapper.Peers(infoPeers)
 
 	// Check if all C/X chains are ready
-	if err := checkBootstrapStatus(ctx, s.client); err != nil {
-		if err.Code == ErrNotReady.Code {
-			return &types.NetworkStatusResponse{
-				CurrentBlockTimestamp:  s.genesisBlock.Timestamp,
-				CurrentBlockIdentifier: s.genesisBlock.BlockIdentifier,
-				GenesisBlockIdentifier: s.genesisBlock.BlockIdentifier,
-				SyncStatus:             mapper.StageBootstrap,
-				Peers:                  peers,
-			}, nil
+	bootstrapped := true
+	if err := checkBootstrapStatus(ctx, s.client, s.config); err != nil {
+		if err.Code != ErrNotReady.Code {
+			return nil, err
 		}
-		return nil, err
+		bootstrapped = false
 	}
 
 	// Fetch the latest block
 	blockHeader, err := s.client.HeaderByNumber(ctx, nil)
+	if !bootstrapped && (err != nil || blockHeader == nil) {
+		// the node may not serve blocks until it is done bootstrapping
+		return &types.NetworkStatusResponse{
+			CurrentBlockTimestamp:  s.genesisBlock.Timestamp,
+			CurrentBlockIdentifier: s.genesisBlock.BlockIdentifier,
+			GenesisBlockIdentifier: s.genesisBlock.BlockIdentifier,
+			SyncStatus:             s.syncStatus.SyncStatus(ctx, bootstrapped, s.genesisBlock.BlockIdentifier.Index, s.genesisBlock.Timestamp),
+			Peers:                  peers,
+		}, nil
+	}
 	if err != nil {
 		return nil, WrapError(ErrClientError, err)
 	}
@@ -120,17 +127,18 @@ func (s *NetworkService) NetworkStatus(
 		return nil, WrapError(ErrClientError, "genesis block not found")
 	}
 
+	currentBlockTimestamp := int64(blockHeader.Time * utils.MillisecondsInSecond)
 	return &types.NetworkStatusResponse{
-		CurrentBlockTimestamp: int64(blockHeader.Time * utils.MillisecondsInSecond),
+		CurrentBlockTimestamp: currentBlockTimestamp,
 		CurrentBlockIdentifier: &types.BlockIdentifier{
 			Index: blockHeader.Number.Int64(),
-			Hash:  blockHeader.Hash().String(),
+			Hash:  s.client.HeaderHash(blockHeader).String(),
 		},
 		GenesisBlockIdentifier: &types.BlockIdentifier{
 			Index: genesisHeader.Number.Int64(),
-			Hash:  genesisHeader.Hash().String(),
+			Hash:  s.client.HeaderHash(genesisHeader).String(),
 		},
-		SyncStatus: mapper.StageSynced,
+		SyncStatus: s.syncStatus.SyncStatus(ctx, bootstrapped, blockHeader.Number.Int64(), currentBlockTimestamp),
 		Peers:      peers,
 	}, nil
 }
@@ -160,7 +168,18 @@ func (s *NetworkService) NetworkOptions(
 	}, nil
 }
 
-func checkBootstrapStatus(ctx context.Context, client client.Client) *types.Error {
+func checkBootstrapStatus(ctx context.Context, client client.Client, config *Config) *types.Error {
+	if !config.IsCChain() {
+		ready, err := client.IsBootstrapped(ctx, config.EVMChain)
+		if err != nil {
+			return WrapError(ErrClientError, err)
+		}
+		if !ready {
+			return WrapError(ErrNotReady, config.EVMChain+" chain is not ready")
+		}
+		return nil
+	}
+
 	cReady, err := client.IsBootstrapped(ctx, constants.CChain.String())
 	if err != nil {
 		return WrapError(ErrClientError, err)
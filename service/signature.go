@@ -0,0 +1,53 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+// verifySigner checks that [signature], applied to [signedTx], was produced by
+// [from] over the signing hash of the transaction for the chain ID of [signer].
+// A signature over the hash for another chain ID recovers another address, so
+// it is rejected as well.
+func verifySigner(
+	signer ethtypes.Signer,
+	signedTx *ethtypes.Transaction,
+	from string,
+	signature *types.Signature,
+) *types.Error {
+	if payload := signature.SigningPayload; payload != nil && len(payload.Bytes) > 0 {
+		if !bytes.Equal(payload.Bytes, signer.Hash(signedTx).Bytes()) {
+			return WrapError(
+				ErrSigningPayloadMismatch,
+				fmt.Sprintf("signing payload is not the hash of the transaction for chain id %s", signer.ChainID()),
+			)
+		}
+	}
+
+	sender, err := ethtypes.Sender(signer, signedTx)
+	if err != nil {
+		return WrapError(ErrInvalidInput, err)
+	}
+
+	expected := []string{from}
+	if payload := signature.SigningPayload; payload != nil && payload.AccountIdentifier != nil {
+		expected = append(expected, payload.AccountIdentifier.Address)
+	}
+	for _, address := range expected {
+		if len(address) == 0 {
+			continue
+		}
+		if !common.IsHexAddress(address) || common.HexToAddress(address) != sender {
+			return WrapError(
+				ErrSignerMismatch,
+				fmt.Sprintf("signature recovers %s, expected %s", sender.Hex(), address),
+			)
+		}
+	}
+	return nil
+}
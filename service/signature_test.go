@@ -0,0 +1,59 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+func TestVerifySigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	other := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+
+	signer := ethtypes.LatestSignerForChainID(big.NewInt(43113))
+	otherSigner := ethtypes.LatestSignerForChainID(big.NewInt(43114))
+	tx := ethtypes.NewTransaction(0, ethcommon.HexToAddress(other), big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	sign := func(signer ethtypes.Signer) (*ethtypes.Transaction, *types.Signature) {
+		hash := signer.Hash(tx)
+		sig, err := crypto.Sign(hash.Bytes(), key)
+		require.NoError(t, err)
+		signedTx, err := tx.WithSignature(signer, sig)
+		require.NoError(t, err)
+		return signedTx, &types.Signature{
+			SigningPayload: &types.SigningPayload{
+				AccountIdentifier: &types.AccountIdentifier{Address: from},
+				Bytes:             hash.Bytes(),
+			},
+			SignatureType: types.EcdsaRecovery,
+			Bytes:         sig,
+		}
+	}
+
+	signedTx, signature := sign(signer)
+	require.Nil(t, verifySigner(signer, signedTx, from, signature))
+
+	tErr := verifySigner(signer, signedTx, other, signature)
+	require.Equal(t, ErrSignerMismatch.Code, tErr.Code)
+
+	// a signature produced for another chain ID
+	signedTx, signature = sign(otherSigner)
+	tErr = verifySigner(signer, signedTx, from, signature)
+	require.Equal(t, ErrSigningPayloadMismatch.Code, tErr.Code)
+
+	// without a signing payload to compare against, the chain ID mismatch
+	// recovers another signer
+	signature.SigningPayload = nil
+	signedTx, err = tx.WithSignature(signer, signature.Bytes)
+	require.NoError(t, err)
+	tErr = verifySigner(signer, signedTx, from, signature)
+	require.Equal(t, ErrSignerMismatch.Code, tErr.Code)
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+// SimulateParams are the parameters of the transaction simulation /call method
+type SimulateParams struct {
+	// Transaction is an unsigned transaction returned by /construction/payloads
+	// or a signed transaction returned by /construction/combine
+	Transaction string `json:"transaction"`
+	Signed      bool   `json:"signed"`
+}
+
+// SimulationResult is the predicted outcome of a transaction executed against
+// the latest state
+type SimulationResult struct {
+	Success bool `json:"success"`
+	// Operations are the operations the transaction would produce once accepted
+	Operations []*types.Operation `json:"operations"`
+	// GasUsed and RevertReason are only set for EVM transactions
+	GasUsed      uint64 `json:"gas_used,omitempty"`
+	RevertReason string `json:"revert_reason,omitempty"`
+	// Fee is the fee burned by the transaction and RequiredFee the fee it must
+	// burn to be accepted. They are only set for UTXO based transactions.
+	Fee         *types.Amount `json:"fee,omitempty"`
+	RequiredFee *types.Amount `json:"required_fee,omitempty"`
+	// Error explains why the transaction would fail
+	Error string `json:"error,omitempty"`
+}
+
+// Simulator dry-runs transactions built by the construction API
+type Simulator interface {
+	ConstructionSimulate(ctx context.Context, req *types.ConstructionParseRequest) (*SimulationResult, *types.Error)
+}
+
+// ConstructionSimulate executes the transaction of [req] against the latest state
+// with eth_call and debug_traceCall, without submitting it, and returns the
+// operations it would produce.
+//
+// P-chain and C-chain atomic transactions are checked for spent inputs and
+// insufficient fees instead.
+func (s ConstructionService) ConstructionSimulate(
+	ctx context.Context,
+	req *types.ConstructionParseRequest,
+) (*SimulationResult, *types.Error) {
+	if s.config.IsOfflineMode() {
+		return nil, ErrUnavailableOffline
+	}
+
+	if len(req.Transaction) == 0 {
+		return nil, WrapError(ErrInvalidInput, "transaction value is not provided")
+	}
+
+	if s.pChainBackend.ShouldHandleRequest(req) {
+		return s.pChainBackend.ConstructionSimulate(ctx, req)
+	}
+
+	if s.cChainAtomicTxBackend.ShouldHandleRequest(req) {
+		return s.cChainAtomicTxBackend.ConstructionSimulate(ctx, req)
+	}
+
+	tx, msg, terr := s.simulatedMessage(req)
+	if terr != nil {
+		return nil, terr
+	}
+
+	callMsg := interfaces.CallMsg{
+		From:     msg.From,
+		To:       msg.To,
+		Gas:      msg.GasLimit,
+		GasPrice: msg.GasPrice,
+		Value:    msg.Value,
+		Data:     msg.Data,
+	}
+
+	result := &SimulationResult{Success: true}
+	if _, err := s.client.CallContract(ctx, callMsg, nil); err != nil {
+		if !isExecutionError(err) {
+			return nil, WrapError(ErrClientError, err)
+		}
+		result.Success = false
+		result.Error = err.Error()
+		result.RevertReason = revertReason(err)
+	}
+
+	simulatedCall, err := s.client.TraceCall(ctx, callMsg)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+	if len(result.RevertReason) == 0 {
+		result.RevertReason = simulatedCall.RevertReason
+	}
+	if len(simulatedCall.Error) > 0 {
+		result.Success = false
+		if len(result.Error) == 0 {
+			result.Error = simulatedCall.Error
+		}
+	}
+	if simulatedCall.GasUsed != nil {
+		result.GasUsed = simulatedCall.GasUsed.ToInt().Uint64()
+	}
+
+	header, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	receipt := &ethtypes.Receipt{
+		Status:  ethtypes.ReceiptStatusSuccessful,
+		GasUsed: result.GasUsed,
+		Logs:    simulatedCall.EmittedLogs(),
+	}
+	if !result.Success {
+		receipt.Status = ethtypes.ReceiptStatusFailed
+	}
+
+	trace, flattened := simulatedCall.Trace()
+	transaction, err := mapper.Transaction(
+		header,
+		tx,
+		msg,
+		receipt,
+		trace,
+		flattened,
+		s.client,
+		s.config.Currency(),
+		s.config.IsAnalyticsMode(),
+		s.config.WhiteListedTokens(),
+		s.config.IndexUnknownTokens,
+	)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	result.Operations = transaction.Operations
+
+	return result, nil
+}
+
+// simulatedMessage decodes the transaction of [req] into the message it executes
+func (s ConstructionService) simulatedMessage(
+	req *types.ConstructionParseRequest,
+) (*ethtypes.Transaction, *core.Message, *types.Error) {
+	if req.Signed {
+		var wrappedTx signedTransactionWrapper
+		if err := json.Unmarshal([]byte(req.Transaction), &wrappedTx); err != nil {
+			return nil, nil, WrapError(ErrInvalidInput, err)
+		}
+
+		var tx ethtypes.Transaction
+		if err := tx.UnmarshalJSON(wrappedTx.SignedTransaction); err != nil {
+			return nil, nil, WrapError(ErrInvalidInput, err)
+		}
+
+		msg, err := core.TransactionToMessage(&tx, s.config.Signer(), nil)
+		if err != nil {
+			return nil, nil, WrapError(ErrInvalidInput, err)
+		}
+		return &tx, msg, nil
+	}
+
+	var unsignedTx transaction
+	if err := json.Unmarshal([]byte(req.Transaction), &unsignedTx); err != nil {
+		return nil, nil, WrapError(ErrInvalidInput, err)
+	}
+	if !common.IsHexAddress(unsignedTx.From) {
+		return nil, nil, WrapError(ErrInvalidInput, "from address is invalid")
+	}
+
+	to := common.HexToAddress(unsignedTx.To)
+	tx := ethtypes.NewTransaction(
+		unsignedTx.Nonce,
+		to,
+		unsignedTx.Value,
+		unsignedTx.GasLimit,
+		unsignedTx.GasPrice,
+		unsignedTx.Data,
+	)
+	value := unsignedTx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	return tx, &core.Message{
+		From:      common.HexToAddress(unsignedTx.From),
+		To:        &to,
+		Nonce:     unsignedTx.Nonce,
+		Value:     value,
+		GasLimit:  unsignedTx.GasLimit,
+		GasPrice:  unsignedTx.GasPrice,
+		GasFeeCap: unsignedTx.GasPrice,
+		GasTipCap: unsignedTx.GasPrice,
+		Data:      unsignedTx.Data,
+	}, nil
+}
+
+// isExecutionError returns true if [err] was returned by eth_call because the
+// transaction failed, rather than because the node could not be reached
+func isExecutionError(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+// revertReason returns the reason decoded from the revert data of [err], if any
+func revertReason(err error) string {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return ""
+	}
+
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return ""
+	}
+	output, decodeErr := hexutil.Decode(data)
+	if decodeErr != nil {
+		return ""
+	}
+	reason, unpackErr := abi.UnpackRevert(output)
+	if unpackErr != nil {
+		return ""
+	}
+	return reason
+}
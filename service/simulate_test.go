@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+
+	rosConst "github.com/ava-labs/avalanche-rosetta/constants"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+// revertError is an eth_call error carrying revert data
+type revertError struct {
+	data string
+}
+
+func (*revertError) Error() string            { return "execution reverted: insufficient balance" }
+func (*revertError) ErrorCode() int           { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+func TestConstructionSimulate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clientMock := client.NewMockClient(ctrl)
+	ctx := context.Background()
+	skippedBackend := NewMockConstructionBackend(ctrl)
+	skippedBackend.EXPECT().ShouldHandleRequest(gomock.Any()).Return(false).AnyTimes()
+
+	service := ConstructionService{
+		config: &Config{
+			Mode:          ModeOnline,
+			IngestionMode: AnalyticsIngestion,
+		},
+		client:                clientMock,
+		pChainBackend:         skippedBackend,
+		cChainAtomicTxBackend: skippedBackend,
+	}
+
+	from := common.HexToAddress(defaultFromAddress)
+	to := common.HexToAddress(defaultToAddress)
+	contract := common.HexToAddress(defaultContractAddress)
+	// transfer(0x57B4..., 1)
+	data := hexutil.MustDecode("0xa9059cbb00000000000000000000000057b414a0332b5cab885a451c2a28a07d1e9b8a8d0000000000000000000000000000000000000000000000000000000000000001")
+	unsignedTx := `{"from":"0xe3a5B4d7f79d64088C8d4ef153A7DDe2B2d47309","to":"0x30e5449b6712Adf4156c8c474250F6eA4400eB82","value":"0x0","data":"0xa9059cbb00000000000000000000000057b414a0332b5cab885a451c2a28a07d1e9b8a8d0000000000000000000000000000000000000000000000000000000000000001","nonce":"0x1","gas_price":"0x5d21dba00","gas":"0xfde8","chain_id":"0xa868"}`
+	callMsg := interfaces.CallMsg{
+		From:     from,
+		To:       &contract,
+		Gas:      65000,
+		GasPrice: big.NewInt(25_000_000_000),
+		Value:    big.NewInt(0),
+		Data:     data,
+	}
+	req := &types.ConstructionParseRequest{
+		NetworkIdentifier: &types.NetworkIdentifier{Network: rosConst.FujiNetwork},
+		Transaction:       unsignedTx,
+	}
+
+	t.Run("unavailable in offline mode", func(t *testing.T) {
+		service := ConstructionService{config: &Config{Mode: ModeOffline}}
+		_, err := service.ConstructionSimulate(ctx, req)
+		require.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	})
+
+	t.Run("successful erc20 transfer", func(t *testing.T) {
+		clientMock.EXPECT().CallContract(ctx, gomock.Any(), nil).DoAndReturn(
+			func(_ context.Context, msg interfaces.CallMsg, _ *big.Int) ([]byte, error) {
+				require.Zero(t, msg.Value.Sign())
+				msg.Value = callMsg.Value
+				require.Equal(t, callMsg, msg)
+				return common.LeftPadBytes([]byte{1}, 32), nil
+			},
+		)
+		clientMock.EXPECT().TraceCall(ctx, gomock.Any()).Return(&client.SimulatedCall{
+			Call: client.Call{
+				Type:    "CALL",
+				From:    from,
+				To:      contract,
+				GasUsed: (*hexutil.Big)(big.NewInt(35_000)),
+			},
+			Logs: []*client.CallLog{{
+				Address: contract,
+				Topics: []common.Hash{
+					common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+					common.BytesToHash(from.Bytes()),
+					common.BytesToHash(to.Bytes()),
+				},
+				Data: common.LeftPadBytes([]byte{1}, 32),
+			}},
+		}, nil)
+		clientMock.EXPECT().HeaderByNumber(ctx, (*big.Int)(nil)).Return(&ethtypes.Header{}, nil)
+		clientMock.EXPECT().GetContractInfo(contract, true).Return(defaultSymbol, uint8(defaultDecimals), nil)
+
+		result, err := service.ConstructionSimulate(ctx, req)
+		require.Nil(t, err)
+		require.True(t, result.Success)
+		require.Equal(t, uint64(35_000), result.GasUsed)
+
+		opTypes := []string{}
+		for _, op := range result.Operations {
+			opTypes = append(opTypes, op.Type)
+		}
+		require.Equal(t, []string{
+			mapper.OpFee,
+			mapper.OpFee,
+			mapper.OpErc20Transfer,
+			mapper.OpErc20Transfer,
+		}, opTypes)
+		require.Equal(t, "-875000000000000", result.Operations[0].Amount.Value)
+		require.Equal(t, "-1", result.Operations[2].Amount.Value)
+		require.Equal(t, defaultSymbol, result.Operations[2].Amount.Currency.Symbol)
+	})
+
+	t.Run("reverted erc20 transfer", func(t *testing.T) {
+		// Error(string) "insufficient balance"
+		revertData := "0x08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000014" +
+			"696e73756666696369656e742062616c616e6365000000000000000000000000"
+		clientMock.EXPECT().CallContract(ctx, gomock.Any(), nil).Return(nil, &revertError{data: revertData})
+		clientMock.EXPECT().TraceCall(ctx, gomock.Any()).Return(&client.SimulatedCall{
+			Call: client.Call{
+				Type:    "CALL",
+				From:    from,
+				To:      contract,
+				GasUsed: (*hexutil.Big)(big.NewInt(23_000)),
+				Error:   "execution reverted",
+			},
+			Logs: []*client.CallLog{{Address: contract}},
+		}, nil)
+		clientMock.EXPECT().HeaderByNumber(ctx, (*big.Int)(nil)).Return(&ethtypes.Header{}, nil)
+
+		result, err := service.ConstructionSimulate(ctx, req)
+		require.Nil(t, err)
+		require.False(t, result.Success)
+		require.Equal(t, "insufficient balance", result.RevertReason)
+		require.Equal(t, uint64(23_000), result.GasUsed)
+
+		// only the fee is paid, the reverted call emits no transfer
+		require.Len(t, result.Operations, 2)
+		require.Equal(t, mapper.OpFee, result.Operations[0].Type)
+	})
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// SkippedBackend is a backend that handles no request. It stands in for the
+// P-chain and atomic transaction backends of EVM chains other than the C-chain,
+// which have neither P-chain nor atomic transactions.
+type SkippedBackend struct{}
+
+// ShouldHandleRequest always returns false
+func (SkippedBackend) ShouldHandleRequest(interface{}) bool {
+	return false
+}
+
+// NetworkIdentifier returns nil as the backend serves no network
+func (SkippedBackend) NetworkIdentifier() *types.NetworkIdentifier {
+	return nil
+}
+
+func (SkippedBackend) NetworkStatus(context.Context, *types.NetworkRequest) (*types.NetworkStatusResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) NetworkOptions(context.Context, *types.NetworkRequest) (*types.NetworkOptionsResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) Block(context.Context, *types.BlockRequest) (*types.BlockResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) BlockTransaction(context.Context, *types.BlockTransactionRequest) (*types.BlockTransactionResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) AccountBalance(context.Context, *types.AccountBalanceRequest) (*types.AccountBalanceResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) AccountCoins(context.Context, *types.AccountCoinsRequest) (*types.AccountCoinsResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionDerive(context.Context, *types.ConstructionDeriveRequest) (*types.ConstructionDeriveResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionPreprocess(context.Context, *types.ConstructionPreprocessRequest) (*types.ConstructionPreprocessResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionMetadata(context.Context, *types.ConstructionMetadataRequest) (*types.ConstructionMetadataResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionPayloads(context.Context, *types.ConstructionPayloadsRequest) (*types.ConstructionPayloadsResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionParse(context.Context, *types.ConstructionParseRequest) (*types.ConstructionParseResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionCombine(context.Context, *types.ConstructionCombineRequest) (*types.ConstructionCombineResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionHash(context.Context, *types.ConstructionHashRequest) (*types.TransactionIdentifierResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionSubmit(context.Context, *types.ConstructionSubmitRequest) (*types.TransactionIdentifierResponse, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionSimulate(context.Context, *types.ConstructionParseRequest) (*SimulationResult, *types.Error) {
+	return nil, ErrNotSupported
+}
+
+func (SkippedBackend) ConstructionTxStatus(context.Context, *TxStatusRequest) (*TxStatus, *types.Error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+var errNoHeightSource = errors.New("no height source available")
+
+// HeightFunc returns the height of the last accepted block of a chain
+type HeightFunc func(ctx context.Context) (uint64, error)
+
+// SyncStatusConfig configures how /network/status reports the sync progress of a chain
+type SyncStatusConfig struct {
+	// LaggingThreshold is the age of the last accepted block after which a
+	// bootstrapped node is reported as lagging. Zero disables the check.
+	LaggingThreshold time.Duration
+	// StateSync is true if the node state syncs the chain before bootstrapping it
+	StateSync bool
+	// TargetHeight, if set, returns the height of the chain tip, used as sync target
+	TargetHeight HeightFunc
+}
+
+// ExecutingWindow is the period over which the last accepted height of a
+// bootstrapping chain must increase for it to be reported as executing
+const ExecutingWindow = 30 * time.Second
+
+// SyncStatusTracker derives the sync status of a chain from its bootstrap
+// status and last accepted block
+type SyncStatusTracker struct {
+	config SyncStatusConfig
+	now    func() time.Time
+
+	lock sync.Mutex
+	// current is the height sample of the current window, and previous the one
+	// of the window before, at least [ExecutingWindow] older
+	current  *heightSample
+	previous *heightSample
+}
+
+type heightSample struct {
+	height int64
+	time   time.Time
+}
+
+// NewSyncStatusTracker returns a new SyncStatusTracker
+func NewSyncStatusTracker(config SyncStatusConfig) *SyncStatusTracker {
+	return &SyncStatusTracker{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// SyncStatus returns the sync status of a chain whose last accepted block is at
+// [height] and was produced at [timestamp], in milliseconds. While the chain
+// is not bootstrapped, it is reported as executing if its height increased
+// since the sample taken at least [ExecutingWindow] earlier, so that the stage
+// does not depend on how often it is requested.
+//
+// The target index is the height returned by [SyncStatusConfig.TargetHeight].
+// Without it, the target of a bootstrapped chain is its own height, and the
+// target of a bootstrapping chain is unknown.
+func (t *SyncStatusTracker) SyncStatus(
+	ctx context.Context,
+	bootstrapped bool,
+	height int64,
+	timestamp int64,
+) *types.SyncStatus {
+	advancing := t.advancing(height)
+
+	var stage *types.SyncStatus
+	switch {
+	case !bootstrapped && height == 0 && t.config.StateSync:
+		stage = mapper.StageStateSync
+	case !bootstrapped && advancing && height > 0:
+		stage = mapper.StageExecuting
+	case !bootstrapped:
+		stage = mapper.StageBootstrap
+	case t.config.LaggingThreshold > 0 && time.Since(time.UnixMilli(timestamp)) > t.config.LaggingThreshold:
+		stage = mapper.StageLagging
+	default:
+		stage = mapper.StageSynced
+	}
+
+	status := &types.SyncStatus{
+		CurrentIndex: types.Int64(height),
+		Stage:        stage.Stage,
+		Synced:       stage.Synced,
+	}
+
+	if t.config.TargetHeight != nil {
+		// the target is best effort, the status is still reported without it
+		if target, err := t.config.TargetHeight(ctx); err == nil {
+			status.TargetIndex = types.Int64(max(int64(target), height))
+		}
+	}
+	if status.TargetIndex == nil && bootstrapped {
+		status.TargetIndex = types.Int64(height)
+	}
+	return status
+}
+
+// advancing records [height] and returns true if it is higher than the height
+// sampled at least [ExecutingWindow] earlier
+func (t *SyncStatusTracker) advancing(height int64) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	if t.current == nil || now.Sub(t.current.time) >= ExecutingWindow {
+		t.previous = t.current
+		t.current = &heightSample{height: height, time: now}
+	}
+	return t.previous != nil && height > t.previous.height
+}
+
+// MaxHeight returns a HeightFunc reporting the highest height returned by [sources].
+// Failing sources are ignored, unless all of them fail.
+func MaxHeight(sources ...HeightFunc) HeightFunc {
+	return func(ctx context.Context) (uint64, error) {
+		var (
+			height  uint64
+			lastErr = errNoHeightSource
+			found   bool
+		)
+		for _, source := range sources {
+			h, err := source(ctx)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			found = true
+			height = max(height, h)
+		}
+		if !found {
+			return 0, lastErr
+		}
+		return height, nil
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+func TestSyncStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UnixMilli()
+
+	t.Run("state sync", func(t *testing.T) {
+		tracker := NewSyncStatusTracker(SyncStatusConfig{StateSync: true})
+
+		status := tracker.SyncStatus(ctx, false, 0, 0)
+		require.Equal(t, *mapper.StageStateSync.Stage, *status.Stage)
+		require.False(t, *status.Synced)
+		require.Equal(t, int64(0), *status.CurrentIndex)
+		require.Nil(t, status.TargetIndex)
+	})
+
+	t.Run("bootstrapping then executing", func(t *testing.T) {
+		tracker := NewSyncStatusTracker(SyncStatusConfig{})
+		clock := time.Unix(1_700_000_000, 0)
+		tracker.now = func() time.Time { return clock }
+
+		status := tracker.SyncStatus(ctx, false, 0, 0)
+		require.Equal(t, *mapper.StageBootstrap.Stage, *status.Stage)
+		require.Nil(t, status.TargetIndex)
+
+		// the height is compared with the sample of the previous window
+		clock = clock.Add(10 * time.Second)
+		status = tracker.SyncStatus(ctx, false, 10, now)
+		require.Equal(t, *mapper.StageBootstrap.Stage, *status.Stage)
+
+		clock = clock.Add(ExecutingWindow)
+		status = tracker.SyncStatus(ctx, false, 10, now)
+		require.Equal(t, *mapper.StageExecuting.Stage, *status.Stage)
+		require.Equal(t, int64(10), *status.CurrentIndex)
+
+		// polling more often does not change the stage
+		for i := 0; i < 5; i++ {
+			clock = clock.Add(time.Second)
+			status = tracker.SyncStatus(ctx, false, 10, now)
+			require.Equal(t, *mapper.StageExecuting.Stage, *status.Stage)
+		}
+
+		// the height stopped increasing over a whole window
+		clock = clock.Add(ExecutingWindow)
+		status = tracker.SyncStatus(ctx, false, 10, now)
+		require.Equal(t, *mapper.StageBootstrap.Stage, *status.Stage)
+	})
+
+	t.Run("lagging", func(t *testing.T) {
+		tracker := NewSyncStatusTracker(SyncStatusConfig{LaggingThreshold: time.Minute})
+
+		status := tracker.SyncStatus(ctx, true, 10, now-time.Hour.Milliseconds())
+		require.Equal(t, *mapper.StageLagging.Stage, *status.Stage)
+		require.False(t, *status.Synced)
+
+		status = tracker.SyncStatus(ctx, true, 11, now)
+		require.Equal(t, *mapper.StageSynced.Stage, *status.Stage)
+		require.True(t, *status.Synced)
+	})
+
+	t.Run("target index", func(t *testing.T) {
+		tracker := NewSyncStatusTracker(SyncStatusConfig{
+			TargetHeight: MaxHeight(
+				func(context.Context) (uint64, error) { return 0, errors.New("unreachable") },
+				func(context.Context) (uint64, error) { return 100, nil },
+				func(context.Context) (uint64, error) { return 90, nil },
+			),
+		})
+
+		status := tracker.SyncStatus(ctx, false, 10, now)
+		require.Equal(t, int64(10), *status.CurrentIndex)
+		require.Equal(t, int64(100), *status.TargetIndex)
+
+		// the target is never behind the node
+		status = tracker.SyncStatus(ctx, true, 110, now)
+		require.Equal(t, int64(110), *status.TargetIndex)
+	})
+
+	t.Run("target index unavailable", func(t *testing.T) {
+		tracker := NewSyncStatusTracker(SyncStatusConfig{
+			TargetHeight: MaxHeight(
+				func(context.Context) (uint64, error) { return 0, errors.New("unreachable") },
+			),
+		})
+
+		// the target of a bootstrapped chain falls back to its own height
+		status := tracker.SyncStatus(ctx, true, 10, now)
+		require.Equal(t, int64(10), *status.TargetIndex)
+
+		status = tracker.SyncStatus(ctx, false, 10, now)
+		require.Nil(t, status.TargetIndex)
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+// ConstructionTxStatus returns the status of a transaction submitted to the node.
+//
+// EVM transactions are accepted or reverted once they have a receipt. Pending
+// transactions waiting for a lower nonce are reported with the reason, and
+// those whose nonce was used by another transaction as dropped. Transactions
+// not known by the node may not have reached it yet, so they remain pending.
+func (s ConstructionService) ConstructionTxStatus(
+	ctx context.Context,
+	req *TxStatusRequest,
+) (*TxStatus, *types.Error) {
+	if s.config.IsOfflineMode() {
+		return nil, ErrUnavailableOffline
+	}
+
+	if req.TransactionIdentifier == nil || len(req.TransactionIdentifier.Hash) == 0 {
+		return nil, WrapError(ErrInvalidInput, "transaction hash is not provided")
+	}
+
+	var (
+		status *TxStatus
+		terr   *types.Error
+	)
+	switch {
+	case s.pChainBackend.ShouldHandleRequest(req):
+		status, terr = s.pChainBackend.ConstructionTxStatus(ctx, req)
+	case s.cChainAtomicTxBackend.ShouldHandleRequest(req):
+		status, terr = s.cChainAtomicTxBackend.ConstructionTxStatus(ctx, req)
+	default:
+		status, terr = s.evmTxStatus(ctx, req.TransactionIdentifier)
+	}
+	if terr != nil {
+		return nil, terr
+	}
+
+	status.UpdatedAt = time.Now().UnixMilli()
+	return status, nil
+}
+
+func (s ConstructionService) evmTxStatus(
+	ctx context.Context,
+	txIdentifier *types.TransactionIdentifier,
+) (*TxStatus, *types.Error) {
+	hashBytes, err := hexutil.Decode(txIdentifier.Hash)
+	if err != nil || len(hashBytes) != common.HashLength {
+		return nil, WrapError(ErrInvalidInput, "transaction hash is invalid")
+	}
+	hash := common.BytesToHash(hashBytes)
+	status := &TxStatus{TransactionIdentifier: txIdentifier}
+
+	if ok, terr := s.receiptStatus(ctx, hash, status); ok || terr != nil {
+		return status, terr
+	}
+
+	tx, isPending, err := s.client.TransactionByHash(ctx, hash)
+	if errors.Is(err, interfaces.NotFound) {
+		status.Status = TxStatusPending
+		status.Reason = "transaction is not known by the node"
+		return status, nil
+	}
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	status.Status = TxStatusPending
+	if !isPending {
+		// included in a block whose receipts are not available yet
+		return status, nil
+	}
+
+	sender, err := ethtypes.Sender(s.config.Signer(), tx)
+	if err != nil {
+		return nil, WrapError(ErrInternalError, err)
+	}
+	nonce, err := s.client.NonceAt(ctx, sender, nil)
+	if err != nil {
+		return nil, WrapError(ErrClientError, err)
+	}
+
+	switch {
+	case tx.Nonce() < nonce:
+		// the transaction may have been included since its receipt was
+		// fetched, in which case it used the nonce itself
+		if ok, terr := s.receiptStatus(ctx, hash, status); ok || terr != nil {
+			return status, terr
+		}
+		status.Status = TxStatusDropped
+		status.Reason = fmt.Sprintf("nonce %d of %s was used by another transaction", tx.Nonce(), sender)
+	case tx.Nonce() > nonce:
+		status.Reason = fmt.Sprintf("waiting for the transactions of %s with nonces %d to %d", sender, nonce, tx.Nonce()-1)
+	}
+	return status, nil
+}
+
+// receiptStatus sets [status] from the receipt of the transaction [hash], and
+// returns false if it has no receipt yet
+func (s ConstructionService) receiptStatus(
+	ctx context.Context,
+	hash common.Hash,
+	status *TxStatus,
+) (bool, *types.Error) {
+	receipt, err := s.client.TransactionReceipt(ctx, hash)
+	switch {
+	case errors.Is(err, interfaces.NotFound):
+		return false, nil
+	case err != nil:
+		return false, WrapError(ErrClientError, err)
+	case receipt.Status == ethtypes.ReceiptStatusSuccessful:
+		status.Status = TxStatusAccepted
+	default:
+		status.Status = TxStatusReverted
+		status.Reason = s.failureReason(ctx, hash)
+	}
+	return true, nil
+}
+
+// failureReason returns the error that made the execution of the transaction
+// [hash] fail, as reported by its trace
+func (s ConstructionService) failureReason(ctx context.Context, hash common.Hash) string {
+	const defaultReason = "execution reverted"
+
+	// the reason is best effort, the status is still reported without it
+	trace, _, err := s.client.TraceTransaction(ctx, hash.String())
+	if err != nil || len(trace.Error) == 0 {
+		return defaultReason
+	}
+	return trace.Error
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/client"
+
+	rosConst "github.com/ava-labs/avalanche-rosetta/constants"
+	ethtypes "github.com/ava-labs/coreth/core/types"
+)
+
+func TestConstructionTxStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clientMock := client.NewMockClient(ctrl)
+	ctx := context.Background()
+	skippedBackend := NewMockConstructionBackend(ctrl)
+	skippedBackend.EXPECT().ShouldHandleRequest(gomock.Any()).Return(false).AnyTimes()
+
+	config := &Config{
+		Mode:    ModeOnline,
+		ChainID: big.NewInt(rosConst.FujiChainID),
+	}
+	service := ConstructionService{
+		config:                config,
+		client:                clientMock,
+		pChainBackend:         skippedBackend,
+		cChainAtomicTxBackend: skippedBackend,
+	}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	tx, err := ethtypes.SignTx(
+		ethtypes.NewTransaction(5, common.HexToAddress(defaultToAddress), big.NewInt(1), 21_000, big.NewInt(25_000_000_000), nil),
+		config.Signer(),
+		key,
+	)
+	require.NoError(t, err)
+	hash := tx.Hash()
+	req := &TxStatusRequest{
+		NetworkIdentifier:     &types.NetworkIdentifier{Network: rosConst.FujiNetwork},
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: hash.String()},
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(&ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}, nil)
+
+		status, terr := service.ConstructionTxStatus(ctx, req)
+		require.Nil(t, terr)
+		require.Equal(t, TxStatusAccepted, status.Status)
+		require.NotZero(t, status.UpdatedAt)
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(&ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed}, nil)
+		clientMock.EXPECT().TraceTransaction(ctx, hash.String()).Return(&client.Call{Error: "out of gas"}, nil, nil)
+
+		status, terr := service.ConstructionTxStatus(ctx, req)
+		require.Nil(t, terr)
+		require.Equal(t, TxStatusReverted, status.Status)
+		require.Equal(t, "out of gas", status.Reason)
+	})
+
+	t.Run("unknown transactions are pending", func(t *testing.T) {
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(nil, interfaces.NotFound)
+		clientMock.EXPECT().TransactionByHash(ctx, hash).Return(nil, false, interfaces.NotFound)
+
+		status, terr := service.ConstructionTxStatus(ctx, req)
+		require.Nil(t, terr)
+		require.Equal(t, TxStatusPending, status.Status)
+		require.False(t, status.Final())
+		require.Equal(t, "transaction is not known by the node", status.Reason)
+	})
+
+	t.Run("pending transactions waiting for a lower nonce", func(t *testing.T) {
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(nil, interfaces.NotFound)
+		clientMock.EXPECT().TransactionByHash(ctx, hash).Return(tx, true, nil)
+		clientMock.EXPECT().NonceAt(ctx, sender, nil).Return(uint64(3), nil)
+
+		status, terr := service.ConstructionTxStatus(ctx, req)
+		require.Nil(t, terr)
+		require.Equal(t, TxStatusPending, status.Status)
+		require.Contains(t, status.Reason, "with nonces 3 to 4")
+	})
+
+	t.Run("pending transactions whose nonce was used are dropped", func(t *testing.T) {
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(nil, interfaces.NotFound)
+		clientMock.EXPECT().TransactionByHash(ctx, hash).Return(tx, true, nil)
+		clientMock.EXPECT().NonceAt(ctx, sender, nil).Return(uint64(6), nil)
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(nil, interfaces.NotFound)
+
+		status, terr := service.ConstructionTxStatus(ctx, req)
+		require.Nil(t, terr)
+		require.Equal(t, TxStatusDropped, status.Status)
+		require.Contains(t, status.Reason, "nonce 5")
+	})
+
+	t.Run("transactions included while their nonce is checked are accepted", func(t *testing.T) {
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(nil, interfaces.NotFound)
+		clientMock.EXPECT().TransactionByHash(ctx, hash).Return(tx, true, nil)
+		clientMock.EXPECT().NonceAt(ctx, sender, nil).Return(uint64(6), nil)
+		clientMock.EXPECT().TransactionReceipt(ctx, hash).Return(&ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}, nil)
+
+		status, terr := service.ConstructionTxStatus(ctx, req)
+		require.Nil(t, terr)
+		require.Equal(t, TxStatusAccepted, status.Status)
+		require.Empty(t, status.Reason)
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		_, terr := service.ConstructionTxStatus(ctx, &TxStatusRequest{
+			NetworkIdentifier:     req.NetworkIdentifier,
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "0x1234"},
+		})
+		require.Equal(t, ErrInvalidInput.Code, terr.Code)
+	})
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// Statuses of a submitted transaction
+const (
+	// TxStatusPending is the status of a transaction not yet included in a block
+	TxStatusPending = "pending"
+	// TxStatusAccepted is the status of a transaction included in an accepted block
+	TxStatusAccepted = "accepted"
+	// TxStatusDropped is the status of a transaction that will never be included,
+	// because it failed verification or was evicted from the mempool
+	TxStatusDropped = "dropped"
+	// TxStatusReverted is the status of an EVM transaction included in an
+	// accepted block whose execution failed
+	TxStatusReverted = "reverted"
+)
+
+const (
+	// DefaultTxPollInterval is the interval at which the status of pending
+	// transactions is polled
+	DefaultTxPollInterval = 2 * time.Second
+	// DefaultTxRetention is how long the final status of a transaction is kept,
+	// and how long a transaction that remains pending is polled
+	DefaultTxRetention = time.Hour
+)
+
+// TxStatusRequest is a request for the status of a transaction of a network
+type TxStatusRequest struct {
+	NetworkIdentifier     *types.NetworkIdentifier
+	TransactionIdentifier *types.TransactionIdentifier
+}
+
+// TxStatus is the status of a transaction
+type TxStatus struct {
+	TransactionIdentifier *types.TransactionIdentifier `json:"transaction_identifier"`
+	Status                string                       `json:"status"`
+	// Reason explains why a transaction was dropped or reverted, or why it
+	// is still pending when it is not expected to be included
+	Reason string `json:"reason,omitempty"`
+	// SubmittedAt is the time the transaction was submitted through
+	// /construction/submit, in milliseconds. It is only set for tracked
+	// transactions.
+	SubmittedAt int64 `json:"submitted_at,omitempty"`
+	// UpdatedAt is the time the status was last fetched, in milliseconds
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// Final returns true if the status of the transaction can no longer change
+func (s *TxStatus) Final() bool {
+	return s.Status != TxStatusPending
+}
+
+// TxStatusParams are the parameters of the transaction status /call method
+type TxStatusParams struct {
+	TxHash string `json:"tx_hash"`
+}
+
+// TxStatusFetcher fetches the status of transactions from the node
+type TxStatusFetcher interface {
+	ConstructionTxStatus(ctx context.Context, req *TxStatusRequest) (*TxStatus, *types.Error)
+}
+
+// TxTrackerConfig configures how submitted transactions are tracked
+type TxTrackerConfig struct {
+	// PollInterval is the interval at which the status of pending transactions is polled
+	PollInterval time.Duration
+	// Retention is how long the final status of a transaction is kept, and how
+	// long a transaction that remains pending is polled
+	Retention time.Duration
+	// WaitTimeout is how long /construction/submit waits for a submitted
+	// transaction to be accepted, dropped or reverted. Zero disables the wait.
+	WaitTimeout time.Duration
+}
+
+// TxTracker keeps the status of the transactions submitted through
+// /construction/submit, polling the status of pending ones until they are
+// accepted, dropped or reverted
+type TxTracker struct {
+	config  TxTrackerConfig
+	fetcher TxStatusFetcher
+
+	lock sync.Mutex
+	txs  map[string]*trackedTx
+}
+
+// trackedTx is a tracked transaction and its last known status
+type trackedTx struct {
+	request *TxStatusRequest
+	status  *TxStatus
+}
+
+// NewTxTracker returns a new TxTracker fetching the status of transactions from [fetcher]
+func NewTxTracker(fetcher TxStatusFetcher, config TxTrackerConfig) *TxTracker {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultTxPollInterval
+	}
+	if config.Retention <= 0 {
+		config.Retention = DefaultTxRetention
+	}
+	return &TxTracker{
+		config:  config,
+		fetcher: fetcher,
+		txs:     map[string]*trackedTx{},
+	}
+}
+
+func txKey(req *TxStatusRequest) string {
+	return types.Hash(req.NetworkIdentifier) + "/" + req.TransactionIdentifier.Hash
+}
+
+// Track starts tracking the transaction of [req], which was just submitted.
+// If the tracker is configured with a wait timeout, it waits for the
+// transaction to be accepted, dropped or reverted and returns its status,
+// which is still pending on timeout. Otherwise it returns nil.
+func (t *TxTracker) Track(ctx context.Context, req *TxStatusRequest) *TxStatus {
+	now := time.Now().UnixMilli()
+	t.lock.Lock()
+	t.txs[txKey(req)] = &trackedTx{
+		request: req,
+		status: &TxStatus{
+			TransactionIdentifier: req.TransactionIdentifier,
+			Status:                TxStatusPending,
+			SubmittedAt:           now,
+			UpdatedAt:             now,
+		},
+	}
+	t.lock.Unlock()
+
+	if t.config.WaitTimeout <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.config.WaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(t.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		status := t.refresh(ctx, req)
+		if status.Final() {
+			return status
+		}
+
+		select {
+		case <-ctx.Done():
+			return status
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status returns the status of the transaction of [req]. The final status of
+// tracked transactions is served from memory, others are fetched from the node.
+func (t *TxTracker) Status(ctx context.Context, req *TxStatusRequest) (*TxStatus, *types.Error) {
+	t.lock.Lock()
+	tracked, ok := t.txs[txKey(req)]
+	if ok && tracked.status.Final() {
+		status := tracked.status
+		t.lock.Unlock()
+		return status, nil
+	}
+	t.lock.Unlock()
+
+	status, err := t.fetcher.ConstructionTxStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		status = t.update(req, status)
+	}
+	return status, nil
+}
+
+// Run polls the status of pending transactions every poll interval, until
+// [ctx] is cancelled. Transactions are forgotten once their final status is
+// older than the retention period, or if they remain pending for longer.
+func (t *TxTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		t.prune()
+		for _, req := range t.pending() {
+			t.refresh(ctx, req)
+		}
+	}
+}
+
+// refresh fetches the status of the tracked transaction of [req] and returns
+// its last known status. Errors are logged and the previous status is kept.
+func (t *TxTracker) refresh(ctx context.Context, req *TxStatusRequest) *TxStatus {
+	status, err := t.fetcher.ConstructionTxStatus(ctx, req)
+	if err != nil {
+		log.Printf("unable to fetch the status of transaction %s: %s\n", req.TransactionIdentifier.Hash, err.Message)
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		if tracked, ok := t.txs[txKey(req)]; ok {
+			return tracked.status
+		}
+		return &TxStatus{TransactionIdentifier: req.TransactionIdentifier, Status: TxStatusPending}
+	}
+	return t.update(req, status)
+}
+
+// update records [status] as the status of the tracked transaction of [req],
+// unless it is no longer tracked, and returns it
+func (t *TxTracker) update(req *TxStatusRequest, status *TxStatus) *TxStatus {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	tracked, ok := t.txs[txKey(req)]
+	if !ok {
+		return status
+	}
+	status.SubmittedAt = tracked.status.SubmittedAt
+	tracked.status = status
+	return status
+}
+
+// pending returns the requests of the tracked transactions that are still pending
+func (t *TxTracker) pending() []*TxStatusRequest {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	reqs := []*TxStatusRequest{}
+	for _, tracked := range t.txs {
+		if !tracked.status.Final() {
+			reqs = append(reqs, tracked.request)
+		}
+	}
+	return reqs
+}
+
+// prune forgets the transactions whose final status is older than the
+// retention period, and those submitted before and still pending
+func (t *TxTracker) prune() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	cutoff := time.Now().Add(-t.config.Retention).UnixMilli()
+	for key, tracked := range t.txs {
+		expired := tracked.status.SubmittedAt < cutoff
+		if tracked.status.Final() {
+			expired = tracked.status.UpdatedAt < cutoff
+		}
+		if expired {
+			delete(t.txs, key)
+		}
+	}
+}
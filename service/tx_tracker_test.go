@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanche-rosetta/constants"
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+func TestTxTracker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	backend := NewMockConstructionBackend(ctrl)
+	backend.EXPECT().ShouldHandleRequest(gomock.Any()).Return(true).AnyTimes()
+
+	networkP := &types.NetworkIdentifier{
+		Blockchain: BlockchainName,
+		Network:    constants.FujiNetwork,
+		SubNetworkIdentifier: &types.SubNetworkIdentifier{
+			Network: constants.PChain.String(),
+		},
+	}
+	router := NewRouter(&Services{
+		Construction: &ConstructionService{
+			config:                &Config{Mode: ModeOnline},
+			pChainBackend:         backend,
+			cChainAtomicTxBackend: backend,
+		},
+	})
+
+	submit := func(txID string) *types.TransactionIdentifierResponse {
+		submitReq := &types.ConstructionSubmitRequest{
+			NetworkIdentifier: networkP,
+			SignedTransaction: "signed",
+		}
+		backend.EXPECT().ConstructionSubmit(ctx, submitReq).Return(&types.TransactionIdentifierResponse{
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: txID},
+		}, nil)
+
+		resp, err := router.ConstructionSubmit(ctx, submitReq)
+		require.Nil(t, err)
+		require.Equal(t, txID, resp.TransactionIdentifier.Hash)
+		return resp
+	}
+	callStatus := func(txID string) *types.CallResponse {
+		resp, err := router.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: networkP,
+			Method:            mapper.TxStatusCallMethod,
+			Parameters:        map[string]interface{}{"tx_hash": txID},
+		})
+		require.Nil(t, err)
+		return resp
+	}
+	forTx := func(txID string) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			return x.(*TxStatusRequest).TransactionIdentifier.Hash == txID
+		})
+	}
+	statusOf := func(txID string, status string, reason string) *TxStatus {
+		return &TxStatus{
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: txID},
+			Status:                status,
+			Reason:                reason,
+		}
+	}
+
+	t.Run("submit waits for the transaction to be accepted", func(t *testing.T) {
+		router.SetTxTracker(NewTxTracker(router, TxTrackerConfig{
+			PollInterval: time.Millisecond,
+			WaitTimeout:  time.Minute,
+		}))
+
+		gomock.InOrder(
+			backend.EXPECT().ConstructionTxStatus(gomock.Any(), forTx("tx1")).Return(statusOf("tx1", TxStatusPending, ""), nil),
+			backend.EXPECT().ConstructionTxStatus(gomock.Any(), forTx("tx1")).Return(statusOf("tx1", TxStatusAccepted, ""), nil),
+		)
+		resp := submit("tx1")
+		require.Equal(t, TxStatusAccepted, resp.Metadata["status"])
+		require.NotZero(t, resp.Metadata["submitted_at"])
+
+		// the final status is served without querying the node
+		result := callStatus("tx1").Result
+		require.Equal(t, TxStatusAccepted, result["status"])
+		require.Equal(t, resp.Metadata["submitted_at"], result["submitted_at"])
+	})
+
+	t.Run("submit returns a pending status on timeout", func(t *testing.T) {
+		router.SetTxTracker(NewTxTracker(router, TxTrackerConfig{
+			PollInterval: time.Millisecond,
+			WaitTimeout:  10 * time.Millisecond,
+		}))
+
+		backend.EXPECT().ConstructionTxStatus(gomock.Any(), forTx("tx2")).Return(statusOf("tx2", TxStatusPending, ""), nil).MinTimes(1)
+		resp := submit("tx2")
+		require.Equal(t, TxStatusPending, resp.Metadata["status"])
+	})
+
+	t.Run("submit does not wait without timeout", func(t *testing.T) {
+		tracker := NewTxTracker(router, TxTrackerConfig{PollInterval: time.Millisecond})
+		router.SetTxTracker(tracker)
+
+		resp := submit("tx3")
+		require.Nil(t, resp.Metadata)
+
+		// pending transactions are polled in the background
+		backend.EXPECT().ConstructionTxStatus(gomock.Any(), forTx("tx3")).Return(statusOf("tx3", TxStatusDropped, "insufficient funds"), nil)
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			tracker.Run(runCtx)
+			close(done)
+		}()
+		require.Eventually(t, func() bool {
+			return len(tracker.pending()) == 0
+		}, time.Second, time.Millisecond)
+		cancel()
+		<-done
+
+		result := callStatus("tx3").Result
+		require.Equal(t, TxStatusDropped, result["status"])
+		require.Equal(t, "insufficient funds", result["reason"])
+	})
+
+	t.Run("untracked transactions are fetched from the node", func(t *testing.T) {
+		backend.EXPECT().ConstructionTxStatus(gomock.Any(), &TxStatusRequest{
+			NetworkIdentifier:     networkP,
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "tx4"},
+		}).Return(statusOf("tx4", TxStatusAccepted, ""), nil)
+
+		result := callStatus("tx4").Result
+		require.Equal(t, TxStatusAccepted, result["status"])
+		require.Nil(t, result["submitted_at"])
+	})
+
+	t.Run("tx_hash is required", func(t *testing.T) {
+		_, err := router.Call(ctx, &types.CallRequest{
+			NetworkIdentifier: networkP,
+			Method:            mapper.TxStatusCallMethod,
+		})
+		require.Equal(t, ErrCallInvalidParams.Code, err.Code)
+	})
+
+	t.Run("expired transactions are forgotten", func(t *testing.T) {
+		tracker := NewTxTracker(router, TxTrackerConfig{Retention: time.Minute})
+		router.SetTxTracker(tracker)
+		submit("tx5")
+		submit("tx6")
+
+		expired := time.Now().Add(-time.Hour).UnixMilli()
+		tracker.txs[txKey(&TxStatusRequest{
+			NetworkIdentifier:     networkP,
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: "tx5"},
+		})].status.SubmittedAt = expired
+		tracker.prune()
+
+		require.Len(t, tracker.txs, 1)
+		require.Equal(t, "tx6", tracker.pending()[0].TransactionIdentifier.Hash)
+	})
+}
@@ -0,0 +1,294 @@
+package txindex
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/service/follower"
+)
+
+var (
+	_ follower.Handler = &Index{}
+
+	errNonContiguousBlock = errors.New("block does not extend the indexed chain")
+	errNotHead            = errors.New("block is not the indexed head")
+
+	headKey = []byte("head")
+)
+
+// Key prefixes. Transactions are referenced by their position in the chain, so
+// that iterating over any index yields them in chain order.
+const (
+	txPrefix byte = iota
+	txHashPrefix
+	accountPrefix
+	addressPrefix
+	currencyPrefix
+	typePrefix
+	statusPrefix
+	coinPrefix
+	blockPrefix
+)
+
+// refLen is the length of a transaction reference: block index, then position
+// of the transaction in the block. Both are inverted, so that iterating over
+// the references yields the most recent transactions first.
+const refLen = 8 + 4
+
+type txRef struct {
+	blockIndex int64
+	position   uint32
+}
+
+func (r txRef) bytes() []byte {
+	b := make([]byte, refLen)
+	binary.BigEndian.PutUint64(b, ^uint64(r.blockIndex))
+	binary.BigEndian.PutUint32(b[8:], ^r.position)
+	return b
+}
+
+// blockRefPrefix returns the prefix of the references to the transactions of
+// block [index]
+func blockRefPrefix(index int64) []byte {
+	return txRef{blockIndex: index}.bytes()[:8]
+}
+
+func blockKey(index int64) []byte {
+	key := make([]byte, 1+8)
+	key[0] = blockPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(index))
+	return key
+}
+
+func parseTxRef(b []byte) txRef {
+	return txRef{
+		blockIndex: int64(^binary.BigEndian.Uint64(b)),
+		position:   ^binary.BigEndian.Uint32(b[8:]),
+	}
+}
+
+// less orders references from the most recent to the oldest transaction
+func (r txRef) less(o txRef) bool {
+	if r.blockIndex != o.blockIndex {
+		return r.blockIndex > o.blockIndex
+	}
+	return r.position > o.position
+}
+
+// Index stores the transactions of a network, indexed by the accounts,
+// currencies, operation types, statuses and coins of their operations
+type Index struct {
+	db database.Database
+}
+
+// New returns the Index of [network] stored in [db]
+func New(db database.Database, network *types.NetworkIdentifier) *Index {
+	return &Index{
+		db: prefixdb.New([]byte(types.Hash(network)), db),
+	}
+}
+
+// Head returns the last indexed block, or nil if no block was indexed yet
+func (i *Index) Head() (*types.BlockIdentifier, error) {
+	b, err := i.db.Get(headKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	head := &types.BlockIdentifier{}
+	if err := json.Unmarshal(b, head); err != nil {
+		return nil, err
+	}
+	return head, nil
+}
+
+// AddBlock indexes the transactions of [block] and makes it the head
+func (i *Index) AddBlock(block *types.Block) error {
+	head, err := i.Head()
+	if err != nil {
+		return err
+	}
+	if head != nil && block.BlockIdentifier.Index != head.Index+1 {
+		return errNonContiguousBlock
+	}
+
+	batch := i.db.NewBatch()
+	for pos, tx := range block.Transactions {
+		ref := txRef{blockIndex: block.BlockIdentifier.Index, position: uint32(pos)}.bytes()
+
+		b, err := json.Marshal(&types.BlockTransaction{
+			BlockIdentifier: block.BlockIdentifier,
+			Transaction:     tx,
+		})
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(append([]byte{txPrefix}, ref...), b); err != nil {
+			return err
+		}
+		if err := batch.Put(append([]byte{txHashPrefix}, []byte(tx.TransactionIdentifier.Hash)...), ref); err != nil {
+			return err
+		}
+
+		for _, key := range txKeys(tx) {
+			if err := batch.Put(append(key, ref...), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	b, err := json.Marshal(block.BlockIdentifier)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(blockKey(block.BlockIdentifier.Index), b); err != nil {
+		return err
+	}
+	if err := batch.Put(headKey, b); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// RemoveBlock removes the transactions of [block], the head, from the index
+// and makes its parent the head
+func (i *Index) RemoveBlock(block *types.BlockIdentifier) error {
+	head, err := i.Head()
+	if err != nil {
+		return err
+	}
+	if head == nil || types.Hash(head) != types.Hash(block) {
+		return errNotHead
+	}
+
+	batch := i.db.NewBatch()
+	prefix := append([]byte{txPrefix}, blockRefPrefix(block.Index)...)
+	it := i.db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	for it.Next() {
+		key := append([]byte{}, it.Key()...)
+		ref := key[1:]
+		tx := &types.BlockTransaction{}
+		if err := json.Unmarshal(it.Value(), tx); err != nil {
+			return err
+		}
+
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		if err := batch.Delete(append([]byte{txHashPrefix}, []byte(tx.Transaction.TransactionIdentifier.Hash)...)); err != nil {
+			return err
+		}
+		for _, key := range txKeys(tx.Transaction) {
+			if err := batch.Delete(append(key, ref...)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if err := batch.Delete(blockKey(block.Index)); err != nil {
+		return err
+	}
+	parent, err := i.db.Get(blockKey(block.Index - 1))
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		err = batch.Delete(headKey)
+	case err == nil:
+		err = batch.Put(headKey, parent)
+	}
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// txKeys returns the deduplicated index keys of the operations of [tx]
+func txKeys(tx *types.Transaction) [][]byte {
+	seen := map[string]struct{}{}
+	keys := [][]byte{}
+	add := func(prefix byte, value string) {
+		key := indexKey(prefix, value)
+		if _, ok := seen[string(key)]; ok {
+			return
+		}
+		seen[string(key)] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	for _, op := range tx.Operations {
+		add(typePrefix, op.Type)
+		if op.Status != nil {
+			add(statusPrefix, *op.Status)
+		}
+		if op.Account != nil {
+			add(accountPrefix, types.Hash(op.Account))
+			add(addressPrefix, op.Account.Address)
+		}
+		if op.Amount != nil && op.Amount.Currency != nil {
+			add(currencyPrefix, types.Hash(op.Amount.Currency))
+		}
+		if op.CoinChange != nil && op.CoinChange.CoinIdentifier != nil {
+			add(coinPrefix, op.CoinChange.CoinIdentifier.Identifier)
+		}
+	}
+	return keys
+}
+
+// indexKey returns the prefix of the keys referencing the transactions with
+// an operation matching [value]. Values are hashed to keep keys fixed size.
+func indexKey(prefix byte, value string) []byte {
+	return append([]byte{prefix}, hashing.ComputeHash256([]byte(value))...)
+}
+
+// refs returns an iterator over the references to the transactions under
+// [key] included up to block [maxBlock], from the most recent one
+func (i *Index) refs(key []byte, maxBlock int64) refIterator {
+	start := append(append([]byte{}, key...), blockRefPrefix(maxBlock)...)
+	return &prefixIterator{
+		it:        i.db.NewIteratorWithStartAndPrefix(start, key),
+		prefixLen: len(key),
+	}
+}
+
+// txHashRefs returns an iterator over the reference to the transaction with
+// hash [hash], if it is included up to block [maxBlock]
+func (i *Index) txHashRefs(hash string, maxBlock int64) (refIterator, error) {
+	b, err := i.db.Get(append([]byte{txHashPrefix}, []byte(hash)...))
+	if errors.Is(err, database.ErrNotFound) {
+		return &sliceIterator{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ref := parseTxRef(b); ref.blockIndex <= maxBlock {
+		return &sliceIterator{refs: []txRef{ref}}, nil
+	}
+	return &sliceIterator{}, nil
+}
+
+func (i *Index) transaction(ref txRef) (*types.BlockTransaction, error) {
+	b, err := i.db.Get(append([]byte{txPrefix}, ref.bytes()...))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &types.BlockTransaction{}
+	if err := json.Unmarshal(b, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,187 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+)
+
+func TestIndexSearch(t *testing.T) {
+	var (
+		network = &types.NetworkIdentifier{Blockchain: "Avalanche", Network: "Fuji"}
+		avax    = &types.Currency{Symbol: "AVAX", Decimals: 18}
+		usdc    = &types.Currency{Symbol: "USDC", Decimals: 6}
+		alice   = &types.AccountIdentifier{Address: "0xalice"}
+		bob     = &types.AccountIdentifier{Address: "0xbob"}
+	)
+
+	op := func(opType string, status string, account *types.AccountIdentifier, currency *types.Currency) *types.Operation {
+		return &types.Operation{
+			Type:    opType,
+			Status:  types.String(status),
+			Account: account,
+			Amount:  &types.Amount{Value: "1", Currency: currency},
+		}
+	}
+	block := func(index int64, txs ...*types.Transaction) *types.Block {
+		return &types.Block{
+			BlockIdentifier: &types.BlockIdentifier{Index: index, Hash: string(rune('a' + index))},
+			Transactions:    txs,
+		}
+	}
+	tx := func(hash string, ops ...*types.Operation) *types.Transaction {
+		return &types.Transaction{
+			TransactionIdentifier: &types.TransactionIdentifier{Hash: hash},
+			Operations:            ops,
+		}
+	}
+
+	index := New(memdb.New(), network)
+
+	_, err := index.Search(&types.SearchTransactionsRequest{}, mapper.OperationStatuses)
+	require.ErrorIs(t, err, errNotIndexed)
+
+	require.NoError(t, index.AddBlock(block(0)))
+	require.NoError(t, index.AddBlock(block(1,
+		tx("tx1",
+			op(mapper.OpCall, mapper.StatusSuccess, alice, avax),
+			op(mapper.OpCall, mapper.StatusSuccess, bob, avax),
+		),
+		tx("tx2", op(mapper.OpErc20Transfer, mapper.StatusSuccess, alice, usdc)),
+	)))
+	require.NoError(t, index.AddBlock(block(2,
+		tx("tx3", op(mapper.OpCall, mapper.StatusFailure, bob, avax)),
+	)))
+	require.ErrorIs(t, index.AddBlock(block(4)), errNonContiguousBlock)
+
+	head, err := index.Head()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), head.Index)
+
+	hashes := func(resp *types.SearchTransactionsResponse) []string {
+		hashes := []string{}
+		for _, tx := range resp.Transactions {
+			hashes = append(hashes, tx.Transaction.TransactionIdentifier.Hash)
+		}
+		return hashes
+	}
+
+	tests := map[string]struct {
+		request  *types.SearchTransactionsRequest
+		expected []string
+		total    int64
+		next     *int64
+	}{
+		"all": {
+			request:  &types.SearchTransactionsRequest{},
+			expected: []string{"tx3", "tx2", "tx1"},
+			total:    3,
+		},
+		"account": {
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: alice},
+			expected: []string{"tx2", "tx1"},
+			total:    2,
+		},
+		"address and currency": {
+			request:  &types.SearchTransactionsRequest{Address: types.String(bob.Address), Currency: avax},
+			expected: []string{"tx3", "tx1"},
+			total:    2,
+		},
+		"type or currency": {
+			request: &types.SearchTransactionsRequest{
+				Operator: types.OperatorP(types.OR),
+				Type:     types.String(mapper.OpErc20Transfer),
+				Currency: avax,
+			},
+			expected: []string{"tx3", "tx2", "tx1"},
+			total:    3,
+		},
+		"failed": {
+			request:  &types.SearchTransactionsRequest{Success: types.Bool(false)},
+			expected: []string{"tx3"},
+			total:    1,
+		},
+		"status": {
+			request:  &types.SearchTransactionsRequest{Status: types.String(mapper.StatusSuccess), AccountIdentifier: bob},
+			expected: []string{"tx1"},
+			total:    1,
+		},
+		"transaction identifier": {
+			request:  &types.SearchTransactionsRequest{TransactionIdentifier: &types.TransactionIdentifier{Hash: "tx2"}},
+			expected: []string{"tx2"},
+			total:    1,
+		},
+		"max block": {
+			request:  &types.SearchTransactionsRequest{MaxBlock: types.Int64(1)},
+			expected: []string{"tx2", "tx1"},
+			total:    2,
+		},
+		"first page": {
+			request:  &types.SearchTransactionsRequest{Limit: types.Int64(2)},
+			expected: []string{"tx3", "tx2"},
+			total:    3,
+			next:     types.Int64(2),
+		},
+		"last page": {
+			request:  &types.SearchTransactionsRequest{Limit: types.Int64(2), Offset: types.Int64(2)},
+			expected: []string{"tx1"},
+			total:    3,
+		},
+		"single transaction page": {
+			request:  &types.SearchTransactionsRequest{Limit: types.Int64(1)},
+			expected: []string{"tx3"},
+			total:    3,
+			next:     types.Int64(1),
+		},
+		"offset past the last match": {
+			request:  &types.SearchTransactionsRequest{Offset: types.Int64(5)},
+			expected: []string{},
+			total:    3,
+		},
+		"no match": {
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: bob, Currency: usdc},
+			expected: []string{},
+			total:    0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := index.Search(test.request, mapper.OperationStatuses)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, hashes(resp))
+			require.Equal(t, test.total, resp.TotalCount)
+			require.Equal(t, test.next, resp.NextOffset)
+		})
+	}
+
+	resp, err := index.Search(&types.SearchTransactionsRequest{TransactionIdentifier: &types.TransactionIdentifier{Hash: "tx1"}}, mapper.OperationStatuses)
+	require.NoError(t, err)
+	require.Equal(t, &types.BlockIdentifier{Index: 1, Hash: "b"}, resp.Transactions[0].BlockIdentifier)
+	require.Len(t, resp.Transactions[0].Transaction.Operations, 2)
+
+	// removing a block drops its transactions from every index
+	require.ErrorIs(t, index.RemoveBlock(&types.BlockIdentifier{Index: 1, Hash: "b"}), errNotHead)
+	require.NoError(t, index.RemoveBlock(&types.BlockIdentifier{Index: 2, Hash: "c"}))
+	require.NoError(t, index.RemoveBlock(&types.BlockIdentifier{Index: 1, Hash: "b"}))
+
+	head, err = index.Head()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), head.Index)
+
+	for _, request := range []*types.SearchTransactionsRequest{
+		{},
+		{AccountIdentifier: alice},
+		{TransactionIdentifier: &types.TransactionIdentifier{Hash: "tx1"}},
+		{Success: types.Bool(false)},
+	} {
+		resp, err := index.Search(request, mapper.OperationStatuses)
+		require.NoError(t, err)
+		require.Empty(t, resp.Transactions)
+		require.Zero(t, resp.TotalCount)
+	}
+}
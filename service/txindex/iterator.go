@@ -0,0 +1,170 @@
+package txindex
+
+import "github.com/ava-labs/avalanchego/database"
+
+// refIterator iterates over transaction references, from the most recent one
+type refIterator interface {
+	// next returns the next reference, or false once the iterator is exhausted
+	next() (txRef, bool, error)
+	release()
+}
+
+// prefixIterator iterates over the references suffixing the keys of a prefix
+type prefixIterator struct {
+	it        database.Iterator
+	prefixLen int
+}
+
+func (p *prefixIterator) next() (txRef, bool, error) {
+	if !p.it.Next() {
+		return txRef{}, false, p.it.Error()
+	}
+	return parseTxRef(p.it.Key()[p.prefixLen:]), true, nil
+}
+
+func (p *prefixIterator) release() {
+	p.it.Release()
+}
+
+// sliceIterator iterates over sorted references
+type sliceIterator struct {
+	refs []txRef
+}
+
+func (s *sliceIterator) next() (txRef, bool, error) {
+	if len(s.refs) == 0 {
+		return txRef{}, false, nil
+	}
+	ref := s.refs[0]
+	s.refs = s.refs[1:]
+	return ref, true, nil
+}
+
+func (*sliceIterator) release() {}
+
+// peekIterator buffers the next reference of an iterator
+type peekIterator struct {
+	refIterator
+	head txRef
+	ok   bool
+}
+
+func peekAll(iterators []refIterator) ([]*peekIterator, error) {
+	peeks := make([]*peekIterator, 0, len(iterators))
+	for _, it := range iterators {
+		p := &peekIterator{refIterator: it}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		peeks = append(peeks, p)
+	}
+	return peeks, nil
+}
+
+func (p *peekIterator) advance() error {
+	var err error
+	p.head, p.ok, err = p.refIterator.next()
+	return err
+}
+
+// unionIterator iterates over the references of any of its iterators
+type unionIterator struct {
+	iterators []refIterator
+	peeks     []*peekIterator
+}
+
+func (u *unionIterator) next() (txRef, bool, error) {
+	if u.peeks == nil {
+		peeks, err := peekAll(u.iterators)
+		if err != nil {
+			return txRef{}, false, err
+		}
+		u.peeks = peeks
+	}
+
+	var (
+		ref   txRef
+		found bool
+	)
+	for _, p := range u.peeks {
+		if p.ok && (!found || p.head.less(ref)) {
+			ref, found = p.head, true
+		}
+	}
+	if !found {
+		return txRef{}, false, nil
+	}
+
+	// the reference is returned once, whichever iterators hold it
+	for _, p := range u.peeks {
+		if p.ok && p.head == ref {
+			if err := p.advance(); err != nil {
+				return txRef{}, false, err
+			}
+		}
+	}
+	return ref, true, nil
+}
+
+func (u *unionIterator) release() {
+	for _, it := range u.iterators {
+		it.release()
+	}
+}
+
+// intersectionIterator iterates over the references of all of its iterators
+type intersectionIterator struct {
+	iterators []refIterator
+	peeks     []*peekIterator
+}
+
+func (in *intersectionIterator) next() (txRef, bool, error) {
+	if in.peeks == nil {
+		peeks, err := peekAll(in.iterators)
+		if err != nil {
+			return txRef{}, false, err
+		}
+		in.peeks = peeks
+	}
+
+	for {
+		// the oldest head is the most recent reference all iterators may hold
+		var target txRef
+		for i, p := range in.peeks {
+			if !p.ok {
+				return txRef{}, false, nil
+			}
+			if i == 0 || target.less(p.head) {
+				target = p.head
+			}
+		}
+
+		matched := true
+		for _, p := range in.peeks {
+			for p.ok && p.head.less(target) {
+				if err := p.advance(); err != nil {
+					return txRef{}, false, err
+				}
+			}
+			if !p.ok || p.head != target {
+				matched = false
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		for _, p := range in.peeks {
+			if err := p.advance(); err != nil {
+				return txRef{}, false, err
+			}
+		}
+		return target, true, nil
+	}
+}
+
+func (in *intersectionIterator) release() {
+	for _, it := range in.iterators {
+		it.release()
+	}
+}
@@ -0,0 +1,157 @@
+package txindex
+
+import (
+	"errors"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const (
+	// DefaultLimit is the number of transactions returned by a search without limit
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of transactions returned by a search
+	MaxLimit = 1000
+)
+
+var errNotIndexed = errors.New("no block is indexed yet")
+
+// Search returns the indexed transactions matching [request], from the most
+// recent to the oldest. Conditions apply to the operations of a transaction:
+// a transaction matches a condition if any of its operations does. With the
+// "and" operator, the default, a transaction must match every condition, and
+// with "or" it must match at least one.
+//
+// The references of all matching transactions are scanned to count them, while
+// only the transactions of the requested page are read.
+//
+// [statuses] lists the operation statuses of the network, used to translate
+// the success condition into status conditions.
+func (i *Index) Search(
+	request *types.SearchTransactionsRequest,
+	statuses []*types.OperationStatus,
+) (*types.SearchTransactionsResponse, error) {
+	head, err := i.Head()
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, errNotIndexed
+	}
+
+	maxBlock := head.Index
+	if request.MaxBlock != nil && *request.MaxBlock < maxBlock {
+		maxBlock = *request.MaxBlock
+	}
+
+	offset := int64(0)
+	if request.Offset != nil && *request.Offset > 0 {
+		offset = *request.Offset
+	}
+	limit := int64(DefaultLimit)
+	if request.Limit != nil && *request.Limit > 0 {
+		limit = *request.Limit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	matches, err := i.match(request, statuses, maxBlock)
+	if err != nil {
+		return nil, err
+	}
+	defer matches.release()
+
+	refs := []txRef{}
+	total := int64(0)
+	for {
+		ref, ok, err := matches.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		if total >= offset && total < offset+limit {
+			refs = append(refs, ref)
+		}
+		total++
+	}
+
+	resp := &types.SearchTransactionsResponse{
+		Transactions: []*types.BlockTransaction{},
+		TotalCount:   total,
+	}
+	if total > offset+limit {
+		resp.NextOffset = types.Int64(offset + limit)
+	}
+
+	for _, ref := range refs {
+		tx, err := i.transaction(ref)
+		if err != nil {
+			return nil, err
+		}
+		resp.Transactions = append(resp.Transactions, tx)
+	}
+	return resp, nil
+}
+
+// match returns an iterator over the references to the transactions matching
+// the conditions of [request] included up to block [maxBlock]
+func (i *Index) match(
+	request *types.SearchTransactionsRequest,
+	statuses []*types.OperationStatus,
+	maxBlock int64,
+) (refIterator, error) {
+	conditions := [][]byte{}
+	if request.AccountIdentifier != nil {
+		conditions = append(conditions, indexKey(accountPrefix, types.Hash(request.AccountIdentifier)))
+	}
+	if request.Address != nil {
+		conditions = append(conditions, indexKey(addressPrefix, *request.Address))
+	}
+	if request.Currency != nil {
+		conditions = append(conditions, indexKey(currencyPrefix, types.Hash(request.Currency)))
+	}
+	if request.Type != nil {
+		conditions = append(conditions, indexKey(typePrefix, *request.Type))
+	}
+	if request.Status != nil {
+		conditions = append(conditions, indexKey(statusPrefix, *request.Status))
+	}
+	if request.CoinIdentifier != nil {
+		conditions = append(conditions, indexKey(coinPrefix, request.CoinIdentifier.Identifier))
+	}
+
+	iterators := make([]refIterator, 0, len(conditions)+2)
+	for _, key := range conditions {
+		iterators = append(iterators, i.refs(key, maxBlock))
+	}
+
+	if request.TransactionIdentifier != nil {
+		refs, err := i.txHashRefs(request.TransactionIdentifier.Hash, maxBlock)
+		if err != nil {
+			return nil, err
+		}
+		iterators = append(iterators, refs)
+	}
+
+	if request.Success != nil {
+		statusIterators := []refIterator{}
+		for _, status := range statuses {
+			if status.Successful == *request.Success {
+				statusIterators = append(statusIterators, i.refs(indexKey(statusPrefix, status.Status), maxBlock))
+			}
+		}
+		iterators = append(iterators, &unionIterator{iterators: statusIterators})
+	}
+
+	// without conditions, every transaction matches
+	if len(iterators) == 0 {
+		return i.refs([]byte{txPrefix}, maxBlock), nil
+	}
+
+	if request.Operator != nil && *request.Operator == types.OR {
+		return &unionIterator{iterators: iterators}, nil
+	}
+	return &intersectionIterator{iterators: iterators}, nil
+}
@@ -0,0 +1,52 @@
+package txindex
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/ava-labs/avalanche-rosetta/mapper"
+	"github.com/ava-labs/avalanche-rosetta/service"
+)
+
+var _ server.SearchAPIServicer = &Service{}
+
+// Service implements the /search/transactions endpoint from the indexes of the
+// networks it serves
+type Service struct {
+	indexes map[string]*Index
+}
+
+// NewService returns a new Service without any network
+func NewService() *Service {
+	return &Service{
+		indexes: map[string]*Index{},
+	}
+}
+
+// AddNetwork serves the searches on [network] from [index]
+func (s *Service) AddNetwork(network *types.NetworkIdentifier, index *Index) {
+	s.indexes[types.Hash(network)] = index
+}
+
+// SearchTransactions implements the /search/transactions endpoint
+func (s *Service) SearchTransactions(
+	_ context.Context,
+	request *types.SearchTransactionsRequest,
+) (*types.SearchTransactionsResponse, *types.Error) {
+	index, ok := s.indexes[types.Hash(request.NetworkIdentifier)]
+	if !ok {
+		return nil, service.WrapError(service.ErrNotSupported, "transactions of this network are not indexed")
+	}
+
+	resp, err := index.Search(request, mapper.OperationStatuses)
+	if errors.Is(err, errNotIndexed) {
+		return nil, service.WrapError(service.ErrNotReady, err)
+	}
+	if err != nil {
+		return nil, service.WrapError(service.ErrInternalError, err)
+	}
+	return resp, nil
+}
@@ -24,6 +24,15 @@ type options struct {
 	Currency               *types.Currency  `json:"currency,omitempty"`
 	Metadata               *metadataOptions `json:"metadata,omitempty"`
 
+	// SpeedUpTxHash and CancelTxHash are the hash of the pending transaction
+	// replaced by the constructed one
+	SpeedUpTxHash string `json:"speed_up_tx_hash,omitempty"`
+	CancelTxHash  string `json:"cancel_tx_hash,omitempty"`
+
+	// Permit is set if the allowance of [To] is signed off by [From] in an
+	// EIP-2612 permit rather than approved by a transaction
+	Permit *permitOptions `json:"permit,omitempty"`
+
 	// Although [metadataOptions] should be used to specify the following fields,
 	// we specify it directly on [Options] to maintain compatibility with
 	// [rosetta-geth-sdk].
@@ -32,6 +41,7 @@ type options struct {
 	ContractAddress string      `json:"contract_address,omitempty"`
 	MethodSignature string      `json:"method_signature,omitempty"`
 	MethodArgs      interface{} `json:"method_args,omitempty"`
+	ContractABI     string      `json:"contract_abi,omitempty"`
 	ContractData    string      `json:"data,omitempty"`
 }
 
@@ -46,9 +56,15 @@ type optionsWire struct {
 	Currency               *types.Currency  `json:"currency,omitempty"`
 	Metadata               *metadataOptions `json:"metadata,omitempty"`
 
+	SpeedUpTxHash string `json:"speed_up_tx_hash,omitempty"`
+	CancelTxHash  string `json:"cancel_tx_hash,omitempty"`
+
+	Permit *permitOptions `json:"permit,omitempty"`
+
 	ContractAddress string      `json:"contract_address,omitempty"`
 	MethodSignature string      `json:"method_signature,omitempty"`
 	MethodArgs      interface{} `json:"method_args,omitempty"`
+	ContractABI     string      `json:"contract_abi,omitempty"`
 	ContractData    string      `json:"data,omitempty"`
 }
 
@@ -63,9 +79,13 @@ func (o *options) MarshalJSON() ([]byte, error) {
 		SuggestedFeeMultiplier: o.SuggestedFeeMultiplier,
 		Currency:               o.Currency,
 		Metadata:               o.Metadata,
+		SpeedUpTxHash:          o.SpeedUpTxHash,
+		CancelTxHash:           o.CancelTxHash,
+		Permit:                 o.Permit,
 		ContractAddress:        o.ContractAddress,
 		MethodSignature:        o.MethodSignature,
 		MethodArgs:             o.MethodArgs,
+		ContractABI:            o.ContractABI,
 		ContractData:           o.ContractData,
 	}
 
@@ -96,9 +116,13 @@ func (o *options) UnmarshalJSON(data []byte) error {
 	o.SuggestedFeeMultiplier = ow.SuggestedFeeMultiplier
 	o.Currency = ow.Currency
 	o.Metadata = ow.Metadata
+	o.SpeedUpTxHash = ow.SpeedUpTxHash
+	o.CancelTxHash = ow.CancelTxHash
+	o.Permit = ow.Permit
 	o.ContractAddress = ow.ContractAddress
 	o.MethodSignature = ow.MethodSignature
 	o.MethodArgs = ow.MethodArgs
+	o.ContractABI = ow.ContractABI
 	o.ContractData = ow.ContractData
 
 	// Manually decode any [big.Int]
@@ -141,9 +165,15 @@ type metadata struct {
 
 	UnwrapBridgeTx bool `json:"bridge_unwrap"`
 
+	// ReplacedTxHash is the hash of the pending transaction replaced by the
+	// constructed one, a 0-value self-transfer if Cancel is set
+	ReplacedTxHash string `json:"replaced_tx_hash,omitempty"`
+	Cancel         bool   `json:"cancel,omitempty"`
+
 	ContractData    string      `json:"data,omitempty"`
 	MethodSignature string      `json:"method_signature,omitempty"`
 	MethodArgs      interface{} `json:"method_args,omitempty"`
+	ContractABI     string      `json:"contract_abi,omitempty"`
 }
 
 type metadataWire struct {
@@ -153,9 +183,13 @@ type metadataWire struct {
 
 	UnwrapBridgeTx bool `json:"bridge_unwrap"`
 
+	ReplacedTxHash string `json:"replaced_tx_hash,omitempty"`
+	Cancel         bool   `json:"cancel,omitempty"`
+
 	ContractData    string      `json:"data,omitempty"`
 	MethodSignature string      `json:"method_signature,omitempty"`
 	MethodArgs      interface{} `json:"method_args,omitempty"`
+	ContractABI     string      `json:"contract_abi,omitempty"`
 }
 
 func (m *metadata) MarshalJSON() ([]byte, error) {
@@ -164,9 +198,12 @@ func (m *metadata) MarshalJSON() ([]byte, error) {
 		GasPrice:        hexutil.EncodeBig(m.GasPrice),
 		GasLimit:        hexutil.Uint64(m.GasLimit).String(),
 		UnwrapBridgeTx:  m.UnwrapBridgeTx,
+		ReplacedTxHash:  m.ReplacedTxHash,
+		Cancel:          m.Cancel,
 		ContractData:    m.ContractData,
 		MethodSignature: m.MethodSignature,
 		MethodArgs:      m.MethodArgs,
+		ContractABI:     m.ContractABI,
 	}
 
 	return json.Marshal(mw)
@@ -179,9 +216,12 @@ func (m *metadata) UnmarshalJSON(data []byte) error {
 	}
 
 	m.UnwrapBridgeTx = mw.UnwrapBridgeTx
+	m.ReplacedTxHash = mw.ReplacedTxHash
+	m.Cancel = mw.Cancel
 	m.ContractData = mw.ContractData
 	m.MethodSignature = mw.MethodSignature
 	m.MethodArgs = mw.MethodArgs
+	m.ContractABI = mw.ContractABI
 
 	gasPrice, err := hexutil.DecodeBig(mw.GasPrice)
 	if err != nil {
@@ -239,6 +279,11 @@ type transaction struct {
 	GasLimit uint64          `json:"gas"`
 	ChainID  *big.Int        `json:"chain_id"`
 	Currency *types.Currency `json:"currency,omitempty"`
+
+	// MethodSignature and ContractABI describe the contract call [Data] is
+	// decoded as by /construction/parse
+	MethodSignature string `json:"method_signature,omitempty"`
+	ContractABI     string `json:"contract_abi,omitempty"`
 }
 
 type transactionWire struct {
@@ -251,6 +296,9 @@ type transactionWire struct {
 	GasLimit string          `json:"gas"`
 	ChainID  string          `json:"chain_id"`
 	Currency *types.Currency `json:"currency,omitempty"`
+
+	MethodSignature string `json:"method_signature,omitempty"`
+	ContractABI     string `json:"contract_abi,omitempty"`
 }
 
 func (t *transaction) MarshalJSON() ([]byte, error) {
@@ -264,6 +312,9 @@ func (t *transaction) MarshalJSON() ([]byte, error) {
 		GasLimit: hexutil.EncodeUint64(t.GasLimit),
 		ChainID:  hexutil.EncodeBig(t.ChainID),
 		Currency: t.Currency,
+
+		MethodSignature: t.MethodSignature,
+		ContractABI:     t.ContractABI,
 	}
 
 	return json.Marshal(tw)
@@ -315,6 +366,8 @@ func (t *transaction) UnmarshalJSON(data []byte) error {
 	t.ChainID = chainID
 	t.GasPrice = gasPrice
 	t.Currency = tw.Currency
+	t.MethodSignature = tw.MethodSignature
+	t.ContractABI = tw.ContractABI
 	return nil
 }
 
@@ -358,6 +411,8 @@ func has0xPrefix(str string) bool {
 type signedTransactionWrapper struct {
 	SignedTransaction []byte          `json:"signed_tx"`
 	Currency          *types.Currency `json:"currency,omitempty"`
+	MethodSignature   string          `json:"method_signature,omitempty"`
+	ContractABI       string          `json:"contract_abi,omitempty"`
 }
 
 func (t *signedTransactionWrapper) UnmarshalJSON(data []byte) error {
@@ -369,6 +424,8 @@ func (t *signedTransactionWrapper) UnmarshalJSON(data []byte) error {
 	tw := struct {
 		SignedTransaction []byte          `json:"signed_tx"`
 		Currency          *types.Currency `json:"currency,omitempty"`
+		MethodSignature   string          `json:"method_signature,omitempty"`
+		ContractABI       string          `json:"contract_abi,omitempty"`
 	}{}
 	if err := json.Unmarshal(data, &tw); err != nil {
 		return err
@@ -378,6 +435,8 @@ func (t *signedTransactionWrapper) UnmarshalJSON(data []byte) error {
 	if len(tw.SignedTransaction) > 0 {
 		t.SignedTransaction = tw.SignedTransaction
 		t.Currency = tw.Currency
+		t.MethodSignature = tw.MethodSignature
+		t.ContractABI = tw.ContractABI
 		return nil
 	}
 